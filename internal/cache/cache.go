@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache is a concurrency-safe cache of values which expire after a fixed duration, holding at most
+// a fixed number of entries - once full, the entry which is closest to expiring is evicted.
+//
+// Alongside the cached values, a lock can be taken out for each key (see Cache.Lock) so that only
+// a single lookup is performed for a given key at a time, whilst lookups for different keys can
+// happen concurrently.
+type Cache[T any] struct {
+	maxEntries int
+	ttl        time.Duration
+
+	// now returns the current time, and is overridden in tests
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry[T]
+	locks   map[string]*keyLock
+}
+
+type entry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+type keyLock struct {
+	sync.Mutex
+
+	// references is the number of callers holding or waiting on this lock, which allows the
+	// lock to be removed once it's no longer in use
+	references int
+}
+
+// New returns a Cache whose entries expire after the specified `ttl`, and which holds at most
+// `maxEntries` entries at any one time
+func New[T any](ttl time.Duration, maxEntries int) *Cache[T] {
+	return &Cache[T]{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		now:        time.Now,
+		entries:    map[string]entry[T]{},
+		locks:      map[string]*keyLock{},
+	}
+}
+
+// Get returns the value for the specified key, if it exists and hasn't expired
+func (c *Cache[T]) Get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.entries[key]
+	if !ok {
+		var empty T
+		return empty, false
+	}
+
+	if !c.now().Before(existing.expiresAt) {
+		delete(c.entries, key)
+		var empty T
+		return empty, false
+	}
+
+	return existing.value, true
+}
+
+// Set stores the value for the specified key, evicting the entry closest to expiring if the Cache is full
+func (c *Cache[T]) Set(key string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		c.evict(now)
+	}
+
+	c.entries[key] = entry[T]{
+		value:     value,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// Delete removes the value for the specified key from the Cache
+func (c *Cache[T]) Delete(key string) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+// Len returns the number of entries within the Cache, including any which have expired but
+// haven't yet been removed
+func (c *Cache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// Lock acquires the lock for the specified key, returning a function which releases it
+func (c *Cache[T]) Lock(key string) func() {
+	c.mu.Lock()
+	lock, ok := c.locks[key]
+	if !ok {
+		lock = &keyLock{}
+		c.locks[key] = lock
+	}
+	lock.references++
+	c.mu.Unlock()
+
+	lock.Lock()
+
+	return func() {
+		lock.Unlock()
+
+		c.mu.Lock()
+		lock.references--
+		if lock.references == 0 {
+			delete(c.locks, key)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// evict removes any expired entries, and if none have expired, the entry which is closest to expiring.
+// This must be called whilst holding `c.mu`.
+func (c *Cache[T]) evict(now time.Time) {
+	oldestKey := ""
+	var oldestExpiry time.Time
+	for k, v := range c.entries {
+		if !now.Before(v.expiresAt) {
+			delete(c.entries, k)
+			continue
+		}
+
+		if oldestKey == "" || v.expiresAt.Before(oldestExpiry) {
+			oldestKey = k
+			oldestExpiry = v.expiresAt
+		}
+	}
+
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, oldestKey)
+	}
+}
@@ -0,0 +1,193 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheGetAndSet(t *testing.T) {
+	c := New[string](time.Minute, 10)
+
+	if _, ok := c.Get("example"); ok {
+		t.Fatalf("expected `example` to not exist in an empty cache")
+	}
+
+	c.Set("example", "first")
+	if v, ok := c.Get("example"); !ok || v != "first" {
+		t.Fatalf("expected `example` to be `first` but got %q (exists: %t)", v, ok)
+	}
+
+	c.Set("example", "second")
+	if v, ok := c.Get("example"); !ok || v != "second" {
+		t.Fatalf("expected `example` to be `second` but got %q (exists: %t)", v, ok)
+	}
+
+	c.Delete("example")
+	if _, ok := c.Get("example"); ok {
+		t.Fatalf("expected `example` to have been deleted")
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New[string](time.Minute, 10)
+	c.now = func() time.Time {
+		return now
+	}
+
+	c.Set("example", "value")
+
+	now = now.Add(59 * time.Second)
+	if _, ok := c.Get("example"); !ok {
+		t.Fatalf("expected `example` to exist before the TTL has passed")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := c.Get("example"); ok {
+		t.Fatalf("expected `example` to have expired once the TTL has passed")
+	}
+	if c.Len() != 0 {
+		t.Fatalf("expected the expired entry to be removed but got %d entries", c.Len())
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New[int](time.Minute, 3)
+	c.now = func() time.Time {
+		return now
+	}
+
+	for i := 0; i < 3; i++ {
+		c.Set(fmt.Sprintf("key%d", i), i)
+		now = now.Add(time.Second)
+	}
+
+	// updating an existing key shouldn't evict anything
+	c.Set("key1", 10)
+	if c.Len() != 3 {
+		t.Fatalf("expected 3 entries but got %d", c.Len())
+	}
+
+	// the cache is full, so the entry closest to expiring (key0) should be evicted
+	c.Set("key3", 3)
+	if c.Len() != 3 {
+		t.Fatalf("expected 3 entries but got %d", c.Len())
+	}
+	if _, ok := c.Get("key0"); ok {
+		t.Fatalf("expected `key0` to have been evicted")
+	}
+	for _, key := range []string{"key1", "key2", "key3"} {
+		if _, ok := c.Get(key); !ok {
+			t.Fatalf("expected %q to exist", key)
+		}
+	}
+
+	// expired entries should be evicted before any which are still valid
+	now = now.Add(time.Minute - 2*time.Second)
+	c.Set("key4", 4)
+	if _, ok := c.Get("key2"); ok {
+		t.Fatalf("expected `key2` to have expired")
+	}
+	for _, key := range []string{"key1", "key3", "key4"} {
+		if _, ok := c.Get(key); !ok {
+			t.Fatalf("expected %q to exist", key)
+		}
+	}
+}
+
+func TestCacheLockIsPerKey(t *testing.T) {
+	c := New[string](time.Minute, 10)
+
+	unlockFirst := c.Lock("first")
+
+	// a different key can be locked whilst the first is held
+	done := make(chan struct{})
+	go func() {
+		unlock := c.Lock("second")
+		unlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting to lock `second` whilst `first` was locked")
+	}
+
+	// whereas the same key blocks until it's been released
+	acquired := make(chan struct{})
+	released := make(chan struct{})
+	go func() {
+		unlock := c.Lock("first")
+		close(acquired)
+		unlock()
+		close(released)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatalf("expected the lock for `first` to block whilst held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlockFirst()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting to lock `first` after it was released")
+	}
+	<-released
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.locks) != 0 {
+		t.Fatalf("expected the locks to be removed once released but got %d", len(c.locks))
+	}
+}
+
+func TestCacheConcurrentAccess(t *testing.T) {
+	// this is intended to be run with `-race` to detect any unguarded access
+	c := New[int](time.Minute, 100)
+	bounded := New[int](time.Minute, 50)
+
+	lookups := 0
+	lookupsLock := sync.Mutex{}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			bounded.Set(fmt.Sprintf("key%d", i), i)
+			if i%20 == 0 {
+				bounded.Delete(fmt.Sprintf("key%d", i))
+			}
+
+			key := fmt.Sprintf("key%d", i%10)
+			unlock := c.Lock(key)
+			defer unlock()
+
+			if _, ok := c.Get(key); ok {
+				return
+			}
+
+			lookupsLock.Lock()
+			lookups++
+			lookupsLock.Unlock()
+
+			c.Set(key, i)
+		}(i)
+	}
+	wg.Wait()
+
+	if lookups != 10 {
+		t.Fatalf("expected a single lookup for each of the 10 keys but got %d", lookups)
+	}
+	if bounded.Len() > 50 {
+		t.Fatalf("expected at most 50 entries but got %d", bounded.Len())
+	}
+}
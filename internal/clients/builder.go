@@ -56,7 +56,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf(azureStackEnvironmentError)
 	}
 
-	var resourceManagerAuth, storageAuth, synapseAuth, batchManagementAuth, keyVaultAuth auth.Authorizer
+	var resourceManagerAuth, storageAuth, synapseAuth, batchManagementAuth, keyVaultAuth, managedHSMAuth auth.Authorizer
 
 	resourceManagerAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ResourceManager)
 	if err != nil {
@@ -82,6 +82,15 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		log.Printf("[DEBUG] Skipping building the Synapse Authorizer since this is not supported in the current Azure Environment")
 	}
 
+	if _, ok := builder.AuthConfig.Environment.ManagedHSM.ResourceIdentifier(); ok {
+		managedHSMAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ManagedHSM)
+		if err != nil {
+			return nil, fmt.Errorf("unable to build authorizer for Managed HSM API: %+v", err)
+		}
+	} else {
+		log.Printf("[DEBUG] Skipping building the Managed HSM Authorizer since this is not supported in the current Azure Environment")
+	}
+
 	if _, ok := builder.AuthConfig.Environment.Batch.ResourceIdentifier(); ok {
 		batchManagementAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Batch)
 		if err != nil {
@@ -152,6 +161,10 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		ResourceManagerEndpoint: *resourceManagerEndpoint,
 	}
 
+	if managedHSMAuth != nil {
+		o.ManagedHSMAuthorizer = authWrapper.AutorestAuthorizer(managedHSMAuth).BearerAuthorizerCallback()
+	}
+
 	if err := client.Build(ctx, o); err != nil {
 		return nil, fmt.Errorf("building Client: %+v", err)
 	}
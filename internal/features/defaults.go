@@ -32,10 +32,15 @@ func Default() UserFeatures {
 			PermanentlyDeleteOnDestroy: true,
 		},
 		ManagedDisk: ManagedDiskFeatures{
-			ExpandWithoutDowntime: true,
+			ExpandWithoutDowntime:        true,
+			GracefulShutdownBeforeExpand: true,
 		},
 		ResourceGroup: ResourceGroupFeatures{
 			PreventDeletionIfContainsResources: true,
+			ForceDeleteVirtualMachines:         false,
+		},
+		Subscription: SubscriptionFeatures{
+			PreventCancellationOnDestroy: false,
 		},
 		TemplateDeployment: TemplateDeploymentFeatures{
 			DeleteNestedItemsDuringDeletion: true,
@@ -12,6 +12,7 @@ type UserFeatures struct {
 	LogAnalyticsWorkspace  LogAnalyticsWorkspaceFeatures
 	ResourceGroup          ResourceGroupFeatures
 	ManagedDisk            ManagedDiskFeatures
+	Subscription           SubscriptionFeatures
 }
 
 type CognitiveAccountFeatures struct {
@@ -52,6 +53,12 @@ type LogAnalyticsWorkspaceFeatures struct {
 
 type ResourceGroupFeatures struct {
 	PreventDeletionIfContainsResources bool
+	DeletableNestedResourceTypes       []string
+	ForceDeleteVirtualMachines         bool
+}
+
+type SubscriptionFeatures struct {
+	PreventCancellationOnDestroy bool
 }
 
 type ApiManagementFeatures struct {
@@ -64,7 +71,8 @@ type ApplicationInsightFeatures struct {
 }
 
 type ManagedDiskFeatures struct {
-	ExpandWithoutDowntime bool
+	ExpandWithoutDowntime        bool
+	GracefulShutdownBeforeExpand bool
 }
 
 type AppConfigurationFeatures struct {
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 )
 
 func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
@@ -186,6 +187,20 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 			},
 		},
 
+		"subscription": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"prevent_cancellation_on_destroy": {
+						Type:     pluginsdk.TypeBool,
+						Required: true,
+					},
+				},
+			},
+		},
+
 		"template_deployment": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
@@ -261,6 +276,21 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 						Optional: true,
 						Default:  os.Getenv("TF_ACC") == "",
 					},
+
+					"deletable_nested_resource_types": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"force_delete_virtual_machines": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
 				},
 			},
 		},
@@ -276,6 +306,12 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 						Optional: true,
 						Default:  true,
 					},
+
+					"graceful_shutdown_before_expand": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
 				},
 			},
 		},
@@ -404,6 +440,16 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["subscription"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			subscriptionRaw := items[0].(map[string]interface{})
+			if v, ok := subscriptionRaw["prevent_cancellation_on_destroy"]; ok {
+				featuresMap.Subscription.PreventCancellationOnDestroy = v.(bool)
+			}
+		}
+	}
+
 	if raw, ok := val["template_deployment"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 {
@@ -453,6 +499,18 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 			if v, ok := resourceGroupRaw["prevent_deletion_if_contains_resources"]; ok {
 				featuresMap.ResourceGroup.PreventDeletionIfContainsResources = v.(bool)
 			}
+			if v, ok := resourceGroupRaw["deletable_nested_resource_types"]; ok && v != nil {
+				resourceTypes := make([]string, 0)
+				for _, item := range v.(*pluginsdk.Set).List() {
+					resourceTypes = append(resourceTypes, item.(string))
+				}
+				if len(resourceTypes) > 0 {
+					featuresMap.ResourceGroup.DeletableNestedResourceTypes = resourceTypes
+				}
+			}
+			if v, ok := resourceGroupRaw["force_delete_virtual_machines"]; ok {
+				featuresMap.ResourceGroup.ForceDeleteVirtualMachines = v.(bool)
+			}
 		}
 	}
 
@@ -463,6 +521,9 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 			if v, ok := managedDiskRaw["expand_without_downtime"]; ok {
 				featuresMap.ManagedDisk.ExpandWithoutDowntime = v.(bool)
 			}
+			if v, ok := managedDiskRaw["graceful_shutdown_before_expand"]; ok {
+				featuresMap.ManagedDisk.GracefulShutdownBeforeExpand = v.(bool)
+			}
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 func TestExpandFeatures(t *testing.T) {
@@ -47,7 +48,8 @@ func TestExpandFeatures(t *testing.T) {
 					PermanentlyDeleteOnDestroy: true,
 				},
 				ManagedDisk: features.ManagedDiskFeatures{
-					ExpandWithoutDowntime: true,
+					ExpandWithoutDowntime:        true,
+					GracefulShutdownBeforeExpand: true,
 				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: true,
@@ -113,7 +115,8 @@ func TestExpandFeatures(t *testing.T) {
 					},
 					"managed_disk": []interface{}{
 						map[string]interface{}{
-							"expand_without_downtime": true,
+							"expand_without_downtime":         true,
+							"graceful_shutdown_before_expand": true,
 						},
 					},
 					"network": []interface{}{
@@ -126,6 +129,11 @@ func TestExpandFeatures(t *testing.T) {
 							"prevent_deletion_if_contains_resources": true,
 						},
 					},
+					"subscription": []interface{}{
+						map[string]interface{}{
+							"prevent_cancellation_on_destroy": true,
+						},
+					},
 					"template_deployment": []interface{}{
 						map[string]interface{}{
 							"delete_nested_items_during_deletion": true,
@@ -177,11 +185,15 @@ func TestExpandFeatures(t *testing.T) {
 					PermanentlyDeleteOnDestroy: true,
 				},
 				ManagedDisk: features.ManagedDiskFeatures{
-					ExpandWithoutDowntime: true,
+					ExpandWithoutDowntime:        true,
+					GracefulShutdownBeforeExpand: true,
 				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: true,
 				},
+				Subscription: features.SubscriptionFeatures{
+					PreventCancellationOnDestroy: true,
+				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: true,
 				},
@@ -243,7 +255,8 @@ func TestExpandFeatures(t *testing.T) {
 					},
 					"managed_disk": []interface{}{
 						map[string]interface{}{
-							"expand_without_downtime": false,
+							"expand_without_downtime":         false,
+							"graceful_shutdown_before_expand": false,
 						},
 					},
 					"network_locking": []interface{}{
@@ -256,6 +269,11 @@ func TestExpandFeatures(t *testing.T) {
 							"prevent_deletion_if_contains_resources": false,
 						},
 					},
+					"subscription": []interface{}{
+						map[string]interface{}{
+							"prevent_cancellation_on_destroy": false,
+						},
+					},
 					"template_deployment": []interface{}{
 						map[string]interface{}{
 							"delete_nested_items_during_deletion": false,
@@ -307,7 +325,8 @@ func TestExpandFeatures(t *testing.T) {
 					PermanentlyDeleteOnDestroy: false,
 				},
 				ManagedDisk: features.ManagedDiskFeatures{
-					ExpandWithoutDowntime: false,
+					ExpandWithoutDowntime:        false,
+					GracefulShutdownBeforeExpand: false,
 				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: false,
@@ -1128,6 +1147,48 @@ func TestExpandFeaturesResourceGroup(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Deletable Nested Resource Types",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_group": []interface{}{
+						map[string]interface{}{
+							"prevent_deletion_if_contains_resources": true,
+							"deletable_nested_resource_types": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{
+								"Microsoft.Network/networkWatchers",
+							}),
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ResourceGroup: features.ResourceGroupFeatures{
+					PreventDeletionIfContainsResources: true,
+					DeletableNestedResourceTypes: []string{
+						"Microsoft.Network/networkWatchers",
+					},
+				},
+			},
+		},
+		{
+			Name: "Force Delete Virtual Machines Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_group": []interface{}{
+						map[string]interface{}{
+							"prevent_deletion_if_contains_resources": false,
+							"force_delete_virtual_machines":          true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ResourceGroup: features.ResourceGroupFeatures{
+					PreventDeletionIfContainsResources: false,
+					ForceDeleteVirtualMachines:         true,
+				},
+			},
+		},
 	}
 
 	for _, testCase := range testData {
@@ -1139,6 +1200,71 @@ func TestExpandFeaturesResourceGroup(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesSubscription(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"subscription": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				Subscription: features.SubscriptionFeatures{
+					PreventCancellationOnDestroy: false,
+				},
+			},
+		},
+		{
+			Name: "Prevent Cancellation On Destroy Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"subscription": []interface{}{
+						map[string]interface{}{
+							"prevent_cancellation_on_destroy": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Subscription: features.SubscriptionFeatures{
+					PreventCancellationOnDestroy: true,
+				},
+			},
+		},
+		{
+			Name: "Prevent Cancellation On Destroy Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"subscription": []interface{}{
+						map[string]interface{}{
+							"prevent_cancellation_on_destroy": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Subscription: features.SubscriptionFeatures{
+					PreventCancellationOnDestroy: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.Subscription, testCase.Expected.Subscription) {
+			t.Fatalf("Expected %+v but got %+v", result.Subscription, testCase.Expected.Subscription)
+		}
+	}
+}
+
 func TestExpandFeaturesManagedDisk(t *testing.T) {
 	testData := []struct {
 		Name     string
@@ -1155,7 +1281,8 @@ func TestExpandFeaturesManagedDisk(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				ManagedDisk: features.ManagedDiskFeatures{
-					ExpandWithoutDowntime: true,
+					ExpandWithoutDowntime:        true,
+					GracefulShutdownBeforeExpand: true,
 				},
 			},
 		},
@@ -1172,7 +1299,8 @@ func TestExpandFeaturesManagedDisk(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				ManagedDisk: features.ManagedDiskFeatures{
-					ExpandWithoutDowntime: true,
+					ExpandWithoutDowntime:        true,
+					GracefulShutdownBeforeExpand: true,
 				},
 			},
 		},
@@ -1189,7 +1317,27 @@ func TestExpandFeaturesManagedDisk(t *testing.T) {
 			},
 			Expected: features.UserFeatures{
 				ManagedDisk: features.ManagedDiskFeatures{
-					ExpandWithoutDowntime: false,
+					ExpandWithoutDowntime:        false,
+					GracefulShutdownBeforeExpand: true,
+				},
+			},
+		},
+		{
+			Name: "Graceful Shutdown Before Expand Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"managed_disk": []interface{}{
+						map[string]interface{}{
+							"expand_without_downtime":         true,
+							"graceful_shutdown_before_expand": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagedDisk: features.ManagedDiskFeatures{
+					ExpandWithoutDowntime:        true,
+					GracefulShutdownBeforeExpand: false,
 				},
 			},
 		},
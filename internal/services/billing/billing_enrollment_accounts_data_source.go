@@ -0,0 +1,125 @@
+package billing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/billing/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceBillingEnrollmentAccounts() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceBillingEnrollmentAccountsRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"billing_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"enrollment_accounts": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"billing_scope_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"display_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"account_owner": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"cost_center": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBillingEnrollmentAccountsRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Billing.AccountsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	billingAccountName := d.Get("billing_account_name").(string)
+
+	// the Enrollment Accounts are only returned when explicitly expanded
+	resp, err := client.Get(ctx, billingAccountName, "enrollmentAccounts")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Billing Account %q was not found", billingAccountName)
+		}
+
+		return fmt.Errorf("retrieving Billing Account %q: %+v", billingAccountName, err)
+	}
+
+	enrollmentAccounts := make([]interface{}, 0)
+	if props := resp.AccountProperties; props != nil && props.EnrollmentAccounts != nil {
+		for _, item := range *props.EnrollmentAccounts {
+			if item.Name == nil {
+				continue
+			}
+
+			id := parse.NewEnrollmentBillingScopeID(billingAccountName, *item.Name)
+			displayName := ""
+			accountOwner := ""
+			costCenter := ""
+			status := ""
+			if itemProps := item.EnrollmentAccountProperties; itemProps != nil {
+				displayName = utils.NormalizeNilableString(itemProps.AccountName)
+				accountOwner = utils.NormalizeNilableString(itemProps.AccountOwner)
+				costCenter = utils.NormalizeNilableString(itemProps.CostCenter)
+				status = utils.NormalizeNilableString(itemProps.Status)
+			}
+
+			enrollmentAccounts = append(enrollmentAccounts, map[string]interface{}{
+				"billing_scope_id": id.ID(),
+				"name":             *item.Name,
+				"display_name":     displayName,
+				"account_owner":    accountOwner,
+				"cost_center":      costCenter,
+				"status":           status,
+			})
+		}
+	}
+
+	d.SetId(fmt.Sprintf("/providers/Microsoft.Billing/billingAccounts/%s/enrollmentAccounts", billingAccountName))
+
+	if err := d.Set("enrollment_accounts", enrollmentAccounts); err != nil {
+		return fmt.Errorf("setting `enrollment_accounts`: %+v", err)
+	}
+
+	return nil
+}
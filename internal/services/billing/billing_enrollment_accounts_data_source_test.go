@@ -0,0 +1,43 @@
+package billing_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type BillingEnrollmentAccountsDataSource struct{}
+
+func TestAccBillingEnrollmentAccountsDataSource_basic(t *testing.T) {
+	if os.Getenv("ARM_BILLING_ENROLLMENT_ACCOUNT") == "" {
+		t.Skip("skipping tests - no billing enrollment account data provided")
+	}
+
+	data := acceptance.BuildTestData(t, "data.azurerm_billing_enrollment_accounts", "test")
+	r := BillingEnrollmentAccountsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("enrollment_accounts.#").Exists(),
+				check.That(data.ResourceName).Key("enrollment_accounts.0.billing_scope_id").IsNotEmpty(),
+			),
+		},
+	})
+}
+
+func (BillingEnrollmentAccountsDataSource) basic() string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_billing_enrollment_accounts" "test" {
+  billing_account_name = "%s"
+}
+`, os.Getenv("ARM_BILLING_ACCOUNT"))
+}
@@ -0,0 +1,115 @@
+package billing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/billing/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceBillingMCAInvoiceSections() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceBillingMCAInvoiceSectionsRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"billing_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"invoice_sections": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"billing_scope_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"display_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"billing_profile_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"billing_profile_display_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"billing_profile_status": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBillingMCAInvoiceSectionsRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Billing.AccountsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	billingAccountName := d.Get("billing_account_name").(string)
+
+	// only the Invoice Sections which the caller is able to create Subscriptions within are returned
+	iterator, err := client.ListInvoiceSectionsByCreateSubscriptionPermissionComplete(ctx, billingAccountName)
+	if err != nil {
+		return fmt.Errorf("listing Invoice Sections for Billing Account %q: %+v", billingAccountName, err)
+	}
+
+	invoiceSections := make([]interface{}, 0)
+	for iterator.NotDone() {
+		item := iterator.Value()
+		if item.InvoiceSectionID != nil {
+			id, err := parse.MicrosoftCustomerAccountBillingScopeID(*item.InvoiceSectionID)
+			if err != nil {
+				return err
+			}
+
+			invoiceSections = append(invoiceSections, map[string]interface{}{
+				"billing_scope_id":             id.ID(),
+				"name":                         id.InvoiceSectionName,
+				"display_name":                 utils.NormalizeNilableString(item.InvoiceSectionDisplayName),
+				"billing_profile_name":         id.BillingProfileName,
+				"billing_profile_display_name": utils.NormalizeNilableString(item.BillingProfileDisplayName),
+				"billing_profile_status":       string(item.BillingProfileStatus),
+			})
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("listing Invoice Sections for Billing Account %q: %+v", billingAccountName, err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("/providers/Microsoft.Billing/billingAccounts/%s/invoiceSections", billingAccountName))
+
+	if err := d.Set("invoice_sections", invoiceSections); err != nil {
+		return fmt.Errorf("setting `invoice_sections`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package billing_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type BillingMCAInvoiceSectionsDataSource struct{}
+
+func TestAccBillingMCAInvoiceSectionsDataSource_basic(t *testing.T) {
+	if os.Getenv("ARM_BILLING_ACCOUNT") == "" {
+		t.Skip("skipping tests - no billing account data provided")
+	}
+
+	data := acceptance.BuildTestData(t, "data.azurerm_billing_mca_invoice_sections", "test")
+	r := BillingMCAInvoiceSectionsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("invoice_sections.#").Exists(),
+				check.That(data.ResourceName).Key("invoice_sections.0.billing_scope_id").IsNotEmpty(),
+			),
+		},
+	})
+}
+
+func (BillingMCAInvoiceSectionsDataSource) basic() string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_billing_mca_invoice_sections" "test" {
+  billing_account_name = "%s"
+}
+`, os.Getenv("ARM_BILLING_ACCOUNT"))
+}
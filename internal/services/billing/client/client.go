@@ -0,0 +1,19 @@
+package client
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/preview/billing/mgmt/2020-05-01-preview/billing" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+type Client struct {
+	AccountsClient *billing.AccountsClient
+}
+
+func NewClient(o *common.ClientOptions) *Client {
+	accountsClient := billing.NewAccountsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&accountsClient.Client, o.ResourceManagerAuthorizer)
+
+	return &Client{
+		AccountsClient: &accountsClient,
+	}
+}
@@ -28,7 +28,9 @@ func (r Registration) WebsiteCategories() []string {
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
 		"azurerm_billing_enrollment_account_scope": dataSourceBillingEnrollmentAccountScope(),
+		"azurerm_billing_enrollment_accounts":      dataSourceBillingEnrollmentAccounts(),
 		"azurerm_billing_mca_account_scope":        dataSourceBillingMCAAccountScope(),
+		"azurerm_billing_mca_invoice_sections":     dataSourceBillingMCAInvoiceSections(),
 		"azurerm_billing_mpa_account_scope":        dataSourceBillingMPAAccountScope(),
 	}
 }
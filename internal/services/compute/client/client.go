@@ -2,7 +2,6 @@ package client
 
 import (
 	"github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering" // nolint: staticcheck
-	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/galleries"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/galleryapplications"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/galleryapplicationversions"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
@@ -23,34 +22,39 @@ import (
 )
 
 type Client struct {
-	AvailabilitySetsClient           *availabilitysets.AvailabilitySetsClient
-	CapacityReservationsClient       *capacityreservations.CapacityReservationsClient
-	CapacityReservationGroupsClient  *capacityreservationgroups.CapacityReservationGroupsClient
-	DedicatedHostsClient             *dedicatedhosts.DedicatedHostsClient
-	DedicatedHostGroupsClient        *dedicatedhostgroups.DedicatedHostGroupsClient
-	DisksClient                      *disks.DisksClient
-	DiskAccessClient                 *diskaccesses.DiskAccessesClient
-	DiskEncryptionSetsClient         *diskencryptionsets.DiskEncryptionSetsClient
-	GalleriesClient                  *galleries.GalleriesClient
-	GalleryApplicationsClient        *galleryapplications.GalleryApplicationsClient
-	GalleryApplicationVersionsClient *galleryapplicationversions.GalleryApplicationVersionsClient
-	GalleryImagesClient              *compute.GalleryImagesClient
-	GalleryImageVersionsClient       *compute.GalleryImageVersionsClient
-	ImagesClient                     *compute.ImagesClient
-	MarketplaceAgreementsClient      *marketplaceordering.MarketplaceAgreementsClient
-	ProximityPlacementGroupsClient   *proximityplacementgroups.ProximityPlacementGroupsClient
-	SkusClient                       *skus.SkusClient
-	SSHPublicKeysClient              *sshpublickeys.SshPublicKeysClient
-	SnapshotsClient                  *snapshots.SnapshotsClient
-	VirtualMachinesClient            *virtualmachines.VirtualMachinesClient
-	VMExtensionImageClient           *compute.VirtualMachineExtensionImagesClient
-	VMExtensionClient                *compute.VirtualMachineExtensionsClient
-	VMScaleSetClient                 *compute.VirtualMachineScaleSetsClient
-	VMScaleSetExtensionsClient       *compute.VirtualMachineScaleSetExtensionsClient
-	VMScaleSetRollingUpgradesClient  *compute.VirtualMachineScaleSetRollingUpgradesClient
-	VMScaleSetVMsClient              *compute.VirtualMachineScaleSetVMsClient
-	VMClient                         *compute.VirtualMachinesClient
-	VMImageClient                    *compute.VirtualMachineImagesClient
+	AvailabilitySetsClient              *availabilitysets.AvailabilitySetsClient
+	CapacityReservationsClient          *capacityreservations.CapacityReservationsClient
+	CapacityReservationGroupsClient     *capacityreservationgroups.CapacityReservationGroupsClient
+	CommunityGalleryImagesClient        *compute.CommunityGalleryImagesClient
+	CommunityGalleryImageVersionsClient *compute.CommunityGalleryImageVersionsClient
+	DedicatedHostsClient                *dedicatedhosts.DedicatedHostsClient
+	DedicatedHostGroupsClient           *dedicatedhostgroups.DedicatedHostGroupsClient
+	DisksClient                         *disks.DisksClient
+	DiskAccessClient                    *diskaccesses.DiskAccessesClient
+	DiskEncryptionSetsClient            *diskencryptionsets.DiskEncryptionSetsClient
+	GalleriesClient                     *compute.GalleriesClient
+	GalleryApplicationsClient           *galleryapplications.GalleryApplicationsClient
+	GalleryApplicationVersionsClient    *galleryapplicationversions.GalleryApplicationVersionsClient
+	GalleryImagesClient                 *compute.GalleryImagesClient
+	GalleryImageVersionsClient          *compute.GalleryImageVersionsClient
+	GallerySharingProfileClient         *compute.GallerySharingProfileClient
+	ImagesClient                        *compute.ImagesClient
+	MarketplaceAgreementsClient         *marketplaceordering.MarketplaceAgreementsClient
+	ProximityPlacementGroupsClient      *proximityplacementgroups.ProximityPlacementGroupsClient
+	SkusClient                          *skus.SkusClient
+	SSHPublicKeysClient                 *sshpublickeys.SshPublicKeysClient
+	SnapshotsClient                     *snapshots.SnapshotsClient
+	VirtualMachinesClient               *virtualmachines.VirtualMachinesClient
+	VMExtensionImageClient              *compute.VirtualMachineExtensionImagesClient
+	VMExtensionClient                   *compute.VirtualMachineExtensionsClient
+	VMRunCommandsClient                 *compute.VirtualMachineRunCommandsClient
+	VMScaleSetClient                    *compute.VirtualMachineScaleSetsClient
+	VMScaleSetExtensionsClient          *compute.VirtualMachineScaleSetExtensionsClient
+	VMScaleSetRollingUpgradesClient     *compute.VirtualMachineScaleSetRollingUpgradesClient
+	VMScaleSetVMsClient                 *compute.VirtualMachineScaleSetVMsClient
+	VMScaleSetVMRunCommandsClient       *compute.VirtualMachineScaleSetVMRunCommandsClient
+	VMClient                            *compute.VirtualMachinesClient
+	VMImageClient                       *compute.VirtualMachineImagesClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -63,6 +67,12 @@ func NewClient(o *common.ClientOptions) *Client {
 	capacityReservationGroupsClient := capacityreservationgroups.NewCapacityReservationGroupsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&capacityReservationGroupsClient.Client, o.ResourceManagerAuthorizer)
 
+	communityGalleryImagesClient := compute.NewCommunityGalleryImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&communityGalleryImagesClient.Client, o.ResourceManagerAuthorizer)
+
+	communityGalleryImageVersionsClient := compute.NewCommunityGalleryImageVersionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&communityGalleryImageVersionsClient.Client, o.ResourceManagerAuthorizer)
+
 	dedicatedHostsClient := dedicatedhosts.NewDedicatedHostsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&dedicatedHostsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -78,7 +88,7 @@ func NewClient(o *common.ClientOptions) *Client {
 	diskEncryptionSetsClient := diskencryptionsets.NewDiskEncryptionSetsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&diskEncryptionSetsClient.Client, o.ResourceManagerAuthorizer)
 
-	galleriesClient := galleries.NewGalleriesClientWithBaseURI(o.ResourceManagerEndpoint)
+	galleriesClient := compute.NewGalleriesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&galleriesClient.Client, o.ResourceManagerAuthorizer)
 
 	galleryApplicationsClient := galleryapplications.NewGalleryApplicationsClientWithBaseURI(o.ResourceManagerEndpoint)
@@ -93,6 +103,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	galleryImageVersionsClient := compute.NewGalleryImageVersionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&galleryImageVersionsClient.Client, o.ResourceManagerAuthorizer)
 
+	gallerySharingProfileClient := compute.NewGallerySharingProfileClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&gallerySharingProfileClient.Client, o.ResourceManagerAuthorizer)
+
 	imagesClient := compute.NewImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&imagesClient.Client, o.ResourceManagerAuthorizer)
 
@@ -123,6 +136,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	vmExtensionClient := compute.NewVirtualMachineExtensionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&vmExtensionClient.Client, o.ResourceManagerAuthorizer)
 
+	vmRunCommandsClient := compute.NewVirtualMachineRunCommandsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&vmRunCommandsClient.Client, o.ResourceManagerAuthorizer)
+
 	vmImageClient := compute.NewVirtualMachineImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&vmImageClient.Client, o.ResourceManagerAuthorizer)
 
@@ -138,37 +154,45 @@ func NewClient(o *common.ClientOptions) *Client {
 	vmScaleSetVMsClient := compute.NewVirtualMachineScaleSetVMsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&vmScaleSetVMsClient.Client, o.ResourceManagerAuthorizer)
 
+	vmScaleSetVMRunCommandsClient := compute.NewVirtualMachineScaleSetVMRunCommandsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&vmScaleSetVMRunCommandsClient.Client, o.ResourceManagerAuthorizer)
+
 	vmClient := compute.NewVirtualMachinesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&vmClient.Client, o.ResourceManagerAuthorizer)
 
 	return &Client{
-		AvailabilitySetsClient:           &availabilitySetsClient,
-		CapacityReservationsClient:       &capacityReservationsClient,
-		CapacityReservationGroupsClient:  &capacityReservationGroupsClient,
-		DedicatedHostsClient:             &dedicatedHostsClient,
-		DedicatedHostGroupsClient:        &dedicatedHostGroupsClient,
-		DisksClient:                      &disksClient,
-		DiskAccessClient:                 &diskAccessClient,
-		DiskEncryptionSetsClient:         &diskEncryptionSetsClient,
-		GalleriesClient:                  &galleriesClient,
-		GalleryApplicationsClient:        &galleryApplicationsClient,
-		GalleryApplicationVersionsClient: &galleryApplicationVersionsClient,
-		GalleryImagesClient:              &galleryImagesClient,
-		GalleryImageVersionsClient:       &galleryImageVersionsClient,
-		ImagesClient:                     &imagesClient,
-		MarketplaceAgreementsClient:      &marketplaceAgreementsClient,
-		ProximityPlacementGroupsClient:   &proximityPlacementGroupsClient,
-		SkusClient:                       &skusClient,
-		SSHPublicKeysClient:              &sshPublicKeysClient,
-		SnapshotsClient:                  &snapshotsClient,
-		VirtualMachinesClient:            &virtualMachinesClient,
-		VMExtensionImageClient:           &vmExtensionImageClient,
-		VMExtensionClient:                &vmExtensionClient,
-		VMScaleSetClient:                 &vmScaleSetClient,
-		VMScaleSetExtensionsClient:       &vmScaleSetExtensionsClient,
-		VMScaleSetRollingUpgradesClient:  &vmScaleSetRollingUpgradesClient,
-		VMScaleSetVMsClient:              &vmScaleSetVMsClient,
-		VMImageClient:                    &vmImageClient,
+		AvailabilitySetsClient:              &availabilitySetsClient,
+		CapacityReservationsClient:          &capacityReservationsClient,
+		CapacityReservationGroupsClient:     &capacityReservationGroupsClient,
+		CommunityGalleryImagesClient:        &communityGalleryImagesClient,
+		CommunityGalleryImageVersionsClient: &communityGalleryImageVersionsClient,
+		DedicatedHostsClient:                &dedicatedHostsClient,
+		DedicatedHostGroupsClient:           &dedicatedHostGroupsClient,
+		DisksClient:                         &disksClient,
+		DiskAccessClient:                    &diskAccessClient,
+		DiskEncryptionSetsClient:            &diskEncryptionSetsClient,
+		GalleriesClient:                     &galleriesClient,
+		GalleryApplicationsClient:           &galleryApplicationsClient,
+		GalleryApplicationVersionsClient:    &galleryApplicationVersionsClient,
+		GalleryImagesClient:                 &galleryImagesClient,
+		GalleryImageVersionsClient:          &galleryImageVersionsClient,
+		GallerySharingProfileClient:         &gallerySharingProfileClient,
+		ImagesClient:                        &imagesClient,
+		MarketplaceAgreementsClient:         &marketplaceAgreementsClient,
+		ProximityPlacementGroupsClient:      &proximityPlacementGroupsClient,
+		SkusClient:                          &skusClient,
+		SSHPublicKeysClient:                 &sshPublicKeysClient,
+		SnapshotsClient:                     &snapshotsClient,
+		VirtualMachinesClient:               &virtualMachinesClient,
+		VMExtensionImageClient:              &vmExtensionImageClient,
+		VMExtensionClient:                   &vmExtensionClient,
+		VMRunCommandsClient:                 &vmRunCommandsClient,
+		VMScaleSetClient:                    &vmScaleSetClient,
+		VMScaleSetExtensionsClient:          &vmScaleSetExtensionsClient,
+		VMScaleSetRollingUpgradesClient:     &vmScaleSetRollingUpgradesClient,
+		VMScaleSetVMsClient:                 &vmScaleSetVMsClient,
+		VMScaleSetVMRunCommandsClient:       &vmScaleSetVMRunCommandsClient,
+		VMImageClient:                       &vmImageClient,
 
 		// NOTE: use `VirtualMachinesClient` instead
 		VMClient: &vmClient,
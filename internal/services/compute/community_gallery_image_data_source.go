@@ -0,0 +1,173 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+func dataSourceCommunityGalleryImage() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceCommunityGalleryImageRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"gallery_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"location": {
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ValidateFunc:     location.EnhancedValidate,
+				StateFunc:        location.StateFunc,
+				DiffSuppressFunc: location.DiffSuppressFunc,
+			},
+
+			"architecture": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"end_of_life_date": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"eula": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"hyper_v_generation": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"identifier": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"publisher": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"offer": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"sku": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"os_type": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"privacy_statement_uri": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"purchase_plan": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"publisher": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"product": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"specialized": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCommunityGalleryImageRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.CommunityGalleryImagesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewCommunityGalleryImageID(d.Get("gallery_name").(string), d.Get("name").(string))
+	loc := location.Normalize(d.Get("location").(string))
+
+	resp, err := client.Get(ctx, loc, id.GalleryName, id.ImageName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("%s was not found in %q", id, loc)
+		}
+
+		return fmt.Errorf("retrieving %s in %q: %+v", id, loc, err)
+	}
+
+	d.SetId(id.ID())
+
+	d.Set("name", id.ImageName)
+	d.Set("gallery_name", id.GalleryName)
+	d.Set("location", loc)
+
+	if props := resp.CommunityGalleryImageProperties; props != nil {
+		d.Set("architecture", string(props.Architecture))
+		d.Set("eula", props.Eula)
+		d.Set("hyper_v_generation", string(props.HyperVGeneration))
+		d.Set("os_type", string(props.OsType))
+		d.Set("privacy_statement_uri", props.PrivacyStatementURI)
+		d.Set("specialized", props.OsState == compute.OperatingSystemStateTypesSpecialized)
+
+		endOfLifeDate := ""
+		if props.EndOfLifeDate != nil {
+			endOfLifeDate = props.EndOfLifeDate.Format(time.RFC3339)
+		}
+		d.Set("end_of_life_date", endOfLifeDate)
+
+		if err := d.Set("identifier", flattenGalleryImageDataSourceIdentifier(props.Identifier)); err != nil {
+			return fmt.Errorf("setting `identifier`: %+v", err)
+		}
+
+		if err := d.Set("purchase_plan", flattenGalleryImageDataSourcePurchasePlan(props.PurchasePlan)); err != nil {
+			return fmt.Errorf("setting `purchase_plan`: %+v", err)
+		}
+	}
+
+	return nil
+}
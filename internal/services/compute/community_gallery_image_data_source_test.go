@@ -0,0 +1,83 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type CommunityGalleryImageDataSource struct{}
+
+func TestAccDataSourceCommunityGalleryImage_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_community_gallery_image", "test")
+	r := CommunityGalleryImageDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("os_type").HasValue("Linux"),
+				check.That(data.ResourceName).Key("specialized").HasValue("false"),
+				check.That(data.ResourceName).Key("identifier.0.publisher").HasValue(fmt.Sprintf("AccTesPublisher%d", data.RandomInteger)),
+			),
+		},
+	})
+}
+
+func (CommunityGalleryImageDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_shared_image_gallery" "test" {
+  name                = "acctestsig%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  sharing {
+    permission = "Community"
+
+    community_gallery {
+      eula            = "https://eula.net"
+      prefix          = "prefix%s"
+      publisher_email = "publisher@test.net"
+      publisher_uri   = "https://publisher.net"
+    }
+  }
+}
+
+resource "azurerm_shared_image" "test" {
+  name                = "acctestimg%d"
+  gallery_name        = azurerm_shared_image_gallery.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  os_type             = "Linux"
+
+  identifier {
+    publisher = "AccTesPublisher%d"
+    offer     = "AccTesOffer%d"
+    sku       = "AccTesSku%d"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomString, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
+func (r CommunityGalleryImageDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_community_gallery_image" "test" {
+  name         = azurerm_shared_image.test.name
+  gallery_name = azurerm_shared_image_gallery.test.sharing.0.community_gallery.0.name
+  location     = azurerm_resource_group.test.location
+}
+`, r.template(data))
+}
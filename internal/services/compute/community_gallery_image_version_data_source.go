@@ -0,0 +1,162 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+func dataSourceCommunityGalleryImageVersion() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceCommunityGalleryImageVersionRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"gallery_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"image_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"location": {
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ValidateFunc:     location.EnhancedValidate,
+				StateFunc:        location.StateFunc,
+				DiffSuppressFunc: location.DiffSuppressFunc,
+			},
+
+			"end_of_life_date": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"exclude_from_latest": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"os_disk_image_size_gb": {
+				Type:     pluginsdk.TypeInt,
+				Computed: true,
+			},
+
+			"published_date": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCommunityGalleryImageVersionRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.CommunityGalleryImageVersionsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewCommunityGalleryImageVersionID(d.Get("gallery_name").(string), d.Get("image_name").(string), d.Get("name").(string))
+	loc := location.Normalize(d.Get("location").(string))
+
+	version, err := obtainCommunityGalleryImageVersion(ctx, client, loc, id)
+	if err != nil {
+		return err
+	}
+
+	exactId := parse.NewCommunityGalleryImageVersionID(id.GalleryName, id.ImageName, utils.NormalizeNilableString(version.Name))
+	d.SetId(exactId.ID())
+
+	d.Set("name", exactId.Version)
+	d.Set("gallery_name", exactId.GalleryName)
+	d.Set("image_name", exactId.ImageName)
+	d.Set("location", loc)
+
+	if props := version.CommunityGalleryImageVersionProperties; props != nil {
+		endOfLifeDate := ""
+		if props.EndOfLifeDate != nil {
+			endOfLifeDate = props.EndOfLifeDate.Format(time.RFC3339)
+		}
+		d.Set("end_of_life_date", endOfLifeDate)
+
+		publishedDate := ""
+		if props.PublishedDate != nil {
+			publishedDate = props.PublishedDate.Format(time.RFC3339)
+		}
+		d.Set("published_date", publishedDate)
+
+		d.Set("exclude_from_latest", props.ExcludeFromLatest)
+
+		osDiskImageSize := 0
+		if profile := props.StorageProfile; profile != nil && profile.OsDiskImage != nil && profile.OsDiskImage.DiskSizeGB != nil {
+			osDiskImageSize = int(*profile.OsDiskImage.DiskSizeGB)
+		}
+		d.Set("os_disk_image_size_gb", osDiskImageSize)
+	}
+
+	return nil
+}
+
+func obtainCommunityGalleryImageVersion(ctx context.Context, client *compute.CommunityGalleryImageVersionsClient, loc string, id parse.CommunityGalleryImageVersionId) (*compute.CommunityGalleryImageVersion, error) {
+	if id.Version != "latest" {
+		version, err := client.Get(ctx, loc, id.GalleryName, id.ImageName, id.Version)
+		if err != nil {
+			if utils.ResponseWasNotFound(version.Response) {
+				return nil, fmt.Errorf("%s was not found in %q", id, loc)
+			}
+
+			return nil, fmt.Errorf("retrieving %s in %q: %+v", id, loc, err)
+		}
+
+		return &version, nil
+	}
+
+	iterator, err := client.ListComplete(ctx, loc, id.GalleryName, id.ImageName)
+	if err != nil {
+		return nil, fmt.Errorf("listing the versions of Community Gallery Image %q (Gallery %q) in %q: %+v", id.ImageName, id.GalleryName, loc, err)
+	}
+
+	// the latest version is the most recently published one which hasn't been excluded from `latest`
+	var latest *compute.CommunityGalleryImageVersion
+	for iterator.NotDone() {
+		current := iterator.Value()
+		if props := current.CommunityGalleryImageVersionProperties; props != nil && props.PublishedDate != nil {
+			excluded := props.ExcludeFromLatest != nil && *props.ExcludeFromLatest
+			if !excluded && (latest == nil || props.PublishedDate.Time.After(latest.PublishedDate.Time)) {
+				latest = &current
+			}
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing the versions of Community Gallery Image %q (Gallery %q) in %q: %+v", id.ImageName, id.GalleryName, loc, err)
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("no versions were found for Community Gallery Image %q (Gallery %q) in %q", id.ImageName, id.GalleryName, loc)
+	}
+
+	return latest, nil
+}
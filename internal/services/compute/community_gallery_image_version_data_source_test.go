@@ -0,0 +1,110 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type CommunityGalleryImageVersionDataSource struct{}
+
+func TestAccDataSourceCommunityGalleryImageVersion_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_community_gallery_image_version", "test")
+	r := CommunityGalleryImageVersionDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			// need to create a vm and then reference it in the image creation
+			Config: SharedImageVersionResource{}.setup(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				data.CheckWithClientForResource(ImageResource{}.virtualMachineExists, "azurerm_virtual_machine.testsource"),
+				data.CheckWithClientForResource(ImageResource{}.generalizeVirtualMachine(data), "azurerm_virtual_machine.testsource"),
+			),
+		},
+		{
+			Config: r.imageVersion(data),
+		},
+		{
+			Config: r.customName(data, "0.0.1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("name").HasValue("0.0.1"),
+				check.That(data.ResourceName).Key("published_date").IsNotEmpty(),
+			),
+		},
+		{
+			Config: r.customName(data, "latest"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("name").HasValue("0.0.1"),
+			),
+		},
+	})
+}
+
+func (CommunityGalleryImageVersionDataSource) imageVersion(data acceptance.TestData) string {
+	template := ImageResource{}.standaloneImageProvision(data, "LRS", "")
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_shared_image_gallery" "test" {
+  name                = "acctestsig%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  sharing {
+    permission = "Community"
+
+    community_gallery {
+      eula            = "https://eula.net"
+      prefix          = "prefix%s"
+      publisher_email = "publisher@test.net"
+      publisher_uri   = "https://publisher.net"
+    }
+  }
+}
+
+resource "azurerm_shared_image" "test" {
+  name                = "acctestimg%d"
+  gallery_name        = azurerm_shared_image_gallery.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  os_type             = "Linux"
+
+  identifier {
+    publisher = "AccTesPublisher%d"
+    offer     = "AccTesOffer%d"
+    sku       = "AccTesSku%d"
+  }
+}
+
+resource "azurerm_shared_image_version" "test" {
+  name                = "0.0.1"
+  gallery_name        = azurerm_shared_image_gallery.test.name
+  image_name          = azurerm_shared_image.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  managed_image_id    = azurerm_image.test.id
+
+  target_region {
+    name                   = azurerm_resource_group.test.location
+    regional_replica_count = 1
+  }
+}
+`, template, data.RandomInteger, data.RandomString, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
+func (r CommunityGalleryImageVersionDataSource) customName(data acceptance.TestData, name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_community_gallery_image_version" "test" {
+  name         = "%s"
+  image_name   = azurerm_shared_image.test.name
+  gallery_name = azurerm_shared_image_gallery.test.sharing.0.community_gallery.0.name
+  location     = azurerm_resource_group.test.location
+
+  depends_on = [azurerm_shared_image_version.test]
+}
+`, r.imageVersion(data), name)
+}
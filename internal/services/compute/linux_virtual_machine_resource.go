@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/capacityreservationgroups"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/proximityplacementgroups"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-02/disks"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-02/snapshots"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	azValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
@@ -249,6 +250,12 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"os_disk_source_snapshot_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: snapshots.ValidateSnapshotID,
+			},
+
 			"patch_mode": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
@@ -281,6 +288,14 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				},
 			},
 
+			"reimage_triggers": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
 			"secret": linuxSecretSchema(),
 
 			"secure_boot_enabled": {
@@ -292,7 +307,6 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 			"source_image_id": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
-				ForceNew: true,
 				ValidateFunc: validation.Any(
 					computeValidate.ImageID,
 					computeValidate.SharedImageID,
@@ -308,7 +322,9 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				},
 			},
 
-			"source_image_reference": sourceImageReferenceSchema(true),
+			"source_image_reference": sourceImageReferenceSchema(),
+
+			"source_image_update_mode": virtualMachineSourceImageUpdateModeSchema(),
 
 			"virtual_machine_scale_set_id": {
 				Type:     pluginsdk.TypeString,
@@ -375,6 +391,10 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			virtualMachineSourceImageCustomizeDiff,
+		),
 	}
 }
 
@@ -688,6 +708,12 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 	}
 
 	d.SetId(id.ID())
+
+	if d.Get("os_disk_source_snapshot_id").(string) != "" {
+		if err := swapVirtualMachineOSDiskFromSnapshot(ctx, d, meta, id); err != nil {
+			return err
+		}
+	}
 	return resourceLinuxVirtualMachineRead(d, meta)
 }
 
@@ -870,20 +896,13 @@ func resourceLinuxVirtualMachineRead(d *pluginsdk.ResourceData, meta interface{}
 			return fmt.Errorf("settings `os_disk`: %+v", err)
 		}
 
-		var storageImageId string
-		if profile.ImageReference != nil && profile.ImageReference.ID != nil {
-			storageImageId = *profile.ImageReference.ID
-		}
-		if profile.ImageReference != nil && profile.ImageReference.CommunityGalleryImageID != nil {
-			storageImageId = *profile.ImageReference.CommunityGalleryImageID
-		}
-		if profile.ImageReference != nil && profile.ImageReference.SharedGalleryImageID != nil {
-			storageImageId = *profile.ImageReference.SharedGalleryImageID
+		sourceImageId, sourceImageReference, err := flattenVirtualMachineSourceImage(ctx, d, disksClient, *profile)
+		if err != nil {
+			return fmt.Errorf("flattening the Source Image: %+v", err)
 		}
+		d.Set("source_image_id", sourceImageId)
 
-		d.Set("source_image_id", storageImageId)
-
-		if err := d.Set("source_image_reference", flattenSourceImageReference(profile.ImageReference, storageImageId != "")); err != nil {
+		if err := d.Set("source_image_reference", sourceImageReference); err != nil {
 			return fmt.Errorf("setting `source_image_reference`: %+v", err)
 		}
 	}
@@ -918,6 +937,12 @@ func resourceLinuxVirtualMachineRead(d *pluginsdk.ResourceData, meta interface{}
 
 	d.Set("virtual_machine_id", props.VMID)
 
+	sourceImageUpdateMode := virtualMachineSourceImageUpdateModeReplace
+	if v := d.Get("source_image_update_mode").(string); v != "" {
+		sourceImageUpdateMode = v
+	}
+	d.Set("source_image_update_mode", sourceImageUpdateMode)
+
 	d.Set("user_data", props.UserData)
 
 	zone := ""
@@ -1139,6 +1164,15 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		}
 	}
 
+	swapOSDisk := virtualMachineOSDiskShouldBeSwapped(d, hasEphemeralOSDisk)
+	if swapOSDisk {
+		shouldUpdate = true
+
+		// the OS Disk can only be swapped whilst the Virtual Machine is deallocated
+		shouldShutDown = true
+		shouldDeallocate = true
+	}
+
 	if d.HasChange("proximity_placement_group_id") {
 		shouldUpdate = true
 
@@ -1334,8 +1368,9 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 
 	if shouldShutDown {
 		log.Printf("[DEBUG] Shutting Down Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
-		forceShutdown := false
-		future, err := client.PowerOff(ctx, id.ResourceGroup, id.Name, utils.Bool(forceShutdown))
+		// when the OS Disk is being expanded the graceful shutdown can be skipped, since this requires a deallocation regardless
+		skipShutdown := d.HasChange("os_disk.0.disk_size_gb") && !meta.(*clients.Client).Features.ManagedDisk.GracefulShutdownBeforeExpand
+		future, err := client.PowerOff(ctx, id.ResourceGroup, id.Name, utils.Bool(skipShutdown))
 		if err != nil {
 			return fmt.Errorf("sending Power Off to Linux Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 		}
@@ -1367,11 +1402,37 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		}
 	}
 
+	// the new OS Disk is only created once the Virtual Machine has been deallocated, and is deleted if it isn't swapped in
+	var osDiskSwap *virtualMachineOSDiskSwap
+	osDiskSwapped := false
+	if swapOSDisk {
+		osDiskSwap, err = createVirtualMachineOSDiskForSwap(ctx, d, meta, *id, existing)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if !osDiskSwapped {
+				osDiskSwap.deleteNewDisk(ctx, meta)
+			}
+		}()
+
+		if update.VirtualMachineProperties.StorageProfile == nil {
+			update.VirtualMachineProperties.StorageProfile = &compute.StorageProfile{}
+		}
+		update.VirtualMachineProperties.StorageProfile.OsDisk = osDiskSwap.expandOSDisk(update.VirtualMachineProperties.StorageProfile.OsDisk)
+	}
+
+	// when the OS Disk is being swapped any changes are made to the new OS Disk, prior to it being swapped in
+	osDiskName := d.Get("os_disk.0.name").(string)
+	if osDiskSwap != nil {
+		osDiskName = osDiskSwap.NewDiskId.DiskName
+	}
+
 	// now the VM's shutdown/deallocated we can update the disk which can't be done via the VM API:
 	// Code="ResizeDiskError" Message="Managed disk resize via Virtual Machine [name] is not allowed. Please resize disk resource at [id]."
 	// Portal: "Disks can be resized or account type changed only when they are unattached or the owner VM is deallocated."
 	if d.HasChange("os_disk.0.disk_size_gb") {
-		diskName := d.Get("os_disk.0.name").(string)
+		diskName := osDiskName
 		newSize := d.Get("os_disk.0.disk_size_gb").(int)
 		log.Printf("[DEBUG] Resizing OS Disk %q for Linux Virtual Machine %q (Resource Group %q) to %dGB..", diskName, id.Name, id.ResourceGroup, newSize)
 
@@ -1395,7 +1456,7 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 
 	if d.HasChange("os_disk.0.disk_encryption_set_id") {
 		if diskEncryptionSetId := d.Get("os_disk.0.disk_encryption_set_id").(string); diskEncryptionSetId != "" {
-			diskName := d.Get("os_disk.0.name").(string)
+			diskName := osDiskName
 			log.Printf("[DEBUG] Updating encryption settings of OS Disk %q for Linux Virtual Machine %q (Resource Group %q) to %q..", diskName, id.Name, id.ResourceGroup, diskEncryptionSetId)
 
 			encryptionType, err := retrieveDiskEncryptionSetEncryptionType(ctx, meta.(*clients.Client).Compute.DiskEncryptionSetsClient, diskEncryptionSetId)
@@ -1439,6 +1500,7 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		}
 
 		log.Printf("[DEBUG] Updated Linux Virtual Machine %q (Resource Group %q).", id.Name, id.ResourceGroup)
+		osDiskSwapped = true
 	}
 
 	// if we've shut it down and it was turned off, let's boot it back up
@@ -1456,6 +1518,18 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		log.Printf("[DEBUG] Started Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 	}
 
+	if osDiskSwap != nil {
+		if err := osDiskSwap.deleteOriginalDisk(ctx, meta); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("reimage_triggers") && hasEphemeralOSDisk {
+		if err := reimageVirtualMachineWithEphemeralOSDisk(ctx, meta, *id); err != nil {
+			return err
+		}
+	}
+
 	return resourceLinuxVirtualMachineRead(d, meta)
 }
 
@@ -100,6 +100,59 @@ func TestAccLinuxVirtualMachine_imageFromSourceImageReference(t *testing.T) {
 	})
 }
 
+func TestAccLinuxVirtualMachine_imageSwapOSDisk(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.imageSwapOSDisk(data, "16.04-LTS", "1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers", "source_image_update_mode"),
+		{
+			Config: r.imageSwapOSDisk(data, "18.04-LTS", "1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("source_image_reference.0.sku").HasValue("18.04-LTS"),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers", "source_image_update_mode", "source_image_reference"),
+		{
+			// reimaging swaps in a new OS Disk created from the same image
+			Config: r.imageSwapOSDisk(data, "18.04-LTS", "2"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers", "source_image_update_mode", "source_image_reference"),
+	})
+}
+
+func TestAccLinuxVirtualMachine_imageReimageEphemeralOSDisk(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.imageReimageEphemeralOSDisk(data, "1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers"),
+		{
+			Config: r.imageReimageEphemeralOSDisk(data, "2"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers"),
+	})
+}
+
 func (LinuxVirtualMachineResource) imageFromExistingMachineDependencies(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 # note: whilst these aren't used in all tests, it saves us redefining these everywhere
@@ -401,6 +454,83 @@ resource "azurerm_linux_virtual_machine" "test" {
 `, r.template(data), data.RandomInteger)
 }
 
+func (r LinuxVirtualMachineResource) imageSwapOSDisk(data acceptance.TestData, sku, trigger string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_linux_virtual_machine" "test" {
+  name                            = "acctestVM-%d"
+  resource_group_name             = azurerm_resource_group.test.name
+  location                        = azurerm_resource_group.test.location
+  size                            = "Standard_F2"
+  admin_username                  = "adminuser"
+  disable_password_authentication = false
+  admin_password                  = "Eung6ahthane2ied"
+  source_image_update_mode        = "SwapOSDisk"
+
+  network_interface_ids = [
+    azurerm_network_interface.test.id,
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  reimage_triggers = {
+    build = "%s"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "%s"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger, trigger, sku)
+}
+
+func (r LinuxVirtualMachineResource) imageReimageEphemeralOSDisk(data acceptance.TestData, trigger string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_linux_virtual_machine" "test" {
+  name                            = "acctestVM-%d"
+  resource_group_name             = azurerm_resource_group.test.name
+  location                        = azurerm_resource_group.test.location
+  size                            = "Standard_F4s_v2"
+  admin_username                  = "adminuser"
+  disable_password_authentication = false
+  admin_password                  = "Eung6ahthane2ied"
+
+  network_interface_ids = [
+    azurerm_network_interface.test.id,
+  ]
+
+  os_disk {
+    caching              = "ReadOnly"
+    storage_account_type = "Standard_LRS"
+
+    diff_disk_settings {
+      option = "Local"
+    }
+  }
+
+  reimage_triggers = {
+    build = "%s"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "18.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger, trigger)
+}
+
 func (LinuxVirtualMachineResource) empty() string {
 	return `
 provider "azurerm" {
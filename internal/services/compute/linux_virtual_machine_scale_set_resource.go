@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
@@ -144,6 +145,10 @@ func resourceLinuxVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta i
 		return fmt.Errorf("a `rolling_upgrade_policy` block must be specified when `upgrade_mode` is set to %q", string(upgradeMode))
 	}
 
+	if upgradeMode != compute.UpgradeModeManual && len(d.Get("instance_rollout_policy").([]interface{})) > 0 {
+		return fmt.Errorf("an `instance_rollout_policy` block can only be specified when `upgrade_mode` is set to %q", string(compute.UpgradeModeManual))
+	}
+
 	secretsRaw := d.Get("secret").([]interface{})
 	secrets := expandLinuxSecrets(secretsRaw)
 
@@ -782,10 +787,38 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 
 	update.VirtualMachineScaleSetUpdateProperties = &updateProps
 
+	instanceRolloutPolicyRaw := d.Get("instance_rollout_policy").([]interface{})
+	if upgradeMode := compute.UpgradeMode(d.Get("upgrade_mode").(string)); upgradeMode != compute.UpgradeModeManual && len(instanceRolloutPolicyRaw) > 0 {
+		return fmt.Errorf("an `instance_rollout_policy` block can only be specified when `upgrade_mode` is set to %q", string(compute.UpgradeModeManual))
+	}
+	instanceRolloutPolicy, err := ExpandVirtualMachineScaleSetInstanceRolloutPolicy(instanceRolloutPolicyRaw)
+	if err != nil {
+		return err
+	}
+
+	// when the only change which requires the instances to be rolled is expanding Data Disks (which support being expanded
+	// without downtime) the instances can be updated to the latest model without being reimaged
+	skipReimage := false
+	if updateInstances && d.HasChange("data_disk") && !d.HasChangesExcept("data_disk", "instance_rollout_policy", "tags") && meta.(*clients.Client).Features.ManagedDisk.ExpandWithoutDowntime {
+		oldDataDisks, newDataDisks := d.GetChange("data_disk")
+		decision := determineIfVirtualMachineScaleSetDataDisksSupportNoDowntimeResize(oldDataDisks.([]interface{}), newDataDisks.([]interface{}))
+		if decision.Supported {
+			sizeSupportsNoDowntimeResize, err := determineIfVirtualMachineSizeSupportsNoDowntimeResize(ctx, commonids.NewSubscriptionID(id.SubscriptionId), d.Get("sku").(string), meta.(*clients.Client).Compute.SkusClient)
+			if err != nil {
+				return fmt.Errorf("determining if the Size of Linux Virtual Machine Scale Set %q (Resource Group %q) supports no-downtime-resize: %+v", id.Name, id.ResourceGroup, err)
+			}
+			skipReimage = *sizeSupportsNoDowntimeResize
+		}
+		log.Printf("[DEBUG] Expanding the Data Disks of Linux Virtual Machine Scale Set %q (Resource Group %q) without reimaging the instances: %t (%s)", id.Name, id.ResourceGroup, skipReimage, decision.Reason)
+	}
+
 	metaData := virtualMachineScaleSetUpdateMetaData{
 		AutomaticOSUpgradeIsEnabled:  automaticOSUpgradeIsEnabled,
 		CanRollInstancesWhenRequired: meta.(*clients.Client).Features.VirtualMachineScaleSet.RollInstancesWhenRequired,
 		UpdateInstances:              updateInstances,
+		InstanceRolloutPolicy:        instanceRolloutPolicy,
+		SkipReimage:                  skipReimage,
+		ReimageInstances:             d.HasChange("reimage_triggers"),
 		Client:                       meta.(*clients.Client).Compute,
 		Existing:                     existing,
 		ID:                           id,
@@ -1340,6 +1373,16 @@ func resourceLinuxVirtualMachineScaleSetSchema() map[string]*pluginsdk.Schema {
 			},
 		},
 
+		"instance_rollout_policy": VirtualMachineScaleSetInstanceRolloutPolicySchema(),
+
+		"reimage_triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
 		"rolling_upgrade_policy": VirtualMachineScaleSetRollingUpgradePolicySchema(),
 
 		"secret": linuxSecretSchema(),
@@ -1374,7 +1417,7 @@ func resourceLinuxVirtualMachineScaleSetSchema() map[string]*pluginsdk.Schema {
 			},
 		},
 
-		"source_image_reference": sourceImageReferenceSchema(false),
+		"source_image_reference": sourceImageReferenceSchema(),
 
 		"tags": tags.Schema(),
 
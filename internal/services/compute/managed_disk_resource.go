@@ -287,6 +287,11 @@ func resourceManagedDisk() *pluginsdk.Resource {
 			"zone": commonschema.ZoneSingleOptionalForceNew(),
 
 			"tags": commonschema.Tags(),
+
+			"expansion_requires_downtime": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
 		},
 
 		// Encryption Settings cannot be disabled once enabled
@@ -297,10 +302,55 @@ func resourceManagedDisk() *pluginsdk.Resource {
 				}
 				return len(old.([]interface{})) > 0 && len(new.([]interface{})) == 0
 			}),
+			managedDiskExpansionCustomizeDiff,
 		),
 	}
 }
 
+// managedDiskExpansionCustomizeDiff surfaces at plan-time whether expanding the Disk will require the Virtual Machine
+// that it's attached to to be shut down, using the same decision as the Update
+func managedDiskExpansionCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("disk_size_gb") {
+		return nil
+	}
+
+	oldSize, newSize := d.GetChange("disk_size_gb")
+	if newSize.(int) <= oldSize.(int) {
+		return nil
+	}
+
+	client := meta.(*clients.Client).Compute
+	id, err := disks.ParseDiskID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	disk, err := client.DisksClient.Get(ctx, *id)
+	if err != nil {
+		// the update will surface this error if it persists, so whether downtime is required isn't known until then
+		log.Printf("[DEBUG] unable to retrieve %s to determine if it can be expanded without downtime: %+v", *id, err)
+		return d.SetNewComputed("expansion_requires_downtime")
+	}
+
+	// a Disk which isn't attached to a Virtual Machine can always be expanded without downtime
+	if disk.Model == nil || disk.Model.ManagedBy == nil || *disk.Model.ManagedBy == "" {
+		return d.SetNew("expansion_requires_downtime", false)
+	}
+
+	if !meta.(*clients.Client).Features.ManagedDisk.ExpandWithoutDowntime {
+		return d.SetNew("expansion_requires_downtime", true)
+	}
+
+	decision, err := determineIfManagedDiskSupportsNoDowntimeResize(ctx, disk.Model, oldSize.(int), newSize.(int), client.VirtualMachinesClient, client.SkusClient)
+	if err != nil {
+		log.Printf("[DEBUG] unable to determine if %s can be expanded without downtime: %+v", *id, err)
+		return d.SetNewComputed("expansion_requires_downtime")
+	}
+
+	log.Printf("[DEBUG] Expanding %s without downtime supported: %t (%s)", *id, decision.Supported, decision.Reason)
+	return d.SetNew("expansion_requires_downtime", !decision.Supported)
+}
+
 func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	client := meta.(*clients.Client).Compute.DisksClient
@@ -705,14 +755,13 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		if oldSize, newSize := d.GetChange("disk_size_gb"); newSize.(int) > oldSize.(int) {
 			canBeResizedWithoutDowntime := false
 			if meta.(*clients.Client).Features.ManagedDisk.ExpandWithoutDowntime {
-				diskSupportsNoDowntimeResize := determineIfDataDiskSupportsNoDowntimeResize(disk.Model, oldSize.(int), newSize.(int))
-
-				vmSkuSupportsNoDowntimeResize, err := determineIfVirtualMachineSkuSupportsNoDowntimeResize(ctx, disk.Model.ManagedBy, virtualMachinesClient, skusClient)
+				decision, err := determineIfManagedDiskSupportsNoDowntimeResize(ctx, disk.Model, oldSize.(int), newSize.(int), virtualMachinesClient, skusClient)
 				if err != nil {
-					return fmt.Errorf("determining if the Virtual Machine the Disk is attached to supports no-downtime-resize: %+v", err)
+					return err
 				}
 
-				canBeResizedWithoutDowntime = *vmSkuSupportsNoDowntimeResize && *diskSupportsNoDowntimeResize
+				log.Printf("[DEBUG] Expanding %s without downtime supported: %t (%s)", *id, decision.Supported, decision.Reason)
+				canBeResizedWithoutDowntime = decision.Supported
 			}
 			if !canBeResizedWithoutDowntime {
 				log.Printf("[INFO] The %s, or the Virtual Machine that it's attached to, doesn't support no-downtime-resizing - requiring that the VM should be shutdown", *id)
@@ -849,8 +898,8 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		// Shutdown
 		if shouldShutDown {
 			log.Printf("[DEBUG] Shutting Down Virtual Machine %q (Resource Group %q)..", virtualMachine.Name, virtualMachine.ResourceGroup)
-			forceShutdown := false
-			future, err := vmClient.PowerOff(ctx, virtualMachine.ResourceGroup, virtualMachine.Name, utils.Bool(forceShutdown))
+			skipShutdown := !meta.(*clients.Client).Features.ManagedDisk.GracefulShutdownBeforeExpand
+			future, err := vmClient.PowerOff(ctx, virtualMachine.ResourceGroup, virtualMachine.Name, utils.Bool(skipShutdown))
 			if err != nil {
 				return fmt.Errorf("sending Power Off to Virtual Machine %q (Resource Group %q): %+v", virtualMachine.Name, virtualMachine.ResourceGroup, err)
 			}
@@ -930,6 +979,9 @@ func resourceManagedDiskRead(d *pluginsdk.ResourceData, meta interface{}) error
 	d.Set("name", id.DiskName)
 	d.Set("resource_group_name", id.ResourceGroupName)
 
+	// this is only true within a plan which expands a Disk that can't be expanded without downtime
+	d.Set("expansion_requires_downtime", false)
+
 	if model := resp.Model; model != nil {
 		d.Set("location", location.NormalizeNilable(&model.Location))
 		d.Set("edge_zone", flattenManagedDiskEdgeZone(model.ExtendedLocation))
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
@@ -20,45 +21,99 @@ import (
 
 // @tombuildsstuff: this is intentionally split out into it's own file since this'll need to be reused
 
-func determineIfDataDiskSupportsNoDowntimeResize(disk *disks.Disk, oldSizeGb, newSizeGb int) *bool {
+// noDowntimeResizeDecision describes whether a disk can be expanded without downtime - and if not, why not
+type noDowntimeResizeDecision struct {
+	Supported bool
+	Reason    string
+}
+
+func noDowntimeResizeSupported(reason string) noDowntimeResizeDecision {
+	return noDowntimeResizeDecision{
+		Supported: true,
+		Reason:    reason,
+	}
+}
+
+func noDowntimeResizeUnsupported(reason string) noDowntimeResizeDecision {
+	return noDowntimeResizeDecision{
+		Supported: false,
+		Reason:    reason,
+	}
+}
+
+// determineIfManagedDiskSupportsNoDowntimeResize determines whether the Managed Disk can be expanded from `oldSizeGb` to
+// `newSizeGb` without shutting down the Virtual Machine which it's attached to (if any)
+func determineIfManagedDiskSupportsNoDowntimeResize(ctx context.Context, disk *disks.Disk, oldSizeGb, newSizeGb int, virtualMachinesClient *virtualmachines.VirtualMachinesClient, skusClient *skus.SkusClient) (*noDowntimeResizeDecision, error) {
+	if disk == nil || disk.Properties == nil {
+		return pointer.To(noDowntimeResizeUnsupported("the Disk could not be retrieved")), nil
+	}
+
+	if disk.ManagedBy == nil || *disk.ManagedBy == "" {
+		return pointer.To(noDowntimeResizeSupported("the Disk is not attached to a Virtual Machine")), nil
+	}
+
+	if decision := determineIfDataDiskSupportsNoDowntimeResize(disk, oldSizeGb, newSizeGb); !decision.Supported {
+		return &decision, nil
+	}
+
+	vmSkuSupportsNoDowntimeResize, err := determineIfVirtualMachineSkuSupportsNoDowntimeResize(ctx, disk.ManagedBy, virtualMachinesClient, skusClient)
+	if err != nil {
+		return nil, fmt.Errorf("determining if the Virtual Machine the Disk is attached to supports no-downtime-resize: %+v", err)
+	}
+	if !*vmSkuSupportsNoDowntimeResize {
+		return pointer.To(noDowntimeResizeUnsupported("the Size of the Virtual Machine that the Disk is attached to doesn't support expanding disks without downtime")), nil
+	}
+
+	return pointer.To(noDowntimeResizeSupported("the Disk and the Virtual Machine that it's attached to support expanding disks without downtime")), nil
+}
+
+func determineIfDataDiskSupportsNoDowntimeResize(disk *disks.Disk, oldSizeGb, newSizeGb int) noDowntimeResizeDecision {
 	if disk == nil || disk.Properties == nil || disk.Sku == nil {
-		return pointer.To(false)
+		return noDowntimeResizeUnsupported("the Disk could not be retrieved")
 	}
 
 	// Only supported for data disks.
-	isDataDisk := disk.Properties.OsType != nil && string(*disk.Properties.OsType) != ""
-	if isDataDisk {
-		return pointer.To(false)
+	isOSDisk := disk.Properties.OsType != nil && string(*disk.Properties.OsType) != ""
+	if isOSDisk {
+		return noDowntimeResizeUnsupported("OS Disks can only be expanded when the Virtual Machine is deallocated")
 	}
 
 	// Not supported for shared disks.
-	isSharedDisk := disk.Properties.MaxShares != nil && *disk.Properties.MaxShares >= 0
+	isSharedDisk := disk.Properties.MaxShares != nil && *disk.Properties.MaxShares > 1
 	if isSharedDisk {
-		log.Printf("[DEBUG] Disk is shared so does not support no-downtime-resize")
-		return pointer.To(false)
+		return noDowntimeResizeUnsupported("Shared Disks can only be expanded when the Virtual Machines are deallocated")
 	}
 
+	return determineIfDiskConfigurationSupportsNoDowntimeResize(disk.Sku.Name, oldSizeGb, newSizeGb)
+}
+
+// determineIfDiskConfigurationSupportsNoDowntimeResize determines if a (Data) Disk using the specified Storage Account Type
+// can be expanded without downtime, this is split out so that it can be used for Virtual Machine Scale Set Data Disks, where
+// there's no Disk resource available to check
+func determineIfDiskConfigurationSupportsNoDowntimeResize(storageAccountType *disks.DiskStorageAccountTypes, oldSizeGb, newSizeGb int) noDowntimeResizeDecision {
 	// If a disk is 4 TiB or less, you can't expand it beyond 4 TiB without deallocating the VM.
 	// If a disk is already greater than 4 TiB, you can expand it without deallocating the VM.
 	if oldSizeGb < 4096 && newSizeGb >= 4096 {
-		return pointer.To(false)
+		return noDowntimeResizeUnsupported("Disks smaller than 4TiB can only be expanded to 4TiB or larger when the Virtual Machine is deallocated")
 	}
 
 	// Not supported for Ultra disks or Premium SSD v2 disks.
-	diskTypeIsSupported := false
-	if disk.Sku.Name != nil {
+	if storageAccountType != nil {
 		for _, supportedDiskType := range []disks.DiskStorageAccountTypes{
 			disks.DiskStorageAccountTypesPremiumLRS,
 			disks.DiskStorageAccountTypesPremiumZRS,
 			disks.DiskStorageAccountTypesStandardSSDLRS,
 			disks.DiskStorageAccountTypesStandardSSDZRS,
 		} {
-			if strings.EqualFold(string(*disk.Sku.Name), string(supportedDiskType)) {
-				diskTypeIsSupported = true
+			if strings.EqualFold(string(*storageAccountType), string(supportedDiskType)) {
+				return noDowntimeResizeSupported(fmt.Sprintf("%s Disks support expanding without downtime", string(*storageAccountType)))
 			}
 		}
+
+		return noDowntimeResizeUnsupported(fmt.Sprintf("%s Disks can only be expanded when the Virtual Machine is deallocated", string(*storageAccountType)))
 	}
-	return pointer.To(diskTypeIsSupported)
+
+	return noDowntimeResizeUnsupported("the Storage Account Type of the Disk could not be determined")
 }
 
 func determineIfVirtualMachineSkuSupportsNoDowntimeResize(ctx context.Context, virtualMachineIdRaw *string, virtualMachinesClient *virtualmachines.VirtualMachinesClient, skusClient *skus.SkusClient) (*bool, error) {
@@ -82,11 +137,18 @@ func determineIfVirtualMachineSkuSupportsNoDowntimeResize(ctx context.Context, v
 	if model := virtualMachine.Model; model != nil && model.Properties != nil && model.Properties.HardwareProfile != nil && model.Properties.HardwareProfile.VMSize != nil {
 		vmSku = string(*model.Properties.HardwareProfile.VMSize)
 	}
+
+	subscriptionId := commonids.NewSubscriptionID(virtualMachineId.SubscriptionId)
+	return determineIfVirtualMachineSizeSupportsNoDowntimeResize(ctx, subscriptionId, vmSku, skusClient)
+}
+
+// determineIfVirtualMachineSizeSupportsNoDowntimeResize determines whether the specified Virtual Machine Size (e.g.
+// `Standard_F2`) supports expanding disks without downtime, this is used for both Virtual Machines and Scale Sets
+func determineIfVirtualMachineSizeSupportsNoDowntimeResize(ctx context.Context, subscriptionId commonids.SubscriptionId, vmSku string, skusClient *skus.SkusClient) (*bool, error) {
 	if vmSku == "" {
 		return pointer.To(false), nil
 	}
 
-	subscriptionId := commonids.NewSubscriptionID(virtualMachineId.SubscriptionId)
 	skusResponse, err := skusClient.ResourceSkusListComplete(ctx, subscriptionId, skus.DefaultResourceSkusListOperationOptions())
 	if err != nil {
 		return nil, fmt.Errorf("retrieving information about the Resource SKUs to check if the Virtual Machine/Disk combination supports no-downtime-resizing: %+v", err)
@@ -99,6 +161,9 @@ func determineIfVirtualMachineSkuSupportsNoDowntimeResize(ctx context.Context, v
 		if sku.ResourceType == nil || !strings.EqualFold(*sku.ResourceType, "virtualMachines") {
 			continue
 		}
+		if sku.Name == nil || !strings.EqualFold(*sku.Name, vmSku) {
+			continue
+		}
 		if sku.Capabilities == nil {
 			continue
 		}
@@ -124,3 +189,47 @@ func determineIfVirtualMachineSkuSupportsNoDowntimeResize(ctx context.Context, v
 	result := supportsEphemeralOSDisks || supportsPremiumIO || supportsHyperVGen2
 	return pointer.To(result), nil
 }
+
+// determineIfVirtualMachineScaleSetDataDisksSupportNoDowntimeResize determines whether the changes to the `data_disk`
+// blocks of a Virtual Machine Scale Set are limited to expanding Data Disks which support being expanded without downtime
+func determineIfVirtualMachineScaleSetDataDisksSupportNoDowntimeResize(oldRaw, newRaw []interface{}) noDowntimeResizeDecision {
+	if len(oldRaw) != len(newRaw) {
+		return noDowntimeResizeUnsupported("Data Disks are being added or removed")
+	}
+
+	for i := range newRaw {
+		oldDisk, ok := oldRaw[i].(map[string]interface{})
+		if !ok {
+			return noDowntimeResizeUnsupported("Data Disks are being added or removed")
+		}
+		newDisk, ok := newRaw[i].(map[string]interface{})
+		if !ok {
+			return noDowntimeResizeUnsupported("Data Disks are being added or removed")
+		}
+
+		for k, v := range newDisk {
+			if k == "disk_size_gb" {
+				continue
+			}
+			if !reflect.DeepEqual(oldDisk[k], v) {
+				return noDowntimeResizeUnsupported(fmt.Sprintf("the `%s` field of a Data Disk is being changed", k))
+			}
+		}
+
+		oldSizeGb := oldDisk["disk_size_gb"].(int)
+		newSizeGb := newDisk["disk_size_gb"].(int)
+		if newSizeGb == oldSizeGb {
+			continue
+		}
+		if newSizeGb < oldSizeGb {
+			return noDowntimeResizeUnsupported("Data Disks cannot be shrunk")
+		}
+
+		storageAccountType := disks.DiskStorageAccountTypes(newDisk["storage_account_type"].(string))
+		if decision := determineIfDiskConfigurationSupportsNoDowntimeResize(&storageAccountType, oldSizeGb, newSizeGb); !decision.Supported {
+			return decision
+		}
+	}
+
+	return noDowntimeResizeSupported("the Data Disks being expanded support expanding without downtime")
+}
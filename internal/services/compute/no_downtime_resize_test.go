@@ -0,0 +1,188 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-02/disks"
+)
+
+func TestDetermineIfDataDiskSupportsNoDowntimeResize(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Disk      *disks.Disk
+		OldSizeGb int
+		NewSizeGb int
+		Expected  bool
+	}{
+		{
+			Name:      "Nil Disk",
+			OldSizeGb: 10,
+			NewSizeGb: 20,
+			Expected:  false,
+		},
+		{
+			Name: "Premium Data Disk",
+			Disk: &disks.Disk{
+				Properties: &disks.DiskProperties{},
+				Sku: &disks.DiskSku{
+					Name: pointer.To(disks.DiskStorageAccountTypesPremiumLRS),
+				},
+			},
+			OldSizeGb: 10,
+			NewSizeGb: 20,
+			Expected:  true,
+		},
+		{
+			Name: "Premium Data Disk with a single share",
+			Disk: &disks.Disk{
+				Properties: &disks.DiskProperties{
+					MaxShares: pointer.To(int64(1)),
+				},
+				Sku: &disks.DiskSku{
+					Name: pointer.To(disks.DiskStorageAccountTypesPremiumLRS),
+				},
+			},
+			OldSizeGb: 10,
+			NewSizeGb: 20,
+			Expected:  true,
+		},
+		{
+			Name: "Shared Premium Data Disk",
+			Disk: &disks.Disk{
+				Properties: &disks.DiskProperties{
+					MaxShares: pointer.To(int64(2)),
+				},
+				Sku: &disks.DiskSku{
+					Name: pointer.To(disks.DiskStorageAccountTypesPremiumLRS),
+				},
+			},
+			OldSizeGb: 10,
+			NewSizeGb: 20,
+			Expected:  false,
+		},
+		{
+			Name: "Premium OS Disk",
+			Disk: &disks.Disk{
+				Properties: &disks.DiskProperties{
+					OsType: pointer.To(disks.OperatingSystemTypesLinux),
+				},
+				Sku: &disks.DiskSku{
+					Name: pointer.To(disks.DiskStorageAccountTypesPremiumLRS),
+				},
+			},
+			OldSizeGb: 10,
+			NewSizeGb: 20,
+			Expected:  false,
+		},
+		{
+			Name: "Standard Data Disk",
+			Disk: &disks.Disk{
+				Properties: &disks.DiskProperties{},
+				Sku: &disks.DiskSku{
+					Name: pointer.To(disks.DiskStorageAccountTypesStandardLRS),
+				},
+			},
+			OldSizeGb: 10,
+			NewSizeGb: 20,
+			Expected:  false,
+		},
+		{
+			Name: "Premium Data Disk expanding beyond 4TiB",
+			Disk: &disks.Disk{
+				Properties: &disks.DiskProperties{},
+				Sku: &disks.DiskSku{
+					Name: pointer.To(disks.DiskStorageAccountTypesPremiumLRS),
+				},
+			},
+			OldSizeGb: 1024,
+			NewSizeGb: 4096,
+			Expected:  false,
+		},
+		{
+			Name: "Premium Data Disk already larger than 4TiB",
+			Disk: &disks.Disk{
+				Properties: &disks.DiskProperties{},
+				Sku: &disks.DiskSku{
+					Name: pointer.To(disks.DiskStorageAccountTypesPremiumLRS),
+				},
+			},
+			OldSizeGb: 4096,
+			NewSizeGb: 8192,
+			Expected:  true,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		actual := determineIfDataDiskSupportsNoDowntimeResize(v.Disk, v.OldSizeGb, v.NewSizeGb)
+		if actual.Supported != v.Expected {
+			t.Fatalf("Expected %t but got %t (%s)", v.Expected, actual.Supported, actual.Reason)
+		}
+	}
+}
+
+func TestDetermineIfVirtualMachineScaleSetDataDisksSupportNoDowntimeResize(t *testing.T) {
+	dataDisk := func(lun, sizeGb int, storageAccountType string) map[string]interface{} {
+		return map[string]interface{}{
+			"caching":              "None",
+			"disk_size_gb":         sizeGb,
+			"lun":                  lun,
+			"storage_account_type": storageAccountType,
+		}
+	}
+
+	testCases := []struct {
+		Name     string
+		Old      []interface{}
+		New      []interface{}
+		Expected bool
+	}{
+		{
+			Name:     "Expanding a Premium Data Disk",
+			Old:      []interface{}{dataDisk(0, 10, "Premium_LRS")},
+			New:      []interface{}{dataDisk(0, 20, "Premium_LRS")},
+			Expected: true,
+		},
+		{
+			Name:     "Expanding one of multiple Data Disks",
+			Old:      []interface{}{dataDisk(0, 10, "Premium_LRS"), dataDisk(1, 10, "Standard_LRS")},
+			New:      []interface{}{dataDisk(0, 20, "Premium_LRS"), dataDisk(1, 10, "Standard_LRS")},
+			Expected: true,
+		},
+		{
+			Name:     "Expanding a Standard Data Disk",
+			Old:      []interface{}{dataDisk(0, 10, "Standard_LRS")},
+			New:      []interface{}{dataDisk(0, 20, "Standard_LRS")},
+			Expected: false,
+		},
+		{
+			Name:     "Changing the Storage Account Type",
+			Old:      []interface{}{dataDisk(0, 10, "StandardSSD_LRS")},
+			New:      []interface{}{dataDisk(0, 20, "Premium_LRS")},
+			Expected: false,
+		},
+		{
+			Name:     "Adding a Data Disk",
+			Old:      []interface{}{dataDisk(0, 10, "Premium_LRS")},
+			New:      []interface{}{dataDisk(0, 10, "Premium_LRS"), dataDisk(1, 10, "Premium_LRS")},
+			Expected: false,
+		},
+		{
+			Name:     "Shrinking a Data Disk",
+			Old:      []interface{}{dataDisk(0, 20, "Premium_LRS")},
+			New:      []interface{}{dataDisk(0, 10, "Premium_LRS")},
+			Expected: false,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		actual := determineIfVirtualMachineScaleSetDataDisksSupportNoDowntimeResize(v.Old, v.New)
+		if actual.Supported != v.Expected {
+			t.Fatalf("Expected %t but got %t (%s)", v.Expected, actual.Supported, actual.Reason)
+		}
+	}
+}
@@ -4,15 +4,15 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
-	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/galleries"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
 func dataSourceSharedImageGallery() *pluginsdk.Resource {
@@ -44,7 +44,7 @@ func dataSourceSharedImageGallery() *pluginsdk.Resource {
 				Computed: true,
 			},
 
-			"tags": commonschema.TagsDataSource(),
+			"tags": tags.SchemaDataSource(),
 		},
 	}
 }
@@ -56,9 +56,9 @@ func dataSourceSharedImageGalleryRead(d *pluginsdk.ResourceData, meta interface{
 	defer cancel()
 
 	id := galleries.NewGalleryID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
-	resp, err := client.Get(ctx, id, galleries.DefaultGetOperationOptions())
+	resp, err := client.Get(ctx, id.ResourceGroupName, id.GalleryName, "", "")
 	if err != nil {
-		if response.WasNotFound(resp.HttpResponse) {
+		if utils.ResponseWasNotFound(resp.Response) {
 			return fmt.Errorf("%s was not found", id)
 		}
 
@@ -69,22 +69,16 @@ func dataSourceSharedImageGalleryRead(d *pluginsdk.ResourceData, meta interface{
 	d.Set("name", id.GalleryName)
 	d.Set("resource_group_name", id.ResourceGroupName)
 
-	if model := resp.Model; model != nil {
-		d.Set("location", location.Normalize(model.Location))
+	d.Set("location", location.NormalizeNilable(resp.Location))
 
-		if props := model.Properties; props != nil {
-			d.Set("description", props.Description)
-			uniqueName := ""
-			if props.Identifier != nil && props.Identifier.UniqueName != nil {
-				uniqueName = *props.Identifier.UniqueName
-			}
-			d.Set("unique_name", uniqueName)
-		}
-
-		if err := tags.FlattenAndSet(d, model.Tags); err != nil {
-			return fmt.Errorf("setting `tags`: %+v", err)
+	if props := resp.GalleryProperties; props != nil {
+		d.Set("description", props.Description)
+		uniqueName := ""
+		if props.Identifier != nil && props.Identifier.UniqueName != nil {
+			uniqueName = *props.Identifier.UniqueName
 		}
+		d.Set("unique_name", uniqueName)
 	}
 
-	return nil
+	return tags.FlattenAndSet(d, resp.Tags)
 }
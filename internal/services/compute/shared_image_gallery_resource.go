@@ -1,28 +1,30 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/hashicorp/go-azure-helpers/lang/pointer"
-	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
-	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/galleries"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
 )
 
 func resourceSharedImageGallery() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
-		Create: resourceSharedImageGalleryCreateUpdate,
+		Create: resourceSharedImageGalleryCreate,
 		Read:   resourceSharedImageGalleryRead,
-		Update: resourceSharedImageGalleryCreateUpdate,
+		Update: resourceSharedImageGalleryUpdate,
 		Delete: resourceSharedImageGalleryDelete,
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := galleries.ParseGalleryID(id)
@@ -53,49 +55,246 @@ func resourceSharedImageGallery() *pluginsdk.Resource {
 				Optional: true,
 			},
 
-			"tags": commonschema.Tags(),
+			"sharing": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"permission": {
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(compute.GallerySharingPermissionTypesCommunity),
+								string(compute.GallerySharingPermissionTypesGroups),
+							}, false),
+						},
+
+						"community_gallery": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"eula": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+									},
+
+									"prefix": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"publisher_email": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"publisher_uri": {
+										Type:         pluginsdk.TypeString,
+										Required:     true,
+										ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+									},
+
+									"name": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+
+						"subscription_ids": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.IsUUID,
+							},
+						},
+
+						"tenant_ids": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.IsUUID,
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tags.Schema(),
 
 			"unique_name": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(func(ctx context.Context, diff *pluginsdk.ResourceDiff, v interface{}) error {
+			sharing := diff.Get("sharing").([]interface{})
+			if len(sharing) == 0 || sharing[0] == nil {
+				return nil
+			}
+
+			raw := sharing[0].(map[string]interface{})
+			permission := raw["permission"].(string)
+			hasCommunityGallery := len(raw["community_gallery"].([]interface{})) > 0
+			hasGroups := raw["subscription_ids"].(*pluginsdk.Set).Len() > 0 || raw["tenant_ids"].(*pluginsdk.Set).Len() > 0
+
+			if permission == string(compute.GallerySharingPermissionTypesCommunity) && !hasCommunityGallery {
+				return fmt.Errorf("`community_gallery` must be specified when `permission` is set to `Community`")
+			}
+			if permission != string(compute.GallerySharingPermissionTypesCommunity) && hasCommunityGallery {
+				return fmt.Errorf("`community_gallery` can only be specified when `permission` is set to `Community`")
+			}
+			if permission != string(compute.GallerySharingPermissionTypesGroups) && hasGroups {
+				return fmt.Errorf("`subscription_ids` and `tenant_ids` can only be specified when `permission` is set to `Groups`")
+			}
+
+			return nil
+		}),
 	}
 }
 
-func resourceSharedImageGalleryCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+func resourceSharedImageGalleryCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Compute.GalleriesClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
 	id := galleries.NewGalleryID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
-	if d.IsNewResource() {
-		existing, err := client.Get(ctx, id, galleries.DefaultGetOperationOptions())
-		if err != nil {
-			if !response.WasNotFound(existing.HttpResponse) {
-				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
-			}
+	existing, err := client.Get(ctx, id.ResourceGroupName, id.GalleryName, "", "")
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
 		}
+	}
 
-		if !response.WasNotFound(existing.HttpResponse) {
-			return tf.ImportAsExistsError("azurerm_shared_image_gallery", id.ID())
-		}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return tf.ImportAsExistsError("azurerm_shared_image_gallery", id.ID())
 	}
 
-	payload := galleries.Gallery{
-		Location: location.Normalize(d.Get("location").(string)),
-		Properties: &galleries.GalleryProperties{
-			Description: pointer.To(d.Get("description").(string)),
+	payload := compute.Gallery{
+		Location: utils.String(location.Normalize(d.Get("location").(string))),
+		GalleryProperties: &compute.GalleryProperties{
+			Description:    utils.String(d.Get("description").(string)),
+			SharingProfile: expandSharedImageGallerySharingProfile(d.Get("sharing").([]interface{})),
 		},
 		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
 	}
 
-	if err := client.CreateOrUpdateThenPoll(ctx, id, payload); err != nil {
-		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroupName, id.GalleryName, payload)
+	if err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
 	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+	}
+
 	d.SetId(id.ID())
 
+	if d.Get("sharing.0.permission").(string) == string(compute.GallerySharingPermissionTypesCommunity) {
+		if err := updateSharedImageGallerySharingProfile(ctx, meta, id, compute.SharingUpdateOperationTypesEnableCommunity, nil); err != nil {
+			return err
+		}
+	}
+
+	toAdd := expandSharedImageGallerySharingGroups(d.Get("sharing.0.subscription_ids").(*pluginsdk.Set), d.Get("sharing.0.tenant_ids").(*pluginsdk.Set))
+	if len(toAdd) > 0 {
+		if err := updateSharedImageGallerySharingProfile(ctx, meta, id, compute.SharingUpdateOperationTypesAdd, &toAdd); err != nil {
+			return err
+		}
+	}
+
+	return resourceSharedImageGalleryRead(d, meta)
+}
+
+func resourceSharedImageGalleryUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.GalleriesClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := galleries.ParseGalleryID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChanges("sharing.0.permission", "sharing.0.community_gallery") {
+		// the existing sharing (e.g. the Groups, or the Community Gallery) has to be reset before the permission can be changed
+		if oldPermission, _ := d.GetChange("sharing.0.permission"); oldPermission.(string) != "" {
+			if err := updateSharedImageGallerySharingProfile(ctx, meta, *id, compute.SharingUpdateOperationTypesReset, nil); err != nil {
+				return err
+			}
+		}
+
+		// `Private` is represented by omitting the `sharing` block
+		sharingProfile := expandSharedImageGallerySharingProfile(d.Get("sharing").([]interface{}))
+		if sharingProfile == nil {
+			sharingProfile = &compute.SharingProfile{
+				Permissions: compute.GallerySharingPermissionTypesPrivate,
+			}
+		}
+
+		payload := compute.GalleryUpdate{
+			GalleryProperties: &compute.GalleryProperties{
+				SharingProfile: sharingProfile,
+			},
+		}
+		if err := updateSharedImageGallery(ctx, client, *id, payload); err != nil {
+			return err
+		}
+
+		if sharingProfile.Permissions == compute.GallerySharingPermissionTypesCommunity {
+			if err := updateSharedImageGallerySharingProfile(ctx, meta, *id, compute.SharingUpdateOperationTypesEnableCommunity, nil); err != nil {
+				return err
+			}
+		}
+
+		// resetting the sharing profile removes all of the Groups, so any which are configured need to be added again
+		toAdd := expandSharedImageGallerySharingGroups(d.Get("sharing.0.subscription_ids").(*pluginsdk.Set), d.Get("sharing.0.tenant_ids").(*pluginsdk.Set))
+		if len(toAdd) > 0 {
+			if err := updateSharedImageGallerySharingProfile(ctx, meta, *id, compute.SharingUpdateOperationTypesAdd, &toAdd); err != nil {
+				return err
+			}
+		}
+	} else if d.HasChanges("sharing.0.subscription_ids", "sharing.0.tenant_ids") {
+		oldSubscriptions, newSubscriptions := d.GetChange("sharing.0.subscription_ids")
+		oldTenants, newTenants := d.GetChange("sharing.0.tenant_ids")
+
+		toRemove := expandSharedImageGallerySharingGroups(oldSubscriptions.(*pluginsdk.Set).Difference(newSubscriptions.(*pluginsdk.Set)), oldTenants.(*pluginsdk.Set).Difference(newTenants.(*pluginsdk.Set)))
+		if len(toRemove) > 0 {
+			if err := updateSharedImageGallerySharingProfile(ctx, meta, *id, compute.SharingUpdateOperationTypesRemove, &toRemove); err != nil {
+				return err
+			}
+		}
+
+		toAdd := expandSharedImageGallerySharingGroups(newSubscriptions.(*pluginsdk.Set).Difference(oldSubscriptions.(*pluginsdk.Set)), newTenants.(*pluginsdk.Set).Difference(oldTenants.(*pluginsdk.Set)))
+		if len(toAdd) > 0 {
+			if err := updateSharedImageGallerySharingProfile(ctx, meta, *id, compute.SharingUpdateOperationTypesAdd, &toAdd); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChanges("description", "tags") {
+		payload := compute.GalleryUpdate{
+			GalleryProperties: &compute.GalleryProperties{
+				Description: utils.String(d.Get("description").(string)),
+			},
+			Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+		}
+		if err := updateSharedImageGallery(ctx, client, *id, payload); err != nil {
+			return err
+		}
+	}
+
 	return resourceSharedImageGalleryRead(d, meta)
 }
 
@@ -109,9 +308,9 @@ func resourceSharedImageGalleryRead(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
-	resp, err := client.Get(ctx, *id, galleries.DefaultGetOperationOptions())
+	resp, err := client.Get(ctx, id.ResourceGroupName, id.GalleryName, "", compute.GalleryExpandParamsSharingProfileGroups)
 	if err != nil {
-		if response.WasNotFound(resp.HttpResponse) {
+		if utils.ResponseWasNotFound(resp.Response) {
 			log.Printf("[DEBUG] %s was not found - removing from state", *id)
 			d.SetId("")
 			return nil
@@ -122,26 +321,23 @@ func resourceSharedImageGalleryRead(d *pluginsdk.ResourceData, meta interface{})
 
 	d.Set("name", id.GalleryName)
 	d.Set("resource_group_name", id.ResourceGroupName)
+	d.Set("location", location.NormalizeNilable(resp.Location))
 
-	if model := resp.Model; model != nil {
-		d.Set("location", location.Normalize(model.Location))
-
-		if props := model.Properties; props != nil {
-			d.Set("description", props.Description)
+	if props := resp.GalleryProperties; props != nil {
+		d.Set("description", props.Description)
 
-			uniqueName := ""
-			if props.Identifier != nil && props.Identifier.UniqueName != nil {
-				uniqueName = *props.Identifier.UniqueName
-			}
-			d.Set("unique_name", uniqueName)
+		uniqueName := ""
+		if props.Identifier != nil && props.Identifier.UniqueName != nil {
+			uniqueName = *props.Identifier.UniqueName
 		}
+		d.Set("unique_name", uniqueName)
 
-		if err := tags.FlattenAndSet(d, model.Tags); err != nil {
-			return fmt.Errorf("setting `tags`: %+v", err)
+		if err := d.Set("sharing", flattenSharedImageGallerySharingProfile(props.SharingProfile)); err != nil {
+			return fmt.Errorf("setting `sharing`: %+v", err)
 		}
 	}
 
-	return nil
+	return tags.FlattenAndSet(d, resp.Tags)
 }
 
 func resourceSharedImageGalleryDelete(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -154,9 +350,140 @@ func resourceSharedImageGalleryDelete(d *pluginsdk.ResourceData, meta interface{
 		return err
 	}
 
-	if err := client.DeleteThenPoll(ctx, *id); err != nil {
+	// a gallery which is shared can't be deleted, so the sharing profile needs to be reset first
+	if len(d.Get("sharing").([]interface{})) > 0 {
+		if err := updateSharedImageGallerySharingProfile(ctx, meta, *id, compute.SharingUpdateOperationTypesReset, nil); err != nil {
+			return err
+		}
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroupName, id.GalleryName)
+	if err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func updateSharedImageGallery(ctx context.Context, client *compute.GalleriesClient, id galleries.GalleryId, payload compute.GalleryUpdate) error {
+	future, err := client.Update(ctx, id.ResourceGroupName, id.GalleryName, payload)
+	if err != nil {
+		return fmt.Errorf("updating %s: %+v", id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func updateSharedImageGallerySharingProfile(ctx context.Context, meta interface{}, id galleries.GalleryId, operation compute.SharingUpdateOperationTypes, groups *[]compute.SharingProfileGroup) error {
+	client := meta.(*clients.Client).Compute.GallerySharingProfileClient
+
+	payload := compute.SharingUpdate{
+		OperationType: operation,
+		Groups:        groups,
+	}
+
+	future, err := client.Update(ctx, id.ResourceGroupName, id.GalleryName, payload)
+	if err != nil {
+		return fmt.Errorf("updating the sharing profile of %s (operation %q): %+v", id, string(operation), err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the sharing profile of %s to be updated (operation %q): %+v", id, string(operation), err)
+	}
 
 	return nil
 }
+
+func expandSharedImageGallerySharingProfile(input []interface{}) *compute.SharingProfile {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	output := &compute.SharingProfile{
+		Permissions: compute.GallerySharingPermissionTypes(raw["permission"].(string)),
+	}
+
+	if v := raw["community_gallery"].([]interface{}); len(v) > 0 && v[0] != nil {
+		communityGallery := v[0].(map[string]interface{})
+		output.CommunityGalleryInfo = &compute.CommunityGalleryInfo{
+			Eula:             utils.String(communityGallery["eula"].(string)),
+			PublicNamePrefix: utils.String(communityGallery["prefix"].(string)),
+			PublisherContact: utils.String(communityGallery["publisher_email"].(string)),
+			PublisherURI:     utils.String(communityGallery["publisher_uri"].(string)),
+		}
+	}
+
+	return output
+}
+
+func expandSharedImageGallerySharingGroups(subscriptionIds *pluginsdk.Set, tenantIds *pluginsdk.Set) []compute.SharingProfileGroup {
+	output := make([]compute.SharingProfileGroup, 0)
+
+	if subscriptionIds.Len() > 0 {
+		output = append(output, compute.SharingProfileGroup{
+			Type: compute.SharingProfileGroupTypesSubscriptions,
+			Ids:  utils.ExpandStringSlice(subscriptionIds.List()),
+		})
+	}
+
+	if tenantIds.Len() > 0 {
+		output = append(output, compute.SharingProfileGroup{
+			Type: compute.SharingProfileGroupTypesAADTenants,
+			Ids:  utils.ExpandStringSlice(tenantIds.List()),
+		})
+	}
+
+	return output
+}
+
+func flattenSharedImageGallerySharingProfile(input *compute.SharingProfile) []interface{} {
+	// `Private` is the default and is represented by omitting the `sharing` block
+	if input == nil || input.Permissions == "" || input.Permissions == compute.GallerySharingPermissionTypesPrivate {
+		return []interface{}{}
+	}
+
+	communityGallery := make([]interface{}, 0)
+	if info := input.CommunityGalleryInfo; info != nil {
+		name := ""
+		if info.PublicNames != nil && len(*info.PublicNames) > 0 {
+			name = (*info.PublicNames)[0]
+		}
+
+		communityGallery = append(communityGallery, map[string]interface{}{
+			"eula":            utils.NormalizeNilableString(info.Eula),
+			"name":            name,
+			"prefix":          utils.NormalizeNilableString(info.PublicNamePrefix),
+			"publisher_email": utils.NormalizeNilableString(info.PublisherContact),
+			"publisher_uri":   utils.NormalizeNilableString(info.PublisherURI),
+		})
+	}
+
+	subscriptionIds := make([]interface{}, 0)
+	tenantIds := make([]interface{}, 0)
+	if input.Groups != nil {
+		for _, group := range *input.Groups {
+			ids := utils.FlattenStringSlice(group.Ids)
+			switch group.Type {
+			case compute.SharingProfileGroupTypesSubscriptions:
+				subscriptionIds = append(subscriptionIds, ids...)
+			case compute.SharingProfileGroupTypesAADTenants:
+				tenantIds = append(tenantIds, ids...)
+			}
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"community_gallery": communityGallery,
+			"permission":        string(input.Permissions),
+			"subscription_ids":  subscriptionIds,
+			"tenant_ids":        tenantIds,
+		},
+	}
+}
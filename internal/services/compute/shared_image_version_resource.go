@@ -173,6 +173,53 @@ func resourceSharedImageVersion() *pluginsdk.Resource {
 				Default:  false,
 			},
 
+			"wait_for_replicated_region_count": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"replication_status": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"aggregated_state": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"region": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"state": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"progress": {
+										Type:     pluginsdk.TypeInt,
+										Computed: true,
+									},
+
+									"details": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
 			"tags": tags.Schema(),
 		},
 
@@ -210,6 +257,11 @@ func resourceSharedImageVersionCreateUpdate(d *pluginsdk.ResourceData, meta inte
 		return err
 	}
 
+	waitForRegionCount := d.Get("wait_for_replicated_region_count").(int)
+	if waitForRegionCount > len(*targetRegions) {
+		return fmt.Errorf("`wait_for_replicated_region_count` (%d) cannot be greater than the number of `target_region` blocks (%d)", waitForRegionCount, len(*targetRegions))
+	}
+
 	version := compute.GalleryImageVersion{
 		Location: utils.String(azure.NormalizeLocation(d.Get("location").(string))),
 		GalleryImageVersionProperties: &compute.GalleryImageVersionProperties{
@@ -258,7 +310,23 @@ func resourceSharedImageVersionCreateUpdate(d *pluginsdk.ResourceData, meta inte
 		return fmt.Errorf("creating %s: %+v", id, err)
 	}
 
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	if waitForRegionCount > 0 {
+		// replicating to every target region can take hours, so when only some of the regions are needed
+		// we poll the replication status rather than waiting for the whole operation to complete
+		log.Printf("[DEBUG] Waiting for %s to be replicated to %d region(s)", id, waitForRegionCount)
+		timeout, _ := ctx.Deadline()
+		stateConf := &pluginsdk.StateChangeConf{
+			Pending:    []string{"Replicating"},
+			Target:     []string{"Replicated"},
+			Refresh:    sharedImageVersionReplicationStateRefreshFunc(ctx, client, id, waitForRegionCount),
+			MinTimeout: 30 * time.Second,
+			Timeout:    time.Until(timeout),
+		}
+
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("waiting for %s to be replicated to %d region(s): %+v", id, waitForRegionCount, err)
+		}
+	} else if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
 		return fmt.Errorf("waiting for the creation of %s: %+v", id, err)
 	}
 
@@ -339,6 +407,10 @@ func resourceSharedImageVersionRead(d *pluginsdk.ResourceData, meta interface{})
 			d.Set("os_disk_snapshot_id", osDiskSnapShotID)
 			d.Set("storage_account_id", storageAccountID)
 		}
+
+		if err := d.Set("replication_status", flattenSharedImageVersionReplicationStatus(props.ReplicationStatus)); err != nil {
+			return fmt.Errorf("setting `replication_status`: %+v", err)
+		}
 	}
 
 	return tags.FlattenAndSet(d, resp.Tags)
@@ -400,6 +472,48 @@ func sharedImageVersionDeleteStateRefreshFunc(ctx context.Context, client *compu
 	}
 }
 
+func sharedImageVersionReplicationStateRefreshFunc(ctx context.Context, client *compute.GalleryImageVersionsClient, id parse.SharedImageVersionId, regionCount int) pluginsdk.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		res, err := client.Get(ctx, id.ResourceGroup, id.GalleryName, id.ImageName, id.VersionName, compute.ReplicationStatusTypesReplicationStatus)
+		if err != nil {
+			// the version may not be returned until the create request has been accepted
+			if utils.ResponseWasNotFound(res.Response) {
+				return res, "Replicating", nil
+			}
+
+			return nil, "", fmt.Errorf("polling for the replication status of %s: %+v", id, err)
+		}
+
+		props := res.GalleryImageVersionProperties
+		if props == nil {
+			return res, "Replicating", nil
+		}
+
+		if props.ProvisioningState == compute.GalleryProvisioningStateFailed {
+			return nil, "", fmt.Errorf("provisioning of %s failed", id)
+		}
+
+		completed := 0
+		if props.ReplicationStatus != nil && props.ReplicationStatus.Summary != nil {
+			for _, region := range *props.ReplicationStatus.Summary {
+				switch region.State {
+				case compute.ReplicationStateCompleted:
+					completed++
+				case compute.ReplicationStateFailed:
+					return nil, "", fmt.Errorf("replication of %s to %q failed: %s", id, utils.NormalizeNilableString(region.Region), utils.NormalizeNilableString(region.Details))
+				}
+			}
+		}
+
+		log.Printf("[DEBUG] %s has been replicated to %d of the %d required region(s)", id, completed, regionCount)
+		if completed >= regionCount {
+			return res, "Replicated", nil
+		}
+
+		return res, "Replicating", nil
+	}
+}
+
 func expandSharedImageVersionTargetRegions(d *pluginsdk.ResourceData) (*[]compute.TargetRegion, error) {
 	vs := d.Get("target_region").([]interface{})
 	results := make([]compute.TargetRegion, 0)
@@ -465,3 +579,38 @@ func flattenSharedImageVersionTargetRegions(input *[]compute.TargetRegion) []int
 
 	return results
 }
+
+func flattenSharedImageVersionReplicationStatus(input *compute.ReplicationStatus) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	regions := make([]interface{}, 0)
+	if input.Summary != nil {
+		for _, v := range *input.Summary {
+			name := ""
+			if v.Region != nil {
+				name = azure.NormalizeLocation(*v.Region)
+			}
+
+			progress := 0
+			if v.Progress != nil {
+				progress = int(*v.Progress)
+			}
+
+			regions = append(regions, map[string]interface{}{
+				"name":     name,
+				"state":    string(v.State),
+				"progress": progress,
+				"details":  utils.NormalizeNilableString(v.Details),
+			})
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"aggregated_state": string(input.AggregatedState),
+			"region":           regions,
+		},
+	}
+}
@@ -231,6 +231,31 @@ func TestAccSharedImageVersion_replicationMode(t *testing.T) {
 	})
 }
 
+func TestAccSharedImageVersion_waitForReplicatedRegionCount(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_shared_image_version", "test")
+	r := SharedImageVersionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			// need to create a vm and then reference it in the image creation
+			Config: r.setup(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				data.CheckWithClientForResource(ImageResource{}.virtualMachineExists, "azurerm_virtual_machine.testsource"),
+				data.CheckWithClientForResource(ImageResource{}.generalizeVirtualMachine(data), "azurerm_virtual_machine.testsource"),
+			),
+		},
+		{
+			Config: r.waitForReplicatedRegionCount(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("replication_status.#").HasValue("1"),
+				check.That(data.ResourceName).Key("replication_status.0.region.#").HasValue("2"),
+			),
+		},
+		data.ImportStep("wait_for_replicated_region_count"),
+	})
+}
+
 func TestAccSharedImageVersion_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_shared_image_version", "test")
 	r := SharedImageVersionResource{}
@@ -688,3 +713,30 @@ resource "azurerm_shared_image_version" "test" {
 }
 `, template)
 }
+
+func (r SharedImageVersionResource) waitForReplicatedRegionCount(data acceptance.TestData) string {
+	template := r.provision(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_shared_image_version" "test" {
+  name                             = "0.0.1"
+  gallery_name                     = azurerm_shared_image_gallery.test.name
+  image_name                       = azurerm_shared_image.test.name
+  resource_group_name              = azurerm_resource_group.test.name
+  location                         = azurerm_resource_group.test.location
+  managed_image_id                 = azurerm_image.test.id
+  wait_for_replicated_region_count = 1
+
+  target_region {
+    name                   = azurerm_resource_group.test.location
+    regional_replica_count = 1
+  }
+
+  target_region {
+    name                   = "%s"
+    regional_replica_count = 1
+  }
+}
+`, template, data.Locations.Secondary)
+}
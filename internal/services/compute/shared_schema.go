@@ -331,15 +331,16 @@ func flattenPlan(input *compute.Plan) []interface{} {
 	}
 }
 
-func sourceImageReferenceSchema(isVirtualMachine bool) *pluginsdk.Schema {
+func sourceImageReferenceSchema() *pluginsdk.Schema {
 	// whilst originally I was hoping we could use the 'id' from `azurerm_platform_image' unfortunately Azure doesn't
 	// like this as a value for the 'id' field:
 	// Id /...../Versions/16.04.201909091 is not a valid resource reference."
 	// as such the image is split into two fields (source_image_id and source_image_reference) to provide better validation
+	// for Virtual Machines changing the `sku` or `version` forces a new resource unless the OS Disk is being swapped
+	// in-place, which is handled in `virtualMachineSourceImageCustomizeDiff`
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeList,
 		Optional: true,
-		ForceNew: isVirtualMachine,
 		MaxItems: 1,
 		ExactlyOneOf: []string{
 			"source_image_id",
@@ -362,13 +363,11 @@ func sourceImageReferenceSchema(isVirtualMachine bool) *pluginsdk.Schema {
 				"sku": {
 					Type:         pluginsdk.TypeString,
 					Required:     true,
-					ForceNew:     isVirtualMachine,
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
 				"version": {
 					Type:         pluginsdk.TypeString,
 					Required:     true,
-					ForceNew:     isVirtualMachine,
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
 			},
@@ -230,6 +230,11 @@ func virtualMachineOSDiskSchema() *pluginsdk.Schema {
 					Optional: true,
 					ForceNew: true,
 					Computed: true,
+					// a swapped in OS Disk (either when the Source Image changes or when the OS Disk is reimaged) is given a
+					// new name, so once the OS Disk has been swapped the name is only used when the Virtual Machine is created
+					DiffSuppressFunc: func(_, old, _ string, d *pluginsdk.ResourceData) bool {
+						return old != "" && virtualMachineOSDiskWasSwapped(d.Get("name").(string), old)
+					},
 				},
 
 				"secure_vm_disk_encryption_set_id": {
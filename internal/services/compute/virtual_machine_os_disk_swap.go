@@ -0,0 +1,548 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-02/disks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+const (
+	virtualMachineSourceImageUpdateModeReplace    = "Replace"
+	virtualMachineSourceImageUpdateModeSwapOSDisk = "SwapOSDisk"
+
+	// virtualMachineOSDiskSwapTimestampFormat is the format of the timestamp used in the name of a swapped in OS Disk
+	virtualMachineOSDiskSwapTimestampFormat = "20060102150405"
+)
+
+func virtualMachineSourceImageUpdateModeSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+		Default:  virtualMachineSourceImageUpdateModeReplace,
+		ValidateFunc: validation.StringInSlice([]string{
+			virtualMachineSourceImageUpdateModeReplace,
+			virtualMachineSourceImageUpdateModeSwapOSDisk,
+		}, false),
+	}
+}
+
+// virtualMachineSourceImageCustomizeDiff forces a new Virtual Machine to be created when the Source Image changes, unless
+// the Virtual Machine has opted into swapping the OS Disk in-place, which is only possible for Managed OS Disks
+func virtualMachineSourceImageCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	swapOSDisk := d.Get("source_image_update_mode").(string) == virtualMachineSourceImageUpdateModeSwapOSDisk
+	hasEphemeralOSDisk := len(d.Get("os_disk.0.diff_disk_settings").([]interface{})) > 0
+
+	if v := d.Get("os_disk_source_snapshot_id").(string); v != "" {
+		if !swapOSDisk {
+			return fmt.Errorf("`os_disk_source_snapshot_id` can only be specified when `source_image_update_mode` is set to %q", virtualMachineSourceImageUpdateModeSwapOSDisk)
+		}
+		if hasEphemeralOSDisk {
+			return fmt.Errorf("`os_disk_source_snapshot_id` cannot be specified when using an Ephemeral OS Disk")
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	for _, key := range []string{"source_image_id", "source_image_reference"} {
+		if !d.HasChange(key) {
+			continue
+		}
+
+		// Ephemeral OS Disks can only be reimaged from the Image the Virtual Machine was provisioned from
+		if swapOSDisk && !hasEphemeralOSDisk {
+			continue
+		}
+
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+
+	// when the OS Disk is swapped (rather than being created from a Snapshot) the new Managed Disk is created from the
+	// Source Image, which isn't possible for all types of Source Image
+	snapshotId := d.Get("os_disk_source_snapshot_id").(string)
+	sourceImageChanged := d.HasChanges("source_image_id", "source_image_reference") || (d.HasChange("os_disk_source_snapshot_id") && snapshotId != "")
+	swapsOSDisk := !hasEphemeralOSDisk && (d.HasChange("reimage_triggers") || (swapOSDisk && sourceImageChanged))
+	if swapsOSDisk && snapshotId == "" {
+		if v := d.Get("source_image_id").(string); v != "" && !virtualMachineSourceImageIdSupportsOSDiskSwap(v) {
+			return fmt.Errorf("the OS Disk can only be swapped when `source_image_id` is the ID of a Shared Image Version, Shared Gallery Image Version or Community Gallery Image Version, or when `os_disk_source_snapshot_id` is specified")
+		}
+	}
+
+	return nil
+}
+
+// virtualMachineSourceImageIdSupportsOSDiskSwap returns whether a Managed Disk can be created from the specified Source
+// Image ID - which is possible for Image Versions but not for (Managed) Images or Shared Images without a version
+func virtualMachineSourceImageIdSupportsOSDiskSwap(sourceImageId string) bool {
+	for _, validateFunc := range []pluginsdk.SchemaValidateFunc{
+		computeValidate.SharedImageVersionID,
+		computeValidate.SharedGalleryImageVersionID,
+		computeValidate.CommunityGalleryImageVersionID,
+	} {
+		if _, errs := validateFunc(sourceImageId, "source_image_id"); len(errs) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// virtualMachineOSDiskWasSwapped returns whether the OS Disk of the Virtual Machine has previously been swapped, based
+// on the name which is given to OS Disks when they're swapped in
+func virtualMachineOSDiskWasSwapped(virtualMachineName, osDiskName string) bool {
+	return regexp.MustCompile(fmt.Sprintf(`^%s_OsDisk_\d{%d}$`, regexp.QuoteMeta(virtualMachineName), len(virtualMachineOSDiskSwapTimestampFormat))).MatchString(osDiskName)
+}
+
+// flattenVirtualMachineSourceImage returns the `source_image_id` and `source_image_reference` of the Virtual Machine.
+// Swapping the OS Disk doesn't change the Image Reference of the Virtual Machine (which continues to point at the Image
+// the Virtual Machine was provisioned from), so when the OS Disk can be swapped these are taken from the OS Disk instead
+func flattenVirtualMachineSourceImage(ctx context.Context, d *pluginsdk.ResourceData, disksClient *disks.DisksClient, profile compute.StorageProfile) (string, []interface{}, error) {
+	var sourceImageId string
+	if ref := profile.ImageReference; ref != nil {
+		if ref.ID != nil {
+			sourceImageId = *ref.ID
+		}
+		if ref.CommunityGalleryImageID != nil {
+			sourceImageId = *ref.CommunityGalleryImageID
+		}
+		if ref.SharedGalleryImageID != nil {
+			sourceImageId = *ref.SharedGalleryImageID
+		}
+	}
+	sourceImageReference := flattenSourceImageReference(profile.ImageReference, sourceImageId != "")
+
+	if d.Get("source_image_update_mode").(string) != virtualMachineSourceImageUpdateModeSwapOSDisk {
+		return sourceImageId, sourceImageReference, nil
+	}
+	if profile.OsDisk == nil || profile.OsDisk.ManagedDisk == nil || profile.OsDisk.ManagedDisk.ID == nil {
+		return sourceImageId, sourceImageReference, nil
+	}
+
+	diskId, err := disks.ParseDiskIDInsensitively(*profile.OsDisk.ManagedDisk.ID)
+	if err != nil {
+		return "", nil, err
+	}
+	disk, err := disksClient.Get(ctx, *diskId)
+	if err != nil {
+		return "", nil, fmt.Errorf("retrieving OS Disk %s: %+v", *diskId, err)
+	}
+	if disk.Model == nil || disk.Model.Properties == nil {
+		return sourceImageId, sourceImageReference, nil
+	}
+
+	creationData := disk.Model.Properties.CreationData
+	if v := creationData.GalleryImageReference; v != nil {
+		if v.SharedGalleryImageId != nil {
+			return *v.SharedGalleryImageId, []interface{}{}, nil
+		}
+		if v.CommunityGalleryImageId != nil {
+			return *v.CommunityGalleryImageId, []interface{}{}, nil
+		}
+		if v.Id != nil {
+			return *v.Id, []interface{}{}, nil
+		}
+	}
+	if v := creationData.ImageReference; v != nil && v.Id != nil {
+		if reference := flattenPlatformImageVersionID(*v.Id, d.Get("source_image_reference.0.version").(string)); reference != nil {
+			return "", reference, nil
+		}
+	}
+
+	// OS Disks created from a Snapshot don't reference an Image, so the Image the Virtual Machine was provisioned from is used
+	return sourceImageId, sourceImageReference, nil
+}
+
+// flattenPlatformImageVersionID flattens the ID of a Platform Image Version (in the format
+// `/Subscriptions/{id}/Providers/Microsoft.Compute/Locations/{location}/Publishers/{publisher}/ArtifactTypes/VMImage/Offers/{offer}/Skus/{sku}/Versions/{version}`)
+// into a `source_image_reference` block - returning nil if the ID isn't for a Platform Image Version
+func flattenPlatformImageVersionID(input string, configuredVersion string) []interface{} {
+	values := make(map[string]string)
+	segments := strings.Split(strings.Trim(input, "/"), "/")
+	for i := 0; i+1 < len(segments); i += 2 {
+		values[strings.ToLower(segments[i])] = segments[i+1]
+	}
+
+	publisher, offer, sku, version := values["publishers"], values["offers"], values["skus"], values["versions"]
+	if publisher == "" || offer == "" || sku == "" || version == "" {
+		return nil
+	}
+
+	// the Virtual Machine API returns `latest` when the Virtual Machine is provisioned from the latest version, so
+	// the same is done here (rather than returning the version which `latest` was resolved to)
+	if strings.EqualFold(configuredVersion, "latest") {
+		version = configuredVersion
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"publisher": publisher,
+			"offer":     offer,
+			"sku":       sku,
+			"version":   version,
+		},
+	}
+}
+
+// virtualMachineOSDiskShouldBeSwapped returns whether the OS Disk of the Virtual Machine needs to be replaced by a new
+// Managed Disk, either because the Source Image has changed or because the OS Disk is being reimaged
+func virtualMachineOSDiskShouldBeSwapped(d *pluginsdk.ResourceData, hasEphemeralOSDisk bool) bool {
+	if hasEphemeralOSDisk {
+		return false
+	}
+
+	if d.HasChange("reimage_triggers") {
+		return true
+	}
+
+	if d.Get("source_image_update_mode").(string) != virtualMachineSourceImageUpdateModeSwapOSDisk {
+		return false
+	}
+
+	return d.HasChanges("source_image_id", "source_image_reference") || (d.HasChange("os_disk_source_snapshot_id") && d.Get("os_disk_source_snapshot_id").(string) != "")
+}
+
+type virtualMachineOSDiskSwap struct {
+	NewDiskId      disks.DiskId
+	OriginalDisk   disks.DiskId
+	OriginalOSDisk compute.OSDisk
+}
+
+// createVirtualMachineOSDiskForSwap creates a new Managed Disk from either the Snapshot or the Source Image configured on
+// the Virtual Machine, using the settings of the existing OS Disk, which can then be swapped in as the OS Disk
+func createVirtualMachineOSDiskForSwap(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id parse.VirtualMachineId, existing compute.VirtualMachine) (*virtualMachineOSDiskSwap, error) {
+	disksClient := meta.(*clients.Client).Compute.DisksClient
+
+	if existing.VirtualMachineProperties == nil || existing.VirtualMachineProperties.StorageProfile == nil || existing.VirtualMachineProperties.StorageProfile.OsDisk == nil {
+		return nil, fmt.Errorf("retrieving %s: `storageProfile.osDisk` was nil", id)
+	}
+	osDisk := *existing.VirtualMachineProperties.StorageProfile.OsDisk
+	if osDisk.ManagedDisk == nil || osDisk.ManagedDisk.ID == nil {
+		return nil, fmt.Errorf("the OS Disk of %s can only be swapped when using a Managed Disk", id)
+	}
+
+	originalDiskId, err := disks.ParseDiskIDInsensitively(*osDisk.ManagedDisk.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := disksClient.Get(ctx, *originalDiskId)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving OS Disk %s: %+v", originalDiskId, err)
+	}
+	if original.Model == nil || original.Model.Properties == nil {
+		return nil, fmt.Errorf("retrieving OS Disk %s: `properties` was nil", originalDiskId)
+	}
+
+	creationData, err := expandVirtualMachineOSDiskSwapCreationData(ctx, d, meta, original.Model.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	newDiskName := fmt.Sprintf("%s_OsDisk_%s", id.Name, time.Now().UTC().Format(virtualMachineOSDiskSwapTimestampFormat))
+	newDiskId := disks.NewDiskID(originalDiskId.SubscriptionId, originalDiskId.ResourceGroupName, newDiskName)
+
+	props := original.Model.Properties
+	disk := disks.Disk{
+		ExtendedLocation: original.Model.ExtendedLocation,
+		Location:         original.Model.Location,
+		Sku:              original.Model.Sku,
+		Tags:             original.Model.Tags,
+		Zones:            original.Model.Zones,
+		Properties: &disks.DiskProperties{
+			CreationData:        *creationData,
+			DiskAccessId:        props.DiskAccessId,
+			Encryption:          props.Encryption,
+			HyperVGeneration:    props.HyperVGeneration,
+			NetworkAccessPolicy: props.NetworkAccessPolicy,
+			OsType:              props.OsType,
+			PublicNetworkAccess: props.PublicNetworkAccess,
+			SecurityProfile:     props.SecurityProfile,
+		},
+	}
+	if diskSizeGB := d.Get("os_disk.0.disk_size_gb").(int); diskSizeGB > 0 {
+		disk.Properties.DiskSizeGB = utils.Int64(int64(diskSizeGB))
+	}
+
+	log.Printf("[DEBUG] Creating %s to swap in as the OS Disk for %s..", newDiskId, id)
+	if err := disksClient.CreateOrUpdateThenPoll(ctx, newDiskId, disk); err != nil {
+		return nil, fmt.Errorf("creating %s to swap in as the OS Disk for %s: %+v", newDiskId, id, err)
+	}
+	log.Printf("[DEBUG] Created %s to swap in as the OS Disk for %s.", newDiskId, id)
+
+	return &virtualMachineOSDiskSwap{
+		NewDiskId:      newDiskId,
+		OriginalDisk:   *originalDiskId,
+		OriginalOSDisk: osDisk,
+	}, nil
+}
+
+func expandVirtualMachineOSDiskSwapCreationData(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, location string) (*disks.CreationData, error) {
+	if snapshotId := d.Get("os_disk_source_snapshot_id").(string); snapshotId != "" {
+		return &disks.CreationData{
+			CreateOption:     disks.DiskCreateOptionCopy,
+			SourceResourceId: utils.String(snapshotId),
+		}, nil
+	}
+
+	if sourceImageId := d.Get("source_image_id").(string); sourceImageId != "" {
+		if !virtualMachineSourceImageIdSupportsOSDiskSwap(sourceImageId) {
+			return nil, fmt.Errorf("the OS Disk can only be swapped when `source_image_id` is the ID of a Shared Image Version, Shared Gallery Image Version or Community Gallery Image Version, or when `os_disk_source_snapshot_id` is specified")
+		}
+
+		reference := disks.ImageDiskReference{
+			Id: utils.String(sourceImageId),
+		}
+		if _, errs := computeValidate.SharedGalleryImageVersionID(sourceImageId, "source_image_id"); len(errs) == 0 {
+			reference = disks.ImageDiskReference{
+				SharedGalleryImageId: utils.String(sourceImageId),
+			}
+		}
+		if _, errs := computeValidate.CommunityGalleryImageVersionID(sourceImageId, "source_image_id"); len(errs) == 0 {
+			reference = disks.ImageDiskReference{
+				CommunityGalleryImageId: utils.String(sourceImageId),
+			}
+		}
+
+		return &disks.CreationData{
+			CreateOption:          disks.DiskCreateOptionFromImage,
+			GalleryImageReference: &reference,
+		}, nil
+	}
+
+	sourceImageReferenceRaw := d.Get("source_image_reference").([]interface{})
+	if len(sourceImageReferenceRaw) == 0 || sourceImageReferenceRaw[0] == nil {
+		return nil, fmt.Errorf("either `source_image_id` or `source_image_reference` must be specified to swap the OS Disk")
+	}
+	raw := sourceImageReferenceRaw[0].(map[string]interface{})
+	publisher := raw["publisher"].(string)
+	offer := raw["offer"].(string)
+	sku := raw["sku"].(string)
+	version := raw["version"].(string)
+
+	// the Platform Image ID is required to create a Managed Disk, which also allows the `latest` version to be resolved
+	client := meta.(*clients.Client).Compute.VMImageClient
+	result, err := client.List(ctx, location, publisher, offer, sku, "", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Platform Images (Location %q / Publisher %q / Offer %q / SKU %q): %+v", location, publisher, offer, sku, err)
+	}
+	if result.Value == nil || len(*result.Value) == 0 {
+		return nil, fmt.Errorf("no Platform Images were found (Location %q / Publisher %q / Offer %q / SKU %q)", location, publisher, offer, sku)
+	}
+
+	var image *compute.VirtualMachineImageResource
+	if strings.EqualFold(version, "latest") {
+		image = latestPlatformImageVersion(*result.Value)
+	} else {
+		for _, item := range *result.Value {
+			if item.Name != nil && *item.Name == version {
+				item := item
+				image = &item
+				break
+			}
+		}
+	}
+	if image == nil || image.ID == nil {
+		return nil, fmt.Errorf("the Platform Image (Location %q / Publisher %q / Offer %q / SKU %q / Version %q) was not found", location, publisher, offer, sku, version)
+	}
+
+	return &disks.CreationData{
+		CreateOption: disks.DiskCreateOptionFromImage,
+		ImageReference: &disks.ImageDiskReference{
+			Id: image.ID,
+		},
+	}, nil
+}
+
+// latestPlatformImageVersion returns the Platform Image with the highest version - the versions of Platform Images are
+// made up of numeric segments (e.g. `18.04.202301100`) and so need to be compared numerically rather than lexically
+func latestPlatformImageVersion(input []compute.VirtualMachineImageResource) *compute.VirtualMachineImageResource {
+	images := make([]compute.VirtualMachineImageResource, 0)
+	for _, item := range input {
+		if item.Name != nil {
+			images = append(images, item)
+		}
+	}
+	if len(images) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(images, func(i, j int) bool {
+		return comparePlatformImageVersions(*images[i].Name, *images[j].Name) < 0
+	})
+
+	return &images[len(images)-1]
+}
+
+// comparePlatformImageVersions compares two Platform Image versions segment by segment, returning a negative number
+// when `a` is older than `b`, zero when they're the same and a positive number when `a` is newer than `b`
+func comparePlatformImageVersions(a, b string) int {
+	aSegments := strings.Split(a, ".")
+	bSegments := strings.Split(b, ".")
+	for i := 0; i < len(aSegments) && i < len(bSegments); i++ {
+		aValue, aErr := strconv.ParseInt(aSegments[i], 10, 64)
+		bValue, bErr := strconv.ParseInt(bSegments[i], 10, 64)
+		if aErr != nil || bErr != nil {
+			if c := strings.Compare(aSegments[i], bSegments[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		if aValue != bValue {
+			if aValue < bValue {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return len(aSegments) - len(bSegments)
+}
+
+// expandOSDisk returns the OS Disk block which swaps in the new Managed Disk, based on either the OS Disk being updated
+// at the same time or the existing OS Disk
+func (s virtualMachineOSDiskSwap) expandOSDisk(update *compute.OSDisk) *compute.OSDisk {
+	osDisk := s.OriginalOSDisk
+	if update != nil {
+		osDisk = *update
+	}
+
+	osDisk.Name = utils.String(s.NewDiskId.DiskName)
+	osDisk.ManagedDisk = &compute.ManagedDiskParameters{
+		ID: utils.String(s.NewDiskId.ID()),
+	}
+
+	return &osDisk
+}
+
+// deleteNewDisk deletes the Managed Disk which was created to be swapped in as the OS Disk, which is used when a later
+// step of the swap fails - any error is logged rather than returned so that the original error is surfaced
+func (s virtualMachineOSDiskSwap) deleteNewDisk(ctx context.Context, meta interface{}) {
+	disksClient := meta.(*clients.Client).Compute.DisksClient
+
+	log.Printf("[DEBUG] Deleting %s since it wasn't swapped in as the OS Disk..", s.NewDiskId)
+	if err := disksClient.DeleteThenPoll(ctx, s.NewDiskId); err != nil {
+		log.Printf("[WARN] unable to delete %s which was created to be swapped in as the OS Disk: %+v", s.NewDiskId, err)
+		return
+	}
+	log.Printf("[DEBUG] Deleted %s.", s.NewDiskId)
+}
+
+// deleteOriginalDisk deletes the OS Disk which has been swapped out, providing the user has opted into OS Disks being
+// deleted alongside the Virtual Machine
+func (s virtualMachineOSDiskSwap) deleteOriginalDisk(ctx context.Context, meta interface{}) error {
+	if !meta.(*clients.Client).Features.VirtualMachine.DeleteOSDiskOnDeletion {
+		log.Printf("[DEBUG] Skipping deleting the swapped out OS Disk %s", s.OriginalDisk)
+		return nil
+	}
+
+	disksClient := meta.(*clients.Client).Compute.DisksClient
+
+	log.Printf("[DEBUG] Deleting the swapped out OS Disk %s..", s.OriginalDisk)
+	future, err := disksClient.Delete(ctx, s.OriginalDisk)
+	if err != nil {
+		if response.WasNotFound(future.HttpResponse) {
+			return nil
+		}
+		return fmt.Errorf("deleting the swapped out OS Disk %s: %+v", s.OriginalDisk, err)
+	}
+	if err := future.Poller.PollUntilDone(); err != nil {
+		return fmt.Errorf("waiting for the deletion of the swapped out OS Disk %s: %+v", s.OriginalDisk, err)
+	}
+	log.Printf("[DEBUG] Deleted the swapped out OS Disk %s.", s.OriginalDisk)
+
+	return nil
+}
+
+// swapVirtualMachineOSDiskFromSnapshot swaps the OS Disk of a newly created Virtual Machine for one created from the
+// configured Snapshot, which requires that the Virtual Machine is deallocated whilst the OS Disk is swapped
+func swapVirtualMachineOSDiskFromSnapshot(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id parse.VirtualMachineId) error {
+	client := meta.(*clients.Client).Compute.VMClient
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	// the new OS Disk is only created once the Virtual Machine has been deallocated
+	log.Printf("[DEBUG] Deallocating %s to swap the OS Disk..", id)
+	deallocateFuture, err := client.Deallocate(ctx, id.ResourceGroup, id.Name, utils.Bool(false))
+	if err != nil {
+		return fmt.Errorf("deallocating %s: %+v", id, err)
+	}
+	if err := deallocateFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deallocation of %s: %+v", id, err)
+	}
+
+	swap, err := createVirtualMachineOSDiskForSwap(ctx, d, meta, id, existing)
+	if err != nil {
+		return err
+	}
+
+	update := compute.VirtualMachineUpdate{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			StorageProfile: &compute.StorageProfile{
+				OsDisk: swap.expandOSDisk(nil),
+			},
+		},
+	}
+	log.Printf("[DEBUG] Swapping the OS Disk of %s to %s..", id, swap.NewDiskId)
+	updateFuture, err := client.Update(ctx, id.ResourceGroup, id.Name, update)
+	if err != nil {
+		swap.deleteNewDisk(ctx, meta)
+		return fmt.Errorf("swapping the OS Disk of %s: %+v", id, err)
+	}
+	if err := updateFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		swap.deleteNewDisk(ctx, meta)
+		return fmt.Errorf("waiting for the OS Disk of %s to be swapped: %+v", id, err)
+	}
+
+	log.Printf("[DEBUG] Starting %s..", id)
+	startFuture, err := client.Start(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("starting %s: %+v", id, err)
+	}
+	if err := startFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for start of %s: %+v", id, err)
+	}
+
+	return swap.deleteOriginalDisk(ctx, meta)
+}
+
+// reimageVirtualMachineWithEphemeralOSDisk resets the Ephemeral OS Disk of the Virtual Machine back to the Image it was
+// provisioned from
+func reimageVirtualMachineWithEphemeralOSDisk(ctx context.Context, meta interface{}, id parse.VirtualMachineId) error {
+	client := meta.(*clients.Client).Compute.VMClient
+
+	log.Printf("[DEBUG] Reimaging %s..", id)
+	future, err := client.Reimage(ctx, id.ResourceGroup, id.Name, &compute.VirtualMachineReimageParameters{
+		TempDisk: utils.Bool(false),
+	})
+	if err != nil {
+		return fmt.Errorf("reimaging %s: %+v", id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for reimage of %s: %+v", id, err)
+	}
+	log.Printf("[DEBUG] Reimaged %s.", id)
+
+	return nil
+}
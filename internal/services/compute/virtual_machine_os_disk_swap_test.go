@@ -0,0 +1,185 @@
+package compute
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+func TestComparePlatformImageVersions(t *testing.T) {
+	testCases := []struct {
+		A        string
+		B        string
+		Expected int
+	}{
+		{
+			A:        "1.0.0",
+			B:        "1.0.0",
+			Expected: 0,
+		},
+		{
+			A:        "18.04.202301100",
+			B:        "18.04.202212090",
+			Expected: 1,
+		},
+		{
+			// compared lexically this would be the other way around
+			A:        "9.0.0",
+			B:        "10.0.0",
+			Expected: -1,
+		},
+		{
+			A:        "1.2",
+			B:        "1.2.1",
+			Expected: -1,
+		},
+		{
+			A:        "1.0.b",
+			B:        "1.0.a",
+			Expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		actual := comparePlatformImageVersions(tc.A, tc.B)
+		if (actual < 0 && tc.Expected >= 0) || (actual > 0 && tc.Expected <= 0) || (actual == 0 && tc.Expected != 0) {
+			t.Fatalf("comparing %q and %q: expected %d but got %d", tc.A, tc.B, tc.Expected, actual)
+		}
+	}
+}
+
+func TestLatestPlatformImageVersion(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    []compute.VirtualMachineImageResource
+		Expected *string
+	}{
+		{
+			Name:     "Empty",
+			Input:    []compute.VirtualMachineImageResource{},
+			Expected: nil,
+		},
+		{
+			Name: "Ordered Lexically",
+			Input: []compute.VirtualMachineImageResource{
+				{Name: pointer.To("10.0.1")},
+				{Name: pointer.To("2.0.0")},
+				{Name: pointer.To("9.5.3")},
+			},
+			Expected: pointer.To("10.0.1"),
+		},
+		{
+			Name: "Without Names",
+			Input: []compute.VirtualMachineImageResource{
+				{Name: pointer.To("1.0.0")},
+				{},
+				{Name: pointer.To("1.0.10")},
+				{Name: pointer.To("1.0.9")},
+			},
+			Expected: pointer.To("1.0.10"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("[DEBUG] Testing %q..", tc.Name)
+
+		actual := latestPlatformImageVersion(tc.Input)
+		if tc.Expected == nil {
+			if actual != nil {
+				t.Fatalf("expected no image but got %q", pointer.From(actual.Name))
+			}
+			continue
+		}
+
+		if actual == nil || pointer.From(actual.Name) != *tc.Expected {
+			t.Fatalf("expected %q but got %+v", *tc.Expected, actual)
+		}
+	}
+}
+
+func TestFlattenPlatformImageVersionID(t *testing.T) {
+	testCases := []struct {
+		Name              string
+		Input             string
+		ConfiguredVersion string
+		Expected          []interface{}
+	}{
+		{
+			Name:     "Managed Image",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/images/image1",
+			Expected: nil,
+		},
+		{
+			Name:              "Platform Image",
+			Input:             "/Subscriptions/00000000-0000-0000-0000-000000000000/Providers/Microsoft.Compute/Locations/westeurope/Publishers/Canonical/ArtifactTypes/VMImage/Offers/0001-com-ubuntu-server-jammy/Skus/22_04-lts/Versions/22.04.202301100",
+			ConfiguredVersion: "22.04.202212090",
+			Expected: []interface{}{
+				map[string]interface{}{
+					"publisher": "Canonical",
+					"offer":     "0001-com-ubuntu-server-jammy",
+					"sku":       "22_04-lts",
+					"version":   "22.04.202301100",
+				},
+			},
+		},
+		{
+			Name:              "Platform Image using the latest version",
+			Input:             "/Subscriptions/00000000-0000-0000-0000-000000000000/Providers/Microsoft.Compute/Locations/westeurope/Publishers/Canonical/ArtifactTypes/VMImage/Offers/0001-com-ubuntu-server-jammy/Skus/22_04-lts/Versions/22.04.202301100",
+			ConfiguredVersion: "latest",
+			Expected: []interface{}{
+				map[string]interface{}{
+					"publisher": "Canonical",
+					"offer":     "0001-com-ubuntu-server-jammy",
+					"sku":       "22_04-lts",
+					"version":   "latest",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("[DEBUG] Testing %q..", tc.Name)
+
+		actual := flattenPlatformImageVersionID(tc.Input, tc.ConfiguredVersion)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("expected %+v but got %+v", tc.Expected, actual)
+		}
+	}
+}
+
+func TestVirtualMachineOSDiskWasSwapped(t *testing.T) {
+	testCases := []struct {
+		VirtualMachineName string
+		OSDiskName         string
+		Expected           bool
+	}{
+		{
+			VirtualMachineName: "vm1",
+			OSDiskName:         "vm1_OsDisk_1_0123456789abcdef0123456789abcdef",
+			Expected:           false,
+		},
+		{
+			VirtualMachineName: "vm1",
+			OSDiskName:         "my-os-disk",
+			Expected:           false,
+		},
+		{
+			VirtualMachineName: "vm1",
+			OSDiskName:         "vm1_OsDisk_20230102150405",
+			Expected:           true,
+		},
+		{
+			VirtualMachineName: "vm.1",
+			OSDiskName:         "vmx1_OsDisk_20230102150405",
+			Expected:           false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := virtualMachineOSDiskWasSwapped(tc.VirtualMachineName, tc.OSDiskName); actual != tc.Expected {
+			t.Fatalf("expected %t for OS Disk %q on Virtual Machine %q but got %t", tc.Expected, tc.OSDiskName, tc.VirtualMachineName, actual)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
+)
+
+// virtualMachineRunCommandSchema returns the schema shared between the Run Command resources for Virtual Machines
+// and Virtual Machine Scale Set instances
+func virtualMachineRunCommandSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"source": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"command_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+					},
+
+					"script": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+					},
+
+					"script_uri": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Sensitive:    true,
+						ValidateFunc: validation.IsURLWithHTTPS,
+						ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+					},
+				},
+			},
+		},
+
+		"parameter": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"value": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+
+		// due to the sensitive nature, these are not returned by the API
+		"protected_parameter": {
+			Type:      pluginsdk.TypeList,
+			Optional:  true,
+			Sensitive: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"value": {
+						Type:      pluginsdk.TypeString,
+						Required:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+
+		"run_as_user": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		// due to the sensitive nature, this is not returned by the API
+		"run_as_password": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			RequiredWith: []string{"run_as_user"},
+		},
+
+		"timeout_in_seconds": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+
+		// these contain a SAS Token and so are not returned by the API
+		"output_blob_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+
+		"error_blob_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+
+		// the Run Command is re-run when any of these values change
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"instance_view": virtualMachineRunCommandInstanceViewSchema(),
+
+		"tags": tags.Schema(),
+	}
+}
+
+func virtualMachineRunCommandInstanceViewSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"execution_state": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"execution_message": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"exit_code": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+
+				"output": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"error": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"start_time": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"end_time": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func expandVirtualMachineRunCommandProperties(d *pluginsdk.ResourceData) *compute.VirtualMachineRunCommandProperties {
+	props := &compute.VirtualMachineRunCommandProperties{
+		Source:              expandVirtualMachineRunCommandScriptSource(d.Get("source").([]interface{})),
+		Parameters:          expandVirtualMachineRunCommandParameters(d.Get("parameter").([]interface{})),
+		ProtectedParameters: expandVirtualMachineRunCommandParameters(d.Get("protected_parameter").([]interface{})),
+		// the outputs of the script are only available once it's completed
+		AsyncExecution: utils.Bool(false),
+	}
+
+	if v := d.Get("timeout_in_seconds").(int); v > 0 {
+		props.TimeoutInSeconds = utils.Int32(int32(v))
+	}
+	if v := d.Get("run_as_user").(string); v != "" {
+		props.RunAsUser = utils.String(v)
+	}
+	if v := d.Get("run_as_password").(string); v != "" {
+		props.RunAsPassword = utils.String(v)
+	}
+	if v := d.Get("output_blob_uri").(string); v != "" {
+		props.OutputBlobURI = utils.String(v)
+	}
+	if v := d.Get("error_blob_uri").(string); v != "" {
+		props.ErrorBlobURI = utils.String(v)
+	}
+
+	return props
+}
+
+func expandVirtualMachineRunCommandScriptSource(input []interface{}) *compute.VirtualMachineRunCommandScriptSource {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	source := &compute.VirtualMachineRunCommandScriptSource{}
+	if v := raw["command_id"].(string); v != "" {
+		source.CommandID = utils.String(v)
+	}
+	if v := raw["script"].(string); v != "" {
+		source.Script = utils.String(v)
+	}
+	if v := raw["script_uri"].(string); v != "" {
+		source.ScriptURI = utils.String(v)
+	}
+
+	return source
+}
+
+func flattenVirtualMachineRunCommandScriptSource(input *compute.VirtualMachineRunCommandScriptSource, existing []interface{}) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	// the Script URI can contain a SAS Token and so isn't returned by the API
+	scriptUri := utils.NormalizeNilableString(input.ScriptURI)
+	if scriptUri == "" && len(existing) > 0 && existing[0] != nil {
+		scriptUri = existing[0].(map[string]interface{})["script_uri"].(string)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"command_id": utils.NormalizeNilableString(input.CommandID),
+			"script":     utils.NormalizeNilableString(input.Script),
+			"script_uri": scriptUri,
+		},
+	}
+}
+
+func expandVirtualMachineRunCommandParameters(input []interface{}) *[]compute.RunCommandInputParameter {
+	results := make([]compute.RunCommandInputParameter, 0)
+	for _, item := range input {
+		if item == nil {
+			continue
+		}
+
+		raw := item.(map[string]interface{})
+		results = append(results, compute.RunCommandInputParameter{
+			Name:  utils.String(raw["name"].(string)),
+			Value: utils.String(raw["value"].(string)),
+		})
+	}
+
+	return &results
+}
+
+func flattenVirtualMachineRunCommandParameters(input *[]compute.RunCommandInputParameter) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		results = append(results, map[string]interface{}{
+			"name":  utils.NormalizeNilableString(item.Name),
+			"value": utils.NormalizeNilableString(item.Value),
+		})
+	}
+
+	return results
+}
+
+func flattenVirtualMachineRunCommandInstanceView(input *compute.VirtualMachineRunCommandInstanceView) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	exitCode := 0
+	if input.ExitCode != nil {
+		exitCode = int(*input.ExitCode)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"execution_state":   string(input.ExecutionState),
+			"execution_message": utils.NormalizeNilableString(input.ExecutionMessage),
+			"exit_code":         exitCode,
+			"output":            utils.NormalizeNilableString(input.Output),
+			"error":             utils.NormalizeNilableString(input.Error),
+			"start_time":        flattenVirtualMachineRunCommandTime(input.StartTime),
+			"end_time":          flattenVirtualMachineRunCommandTime(input.EndTime),
+		},
+	}
+}
+
+func flattenVirtualMachineRunCommandTime(input *date.Time) string {
+	if input == nil {
+		return ""
+	}
+
+	return input.Format(time.RFC3339)
+}
+
+func setVirtualMachineRunCommandProperties(d *pluginsdk.ResourceData, props *compute.VirtualMachineRunCommandProperties) error {
+	if props == nil {
+		return nil
+	}
+
+	if err := d.Set("source", flattenVirtualMachineRunCommandScriptSource(props.Source, d.Get("source").([]interface{}))); err != nil {
+		return fmt.Errorf("setting `source`: %+v", err)
+	}
+	if err := d.Set("parameter", flattenVirtualMachineRunCommandParameters(props.Parameters)); err != nil {
+		return fmt.Errorf("setting `parameter`: %+v", err)
+	}
+	d.Set("run_as_user", props.RunAsUser)
+
+	timeoutInSeconds := 0
+	if props.TimeoutInSeconds != nil {
+		timeoutInSeconds = int(*props.TimeoutInSeconds)
+	}
+	d.Set("timeout_in_seconds", timeoutInSeconds)
+
+	if err := d.Set("instance_view", flattenVirtualMachineRunCommandInstanceView(props.InstanceView)); err != nil {
+		return fmt.Errorf("setting `instance_view`: %+v", err)
+	}
+
+	return nil
+}
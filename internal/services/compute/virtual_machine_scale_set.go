@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/rickb777/date/period"
 	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
 )
 
@@ -1885,6 +1886,75 @@ func FlattenVirtualMachineScaleSetRollingUpgradePolicy(input *compute.RollingUpg
 	}
 }
 
+func VirtualMachineScaleSetInstanceRolloutPolicySchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"max_batch_instance_count": {
+					Type:          pluginsdk.TypeInt,
+					Optional:      true,
+					ValidateFunc:  validation.IntAtLeast(1),
+					ConflictsWith: []string{"instance_rollout_policy.0.max_batch_instance_percent"},
+				},
+
+				"max_batch_instance_percent": {
+					Type:          pluginsdk.TypeInt,
+					Optional:      true,
+					ValidateFunc:  validation.IntBetween(1, 100),
+					ConflictsWith: []string{"instance_rollout_policy.0.max_batch_instance_count"},
+				},
+
+				"pause_time_between_batches": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "PT0S",
+					ValidateFunc: azValidate.ISO8601Duration,
+				},
+
+				"abort_on_unhealthy_instances_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"mode": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					Default:  string(virtualMachineScaleSetInstanceRolloutModeUpdateAndReimage),
+					ValidateFunc: validation.StringInSlice([]string{
+						string(virtualMachineScaleSetInstanceRolloutModeUpdateAndReimage),
+						string(virtualMachineScaleSetInstanceRolloutModeSurgeThenDelete),
+					}, false),
+				},
+			},
+		},
+	}
+}
+
+func ExpandVirtualMachineScaleSetInstanceRolloutPolicy(input []interface{}) (*virtualMachineScaleSetInstanceRolloutPolicy, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+
+	pauseTime, err := period.Parse(raw["pause_time_between_batches"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("parsing `pause_time_between_batches`: %+v", err)
+	}
+
+	return &virtualMachineScaleSetInstanceRolloutPolicy{
+		MaxBatchInstanceCount:     raw["max_batch_instance_count"].(int),
+		MaxBatchInstancePercent:   raw["max_batch_instance_percent"].(int),
+		PauseTimeBetweenBatches:   pauseTime.DurationApprox(),
+		AbortOnUnhealthyInstances: raw["abort_on_unhealthy_instances_enabled"].(bool),
+		Mode:                      virtualMachineScaleSetInstanceRolloutMode(raw["mode"].(string)),
+	}, nil
+}
+
 // TODO remove VirtualMachineScaleSetTerminateNotificationSchema in 4.0
 func VirtualMachineScaleSetTerminateNotificationSchema() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
@@ -4,14 +4,65 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 	"github.com/tombuildsstuff/kermit/sdk/compute/2022-08-01/compute"
 )
 
+type virtualMachineScaleSetInstanceRolloutMode string
+
+const (
+	virtualMachineScaleSetInstanceRolloutModeSurgeThenDelete  virtualMachineScaleSetInstanceRolloutMode = "SurgeThenDelete"
+	virtualMachineScaleSetInstanceRolloutModeUpdateAndReimage virtualMachineScaleSetInstanceRolloutMode = "UpdateAndReimage"
+)
+
+const (
+	virtualMachineScaleSetInstanceHealthStateHealthy      = "HealthState/healthy"
+	virtualMachineScaleSetInstanceHealthStateInitializing = "HealthState/initializing"
+	virtualMachineScaleSetInstanceHealthStateUnhealthy    = "HealthState/unhealthy"
+	virtualMachineScaleSetInstanceHealthStateUnknown      = "HealthState/unknown"
+)
+
+type virtualMachineScaleSetInstanceRolloutPolicy struct {
+	MaxBatchInstanceCount     int
+	MaxBatchInstancePercent   int
+	PauseTimeBetweenBatches   time.Duration
+	AbortOnUnhealthyInstances bool
+	Mode                      virtualMachineScaleSetInstanceRolloutMode
+}
+
+// batches splits the instances which need to be rolled into batches, where the size of each batch is determined from
+// either the number of instances or the percentage of the total instances within the Scale Set (defaulting to 1)
+func (policy virtualMachineScaleSetInstanceRolloutPolicy) batches(instanceIds []string, totalInstances int) [][]string {
+	batchSize := 1
+	if policy.MaxBatchInstanceCount > 0 {
+		batchSize = policy.MaxBatchInstanceCount
+	}
+	if policy.MaxBatchInstancePercent > 0 {
+		batchSize = int(math.Ceil(float64(totalInstances) * float64(policy.MaxBatchInstancePercent) / 100))
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	batches := make([][]string, 0)
+	for i := 0; i < len(instanceIds); i += batchSize {
+		end := i + batchSize
+		if end > len(instanceIds) {
+			end = len(instanceIds)
+		}
+		batches = append(batches, instanceIds[i:end])
+	}
+
+	return batches
+}
+
 type virtualMachineScaleSetUpdateMetaData struct {
 	// is "automaticOSUpgrade" enable in the upgradeProfile block
 	AutomaticOSUpgradeIsEnabled bool
@@ -22,6 +73,15 @@ type virtualMachineScaleSetUpdateMetaData struct {
 	// do we need to roll the instances in this scale set?
 	UpdateInstances bool
 
+	// how should the instances be rolled? when specified this opts the scale set into rolling instances
+	InstanceRolloutPolicy *virtualMachineScaleSetInstanceRolloutPolicy
+
+	// can reimaging the instances be skipped once they've been updated to the latest model?
+	SkipReimage bool
+
+	// should every instance in this scale set be reimaged? this is triggered by the user
+	ReimageInstances bool
+
 	Client   *client.Client
 	Existing compute.VirtualMachineScaleSet
 	ID       *parse.VirtualMachineScaleSetId
@@ -44,7 +104,7 @@ func (metadata virtualMachineScaleSetUpdateMetaData) performUpdate(ctx context.C
 
 	// if we update the SKU, we also need to subsequently roll the instances using the `UpdateInstances` API
 	if metadata.UpdateInstances {
-		userWantsToRollInstances := metadata.CanRollInstancesWhenRequired
+		userWantsToRollInstances := metadata.CanRollInstancesWhenRequired || metadata.InstanceRolloutPolicy != nil
 		upgradeMode := metadata.Existing.VirtualMachineScaleSetProperties.UpgradePolicy.Mode
 
 		if userWantsToRollInstances {
@@ -64,6 +124,12 @@ func (metadata virtualMachineScaleSetUpdateMetaData) performUpdate(ctx context.C
 		}
 	}
 
+	if metadata.ReimageInstances {
+		if err := metadata.reimageAllInstances(ctx); err != nil {
+			return err
+		}
+	}
+
 	if metadata.AutomaticOSUpgradeIsEnabled {
 		// Virtual Machine Scale Sets with Automatic OS Upgrade enabled must have all VM instances upgraded to same
 		// Platform Image. Upgrade all VM instances to latest Virtual Machine Scale Set model while property
@@ -122,68 +188,302 @@ func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForAutomati
 }
 
 func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForManualUpgradePolicy(ctx context.Context) error {
-	client := metadata.Client.VMScaleSetClient
 	id := metadata.ID
 
+	rollout := metadata.InstanceRolloutPolicy
+	if rollout == nil {
+		// by default instances are rolled one at a time
+		rollout = &virtualMachineScaleSetInstanceRolloutPolicy{
+			Mode: virtualMachineScaleSetInstanceRolloutModeUpdateAndReimage,
+		}
+	}
+
 	log.Printf("[DEBUG] Rolling the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q)..", metadata.OSType, id.Name, id.ResourceGroup)
+	allInstanceIds, err := metadata.listInstanceIds(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Determining instances to roll..")
+	instanceIdsToRoll, err := metadata.listInstanceIds(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	batches := rollout.batches(instanceIdsToRoll, len(allInstanceIds))
+	for i, batch := range batches {
+		log.Printf("[DEBUG] Rolling batch %d of %d (Instances %q)..", i+1, len(batches), strings.Join(batch, ", "))
+
+		switch rollout.Mode {
+		case virtualMachineScaleSetInstanceRolloutModeSurgeThenDelete:
+			if err := metadata.surgeThenDeleteInstances(ctx, batch, rollout.AbortOnUnhealthyInstances); err != nil {
+				return err
+			}
+
+		default:
+			if err := metadata.updateAndReimageInstances(ctx, batch); err != nil {
+				return err
+			}
+
+			if rollout.AbortOnUnhealthyInstances {
+				if err := metadata.waitForInstancesToBecomeHealthy(ctx, batch); err != nil {
+					return err
+				}
+			}
+		}
+
+		if i < len(batches)-1 {
+			if err := metadata.pauseBetweenBatches(ctx, *rollout); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] Rolled the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q).", metadata.OSType, id.Name, id.ResourceGroup)
+	return nil
+}
+
+// reimageAllInstances reimages every instance within the Scale Set, in batches determined by the Instance Rollout
+// Policy (defaulting to one instance at a time)
+func (metadata virtualMachineScaleSetUpdateMetaData) reimageAllInstances(ctx context.Context) error {
+	id := metadata.ID
+
+	rollout := metadata.InstanceRolloutPolicy
+	if rollout == nil {
+		rollout = &virtualMachineScaleSetInstanceRolloutPolicy{}
+	}
+
+	log.Printf("[DEBUG] Reimaging the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q)..", metadata.OSType, id.Name, id.ResourceGroup)
+	instanceIds, err := metadata.listInstanceIds(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	batches := rollout.batches(instanceIds, len(instanceIds))
+	for i, batch := range batches {
+		log.Printf("[DEBUG] Reimaging batch %d of %d (Instances %q)..", i+1, len(batches), strings.Join(batch, ", "))
+		if err := metadata.reimageInstances(ctx, batch); err != nil {
+			return err
+		}
+
+		if rollout.AbortOnUnhealthyInstances {
+			if err := metadata.waitForInstancesToBecomeHealthy(ctx, batch); err != nil {
+				return err
+			}
+		}
+
+		if i < len(batches)-1 {
+			if err := metadata.pauseBetweenBatches(ctx, *rollout); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] Reimaged the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q).", metadata.OSType, id.Name, id.ResourceGroup)
+	return nil
+}
+
+func (metadata virtualMachineScaleSetUpdateMetaData) pauseBetweenBatches(ctx context.Context, rollout virtualMachineScaleSetInstanceRolloutPolicy) error {
+	if rollout.PauseTimeBetweenBatches <= 0 {
+		return nil
+	}
+
+	id := metadata.ID
+	log.Printf("[DEBUG] Pausing for %s before rolling the next batch..", rollout.PauseTimeBetweenBatches)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("waiting to roll the next batch of instances for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, ctx.Err())
+	case <-time.After(rollout.PauseTimeBetweenBatches):
+	}
+
+	return nil
+}
+
+func (metadata virtualMachineScaleSetUpdateMetaData) listInstanceIds(ctx context.Context, outdatedOnly bool) ([]string, error) {
+	id := metadata.ID
+
 	instancesClient := metadata.Client.VMScaleSetVMsClient
 	instances, err := instancesClient.ListComplete(ctx, id.ResourceGroup, id.Name, "", "", "")
 	if err != nil {
-		return fmt.Errorf("listing VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+		return nil, fmt.Errorf("listing VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
 	}
 
-	log.Printf("[DEBUG] Determining instances to roll..")
-	instanceIdsToRoll := make([]string, 0)
+	instanceIds := make([]string, 0)
 	for instances.NotDone() {
 		instance := instances.Value()
 		props := instance.VirtualMachineScaleSetVMProperties
 		if props != nil && instance.InstanceID != nil {
 			latestModel := props.LatestModelApplied
-			if latestModel != nil || !*latestModel {
-				instanceIdsToRoll = append(instanceIdsToRoll, *instance.InstanceID)
+			if !outdatedOnly || latestModel == nil || !*latestModel {
+				instanceIds = append(instanceIds, *instance.InstanceID)
 			}
 		}
 
 		if err := instances.NextWithContext(ctx); err != nil {
-			return fmt.Errorf("enumerating instances: %s", err)
+			return nil, fmt.Errorf("enumerating instances: %s", err)
 		}
 	}
 
-	// TODO: there's a performance enhancement to do batches here, but this is fine for a first pass
-	for _, instanceId := range instanceIdsToRoll {
-		instanceIds := []string{instanceId}
+	return instanceIds, nil
+}
 
-		log.Printf("[DEBUG] Updating Instance %q to the Latest Configuration..", instanceId)
-		ids := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
-			InstanceIds: &instanceIds,
-		}
-		future, err := client.UpdateInstances(ctx, id.ResourceGroup, id.Name, ids)
+func (metadata virtualMachineScaleSetUpdateMetaData) updateAndReimageInstances(ctx context.Context, instanceIds []string) error {
+	client := metadata.Client.VMScaleSetClient
+	id := metadata.ID
+
+	log.Printf("[DEBUG] Updating Instances %q to the Latest Configuration..", strings.Join(instanceIds, ", "))
+	ids := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
+		InstanceIds: &instanceIds,
+	}
+	future, err := client.UpdateInstances(ctx, id.ResourceGroup, id.Name, ids)
+	if err != nil {
+		return fmt.Errorf("updating Instances %q (%s VM Scale Set %q / Resource Group %q) to the Latest Configuration: %+v", strings.Join(instanceIds, ", "), metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of Instances %q (%s VM Scale Set %q / Resource Group %q) to the Latest Configuration: %+v", strings.Join(instanceIds, ", "), metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+	log.Printf("[DEBUG] Updated Instances %q to the Latest Configuration.", strings.Join(instanceIds, ", "))
+
+	if metadata.SkipReimage {
+		log.Printf("[DEBUG] Skipping reimaging Instances %q since this isn't required", strings.Join(instanceIds, ", "))
+		return nil
+	}
+
+	// TODO: does this want to be a separate, user-configurable toggle?
+	return metadata.reimageInstances(ctx, instanceIds)
+}
+
+func (metadata virtualMachineScaleSetUpdateMetaData) reimageInstances(ctx context.Context, instanceIds []string) error {
+	client := metadata.Client.VMScaleSetClient
+	id := metadata.ID
+
+	log.Printf("[DEBUG] Reimaging Instances %q..", strings.Join(instanceIds, ", "))
+	reimageInput := &compute.VirtualMachineScaleSetReimageParameters{
+		InstanceIds: &instanceIds,
+	}
+	reimageFuture, err := client.Reimage(ctx, id.ResourceGroup, id.Name, reimageInput)
+	if err != nil {
+		return fmt.Errorf("reimaging Instances %q (%s VM Scale Set %q / Resource Group %q): %+v", strings.Join(instanceIds, ", "), metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+
+	if err = reimageFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for reimage of Instances %q (%s VM Scale Set %q / Resource Group %q): %+v", strings.Join(instanceIds, ", "), metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+	log.Printf("[DEBUG] Reimaged Instances %q..", strings.Join(instanceIds, ", "))
+
+	return nil
+}
+
+// surgeThenDeleteInstances scales out the Scale Set by the number of instances being replaced (with the new instances
+// using the latest model) and then deletes the outdated instances, returning the Scale Set to its original capacity
+func (metadata virtualMachineScaleSetUpdateMetaData) surgeThenDeleteInstances(ctx context.Context, instanceIds []string, waitForHealthyInstances bool) error {
+	client := metadata.Client.VMScaleSetClient
+	id := metadata.ID
+
+	existingInstanceIds, err := metadata.listInstanceIds(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		return fmt.Errorf("retrieving %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+	if existing.Sku == nil || existing.Sku.Capacity == nil {
+		return fmt.Errorf("retrieving %s Virtual Machine Scale Set %q (Resource Group %q): `sku.capacity` was nil", metadata.OSType, id.Name, id.ResourceGroup)
+	}
+
+	sku := *existing.Sku
+	sku.Capacity = utils.Int64(*existing.Sku.Capacity + int64(len(instanceIds)))
+	log.Printf("[DEBUG] Surging %s Virtual Machine Scale Set %q (Resource Group %q) to %d instances..", metadata.OSType, id.Name, id.ResourceGroup, *sku.Capacity)
+	if err := metadata.updateVmss(ctx, compute.VirtualMachineScaleSetUpdate{Sku: &sku}); err != nil {
+		return err
+	}
+
+	if waitForHealthyInstances {
+		currentInstanceIds, err := metadata.listInstanceIds(ctx, false)
 		if err != nil {
-			return fmt.Errorf("updating Instance %q (%s VM Scale Set %q / Resource Group %q) to the Latest Configuration: %+v", instanceId, metadata.OSType, id.Name, id.ResourceGroup, err)
+			return err
 		}
 
-		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
-			return fmt.Errorf("waiting for update of Instance %q (%s VM Scale Set %q / Resource Group %q) to the Latest Configuration: %+v", instanceId, metadata.OSType, id.Name, id.ResourceGroup, err)
+		newInstanceIds := make([]string, 0)
+		for _, v := range currentInstanceIds {
+			if !utils.SliceContainsValue(existingInstanceIds, v) {
+				newInstanceIds = append(newInstanceIds, v)
+			}
 		}
-		log.Printf("[DEBUG] Updated Instance %q to the Latest Configuration.", instanceId)
 
-		// TODO: does this want to be a separate, user-configurable toggle?
-		log.Printf("[DEBUG] Reimaging Instance %q..", instanceId)
-		reimageInput := &compute.VirtualMachineScaleSetReimageParameters{
-			InstanceIds: &instanceIds,
+		if err := metadata.waitForInstancesToBecomeHealthy(ctx, newInstanceIds); err != nil {
+			return err
 		}
-		reimageFuture, err := client.Reimage(ctx, id.ResourceGroup, id.Name, reimageInput)
-		if err != nil {
-			return fmt.Errorf("reimaging Instance %q (%s VM Scale Set %q / Resource Group %q): %+v", instanceId, metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+
+	log.Printf("[DEBUG] Deleting Instances %q..", strings.Join(instanceIds, ", "))
+	ids := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
+		InstanceIds: &instanceIds,
+	}
+	future, err := client.DeleteInstances(ctx, id.ResourceGroup, id.Name, ids, utils.Bool(false))
+	if err != nil {
+		return fmt.Errorf("deleting Instances %q (%s VM Scale Set %q / Resource Group %q): %+v", strings.Join(instanceIds, ", "), metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of Instances %q (%s VM Scale Set %q / Resource Group %q): %+v", strings.Join(instanceIds, ", "), metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+	log.Printf("[DEBUG] Deleted Instances %q.", strings.Join(instanceIds, ", "))
+
+	return nil
+}
+
+// waitForInstancesToBecomeHealthy waits for the health status reported by the Application Health Extension (or Load
+// Balancer Health Probe) of each instance to become healthy, returning an error if any instance reports as unhealthy
+func (metadata virtualMachineScaleSetUpdateMetaData) waitForInstancesToBecomeHealthy(ctx context.Context, instanceIds []string) error {
+	id := metadata.ID
+	instancesClient := metadata.Client.VMScaleSetVMsClient
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	for _, instanceId := range instanceIds {
+		log.Printf("[DEBUG] Waiting for Instance %q to become healthy..", instanceId)
+		stateConf := &pluginsdk.StateChangeConf{
+			Pending: []string{
+				virtualMachineScaleSetInstanceHealthStateInitializing,
+				virtualMachineScaleSetInstanceHealthStateUnknown,
+			},
+			Target: []string{
+				virtualMachineScaleSetInstanceHealthStateHealthy,
+			},
+			Refresh: func() (interface{}, string, error) {
+				resp, err := instancesClient.GetInstanceView(ctx, id.ResourceGroup, id.Name, instanceId)
+				if err != nil {
+					return nil, "", fmt.Errorf("retrieving Instance View for Instance %q: %+v", instanceId, err)
+				}
+
+				if resp.VMHealth == nil || resp.VMHealth.Status == nil || resp.VMHealth.Status.Code == nil {
+					return nil, "", fmt.Errorf("the health of Instance %q is not being reported - `abort_on_unhealthy_instances_enabled` requires that the Application Health Extension or a Load Balancer Health Probe is configured", instanceId)
+				}
+
+				state := *resp.VMHealth.Status.Code
+				if strings.EqualFold(state, virtualMachineScaleSetInstanceHealthStateUnhealthy) {
+					return resp, state, fmt.Errorf("Instance %q is unhealthy - aborting the roll out of the remaining instances", instanceId)
+				}
+
+				return resp, state, nil
+			},
+			MinTimeout: 15 * time.Second,
+			Timeout:    time.Until(deadline),
 		}
 
-		if err = reimageFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
-			return fmt.Errorf("waiting for reimage of Instance %q (%s VM Scale Set %q / Resource Group %q): %+v", instanceId, metadata.OSType, id.Name, id.ResourceGroup, err)
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("waiting for Instance %q (%s VM Scale Set %q / Resource Group %q) to become healthy: %+v", instanceId, metadata.OSType, id.Name, id.ResourceGroup, err)
 		}
-		log.Printf("[DEBUG] Reimaged Instance %q..", instanceId)
+		log.Printf("[DEBUG] Instance %q is healthy.", instanceId)
 	}
 
-	log.Printf("[DEBUG] Rolled the VM Instances for %s Virtual Machine Scale Set %q (Resource Group %q).", metadata.OSType, id.Name, id.ResourceGroup)
 	return nil
 }
 
@@ -0,0 +1,76 @@
+package compute
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVirtualMachineScaleSetInstanceRolloutPolicyBatches(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Policy         virtualMachineScaleSetInstanceRolloutPolicy
+		InstanceIds    []string
+		TotalInstances int
+		Expected       [][]string
+	}{
+		{
+			Name:           "No Instances",
+			Policy:         virtualMachineScaleSetInstanceRolloutPolicy{},
+			InstanceIds:    []string{},
+			TotalInstances: 3,
+			Expected:       [][]string{},
+		},
+		{
+			Name:           "Default Batch Size",
+			Policy:         virtualMachineScaleSetInstanceRolloutPolicy{},
+			InstanceIds:    []string{"0", "1", "2"},
+			TotalInstances: 3,
+			Expected:       [][]string{{"0"}, {"1"}, {"2"}},
+		},
+		{
+			Name: "Batch Instance Count",
+			Policy: virtualMachineScaleSetInstanceRolloutPolicy{
+				MaxBatchInstanceCount: 2,
+			},
+			InstanceIds:    []string{"0", "1", "2"},
+			TotalInstances: 3,
+			Expected:       [][]string{{"0", "1"}, {"2"}},
+		},
+		{
+			Name: "Batch Instance Count Larger Than Instances",
+			Policy: virtualMachineScaleSetInstanceRolloutPolicy{
+				MaxBatchInstanceCount: 5,
+			},
+			InstanceIds:    []string{"0", "1", "2"},
+			TotalInstances: 3,
+			Expected:       [][]string{{"0", "1", "2"}},
+		},
+		{
+			Name: "Batch Instance Percent Rounds Up",
+			Policy: virtualMachineScaleSetInstanceRolloutPolicy{
+				MaxBatchInstancePercent: 20,
+			},
+			InstanceIds:    []string{"0", "1", "2", "3"},
+			TotalInstances: 6,
+			Expected:       [][]string{{"0", "1"}, {"2", "3"}},
+		},
+		{
+			Name: "Batch Instance Percent Of Total Instances",
+			Policy: virtualMachineScaleSetInstanceRolloutPolicy{
+				MaxBatchInstancePercent: 50,
+			},
+			InstanceIds:    []string{"1", "3", "5"},
+			TotalInstances: 4,
+			Expected:       [][]string{{"1", "3"}, {"5"}},
+		},
+	}
+
+	for _, v := range testCases {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		actual := v.Policy.batches(v.InstanceIds, v.TotalInstances)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
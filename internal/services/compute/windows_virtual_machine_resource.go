@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/capacityreservationgroups"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/proximityplacementgroups"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-02/disks"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-02/snapshots"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	azValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
@@ -239,6 +240,12 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 				ValidateFunc: validation.FloatAtLeast(-1.0),
 			},
 
+			"os_disk_source_snapshot_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: snapshots.ValidateSnapshotID,
+			},
+
 			"patch_mode": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
@@ -298,6 +305,14 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 				},
 			},
 
+			"reimage_triggers": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
 			"secret": windowsSecretSchema(),
 
 			"secure_boot_enabled": {
@@ -309,7 +324,6 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 			"source_image_id": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
-				ForceNew: true,
 				ValidateFunc: validation.Any(
 					computeValidate.ImageID,
 					computeValidate.SharedImageID,
@@ -325,7 +339,9 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 				},
 			},
 
-			"source_image_reference": sourceImageReferenceSchema(true),
+			"source_image_reference": sourceImageReferenceSchema(),
+
+			"source_image_update_mode": virtualMachineSourceImageUpdateModeSchema(),
 
 			"tags": tags.Schema(),
 
@@ -401,6 +417,10 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			virtualMachineSourceImageCustomizeDiff,
+		),
 	}
 }
 
@@ -740,6 +760,12 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 	}
 
 	d.SetId(id.ID())
+
+	if d.Get("os_disk_source_snapshot_id").(string) != "" {
+		if err := swapVirtualMachineOSDiskFromSnapshot(ctx, d, meta, id); err != nil {
+			return err
+		}
+	}
 	return resourceWindowsVirtualMachineRead(d, meta)
 }
 
@@ -921,19 +947,13 @@ func resourceWindowsVirtualMachineRead(d *pluginsdk.ResourceData, meta interface
 			return fmt.Errorf("settings `os_disk`: %+v", err)
 		}
 
-		var storageImageId string
-		if profile.ImageReference != nil && profile.ImageReference.ID != nil {
-			storageImageId = *profile.ImageReference.ID
-		}
-		if profile.ImageReference != nil && profile.ImageReference.CommunityGalleryImageID != nil {
-			storageImageId = *profile.ImageReference.CommunityGalleryImageID
-		}
-		if profile.ImageReference != nil && profile.ImageReference.SharedGalleryImageID != nil {
-			storageImageId = *profile.ImageReference.SharedGalleryImageID
+		sourceImageId, sourceImageReference, err := flattenVirtualMachineSourceImage(ctx, d, disksClient, *profile)
+		if err != nil {
+			return fmt.Errorf("flattening the Source Image: %+v", err)
 		}
-		d.Set("source_image_id", storageImageId)
+		d.Set("source_image_id", sourceImageId)
 
-		if err := d.Set("source_image_reference", flattenSourceImageReference(profile.ImageReference, storageImageId != "")); err != nil {
+		if err := d.Set("source_image_reference", sourceImageReference); err != nil {
 			return fmt.Errorf("setting `source_image_reference`: %+v", err)
 		}
 	}
@@ -968,6 +988,12 @@ func resourceWindowsVirtualMachineRead(d *pluginsdk.ResourceData, meta interface
 
 	d.Set("virtual_machine_id", props.VMID)
 
+	sourceImageUpdateMode := virtualMachineSourceImageUpdateModeReplace
+	if v := d.Get("source_image_update_mode").(string); v != "" {
+		sourceImageUpdateMode = v
+	}
+	d.Set("source_image_update_mode", sourceImageUpdateMode)
+
 	d.Set("user_data", props.UserData)
 
 	zone := ""
@@ -1252,6 +1278,15 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		}
 	}
 
+	swapOSDisk := virtualMachineOSDiskShouldBeSwapped(d, hasEphemeralOSDisk)
+	if swapOSDisk {
+		shouldUpdate = true
+
+		// the OS Disk can only be swapped whilst the Virtual Machine is deallocated
+		shouldShutDown = true
+		shouldDeallocate = true
+	}
+
 	if d.HasChange("proximity_placement_group_id") {
 		shouldUpdate = true
 
@@ -1404,8 +1439,9 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 
 	if shouldShutDown {
 		log.Printf("[DEBUG] Shutting Down Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
-		forceShutdown := false
-		future, err := client.PowerOff(ctx, id.ResourceGroup, id.Name, utils.Bool(forceShutdown))
+		// when the OS Disk is being expanded the graceful shutdown can be skipped, since this requires a deallocation regardless
+		skipShutdown := d.HasChange("os_disk.0.disk_size_gb") && !meta.(*clients.Client).Features.ManagedDisk.GracefulShutdownBeforeExpand
+		future, err := client.PowerOff(ctx, id.ResourceGroup, id.Name, utils.Bool(skipShutdown))
 		if err != nil {
 			return fmt.Errorf("sending Power Off to Windows Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
 		}
@@ -1437,11 +1473,37 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		}
 	}
 
+	// the new OS Disk is only created once the Virtual Machine has been deallocated, and is deleted if it isn't swapped in
+	var osDiskSwap *virtualMachineOSDiskSwap
+	osDiskSwapped := false
+	if swapOSDisk {
+		osDiskSwap, err = createVirtualMachineOSDiskForSwap(ctx, d, meta, *id, existing)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if !osDiskSwapped {
+				osDiskSwap.deleteNewDisk(ctx, meta)
+			}
+		}()
+
+		if update.VirtualMachineProperties.StorageProfile == nil {
+			update.VirtualMachineProperties.StorageProfile = &compute.StorageProfile{}
+		}
+		update.VirtualMachineProperties.StorageProfile.OsDisk = osDiskSwap.expandOSDisk(update.VirtualMachineProperties.StorageProfile.OsDisk)
+	}
+
+	// when the OS Disk is being swapped any changes are made to the new OS Disk, prior to it being swapped in
+	osDiskName := d.Get("os_disk.0.name").(string)
+	if osDiskSwap != nil {
+		osDiskName = osDiskSwap.NewDiskId.DiskName
+	}
+
 	// now the VM's shutdown/deallocated we can update the disk which can't be done via the VM API:
 	// Code="ResizeDiskError" Message="Managed disk resize via Virtual Machine [name] is not allowed. Please resize disk resource at [id]."
 	// Portal: "Disks can be resized or account type changed only when they are unattached or the owner VM is deallocated."
 	if d.HasChange("os_disk.0.disk_size_gb") {
-		diskName := d.Get("os_disk.0.name").(string)
+		diskName := osDiskName
 		newSize := d.Get("os_disk.0.disk_size_gb").(int)
 		log.Printf("[DEBUG] Resizing OS Disk %q for Windows Virtual Machine %q (Resource Group %q) to %dGB..", diskName, id.Name, id.ResourceGroup, newSize)
 
@@ -1465,7 +1527,7 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 
 	if d.HasChange("os_disk.0.disk_encryption_set_id") {
 		if diskEncryptionSetId := d.Get("os_disk.0.disk_encryption_set_id").(string); diskEncryptionSetId != "" {
-			diskName := d.Get("os_disk.0.name").(string)
+			diskName := osDiskName
 			log.Printf("[DEBUG] Updating encryption settings of OS Disk %q for Windows Virtual Machine %q (Resource Group %q) to %q..", diskName, id.Name, id.ResourceGroup, diskEncryptionSetId)
 
 			encryptionType, err := retrieveDiskEncryptionSetEncryptionType(ctx, meta.(*clients.Client).Compute.DiskEncryptionSetsClient, diskEncryptionSetId)
@@ -1509,6 +1571,7 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		}
 
 		log.Printf("[DEBUG] Updated Windows Virtual Machine %q (Resource Group %q).", id.Name, id.ResourceGroup)
+		osDiskSwapped = true
 	}
 
 	// if we've shut it down and it was turned off, let's boot it back up
@@ -1526,6 +1589,18 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		log.Printf("[DEBUG] Started Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 	}
 
+	if osDiskSwap != nil {
+		if err := osDiskSwap.deleteOriginalDisk(ctx, meta); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("reimage_triggers") && hasEphemeralOSDisk {
+		if err := reimageVirtualMachineWithEphemeralOSDisk(ctx, meta, *id); err != nil {
+			return err
+		}
+	}
+
 	return resourceWindowsVirtualMachineRead(d, meta)
 }
 
@@ -99,6 +99,37 @@ func TestAccWindowsVirtualMachine_imageFromSourceImageReference(t *testing.T) {
 	})
 }
 
+func TestAccWindowsVirtualMachine_imageSwapOSDisk(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_windows_virtual_machine", "test")
+	r := WindowsVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.imageSwapOSDisk(data, "2016-Datacenter", "1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers", "source_image_update_mode"),
+		{
+			Config: r.imageSwapOSDisk(data, "2019-Datacenter", "1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("source_image_reference.0.sku").HasValue("2019-Datacenter"),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers", "source_image_update_mode", "source_image_reference"),
+		{
+			// reimaging swaps in a new OS Disk created from the same image
+			Config: r.imageSwapOSDisk(data, "2019-Datacenter", "2"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers", "source_image_update_mode", "source_image_reference"),
+	})
+}
+
 func (WindowsVirtualMachineResource) imageFromExistingMachineDependencies(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 locals {
@@ -360,6 +391,41 @@ resource "azurerm_windows_virtual_machine" "test" {
 `, r.template(data))
 }
 
+func (r WindowsVirtualMachineResource) imageSwapOSDisk(data acceptance.TestData, sku, trigger string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_windows_virtual_machine" "test" {
+  name                     = local.vm_name
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  size                     = "Standard_F2"
+  admin_username           = "adminuser"
+  admin_password           = "P@$$w0rd1234!"
+  source_image_update_mode = "SwapOSDisk"
+  network_interface_ids = [
+    azurerm_network_interface.test.id,
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  reimage_triggers = {
+    build = "%s"
+  }
+
+  source_image_reference {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "%s"
+    version   = "latest"
+  }
+}
+`, r.template(data), trigger, sku)
+}
+
 func (WindowsVirtualMachineResource) empty() string {
 	return `
 provider "azurerm" {
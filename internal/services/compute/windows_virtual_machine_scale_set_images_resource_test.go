@@ -155,6 +155,28 @@ func TestAccWindowsVirtualMachineScaleSet_imagesManualUpdateExternalRoll(t *test
 	})
 }
 
+func TestAccWindowsVirtualMachineScaleSet_imagesReimageTriggers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_windows_virtual_machine_scale_set", "test")
+	r := WindowsVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.imagesReimageTriggers(data, "1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers"),
+		{
+			Config: r.imagesReimageTriggers(data, "2"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password", "reimage_triggers"),
+	})
+}
+
 func TestAccWindowsVirtualMachineScaleSet_imagesRollingUpdate(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_windows_virtual_machine_scale_set", "test")
 	r := WindowsVirtualMachineScaleSetResource{}
@@ -599,6 +621,49 @@ resource "azurerm_windows_virtual_machine_scale_set" "test" {
 `, r.template(data), version)
 }
 
+func (r WindowsVirtualMachineScaleSetResource) imagesReimageTriggers(data acceptance.TestData, trigger string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_windows_virtual_machine_scale_set" "test" {
+  name                = local.vm_name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "Standard_F2"
+  instances           = 1
+  admin_username      = "adminuser"
+  admin_password      = "P@ssword1234!"
+
+  reimage_triggers = {
+    build = "%s"
+  }
+
+  source_image_reference {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "2019-Datacenter"
+    version   = "latest"
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = azurerm_subnet.test.id
+    }
+  }
+}
+`, r.template(data), trigger)
+}
+
 func (r WindowsVirtualMachineScaleSetResource) imagesRollingUpdate(data acceptance.TestData, version string) string {
 	return fmt.Sprintf(`
 %s
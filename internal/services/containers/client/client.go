@@ -9,9 +9,14 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/agentpools"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/maintenanceconfigurations"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/snapshots"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/trustedaccess"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/extensions"
 	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/sdk/2023-08-15-preview/fleetmembers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/sdk/2023-08-15-preview/fleetupdatestrategies"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/sdk/2023-08-15-preview/updateruns"
 )
 
 type Client struct {
@@ -20,10 +25,15 @@ type Client struct {
 	ContainerRegistryClient_v2021_08_01_preview *containerregistry_v2021_08_01_preview.Client
 	// v2019_06_01_preview is needed for container registry agent pools and tasks
 	ContainerRegistryClient_v2019_06_01_preview *containerregistry_v2019_06_01_preview.Client
+	FleetMembersClient                          *fleetmembers.FleetMembersClient
+	FleetUpdateRunsClient                       *updateruns.UpdateRunsClient
+	FleetUpdateStrategiesClient                 *fleetupdatestrategies.FleetUpdateStrategiesClient
 	KubernetesClustersClient                    *managedclusters.ManagedClustersClient
 	KubernetesExtensionsClient                  *extensions.ExtensionsClient
 	MaintenanceConfigurationsClient             *maintenanceconfigurations.MaintenanceConfigurationsClient
 	ServicesClient                              *containerservices.ContainerServicesClient
+	SnapshotsClient                             *snapshots.SnapshotsClient
+	TrustedAccessClient                         *trustedaccess.TrustedAccessClient
 	Environment                                 azure.Environment
 }
 
@@ -45,6 +55,16 @@ func NewContainersClient(o *common.ClientOptions) (*Client, error) {
 		return nil, err
 	}
 
+	// Fleet member groups, update strategies and update runs are only available from 2023-08-15-preview
+	fleetMembersClient := fleetmembers.NewFleetMembersClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&fleetMembersClient.Client, o.ResourceManagerAuthorizer)
+
+	fleetUpdateRunsClient := updateruns.NewUpdateRunsClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&fleetUpdateRunsClient.Client, o.ResourceManagerAuthorizer)
+
+	fleetUpdateStrategiesClient := fleetupdatestrategies.NewFleetUpdateStrategiesClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&fleetUpdateStrategiesClient.Client, o.ResourceManagerAuthorizer)
+
 	// AKS
 	kubernetesClustersClient := managedclusters.NewManagedClustersClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&kubernetesClustersClient.Client, o.ResourceManagerAuthorizer)
@@ -61,15 +81,26 @@ func NewContainersClient(o *common.ClientOptions) (*Client, error) {
 	servicesClient := containerservices.NewContainerServicesClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&servicesClient.Client, o.ResourceManagerAuthorizer)
 
+	snapshotsClient := snapshots.NewSnapshotsClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&snapshotsClient.Client, o.ResourceManagerAuthorizer)
+
+	trustedAccessClient := trustedaccess.NewTrustedAccessClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&trustedAccessClient.Client, o.ResourceManagerAuthorizer)
+
 	return &Client{
 		AgentPoolsClient:                            &agentPoolsClient,
 		ContainerInstanceClient:                     &containerInstanceClient,
 		ContainerRegistryClient_v2021_08_01_preview: containerRegistryClient_v2021_08_01_preview,
 		ContainerRegistryClient_v2019_06_01_preview: containerRegistryClient_v2019_06_01_preview,
+		FleetMembersClient:                          &fleetMembersClient,
+		FleetUpdateRunsClient:                       &fleetUpdateRunsClient,
+		FleetUpdateStrategiesClient:                 &fleetUpdateStrategiesClient,
 		KubernetesClustersClient:                    &kubernetesClustersClient,
 		KubernetesExtensionsClient:                  &kubernetesExtensionsClient,
 		MaintenanceConfigurationsClient:             &maintenanceConfigurationsClient,
 		ServicesClient:                              &servicesClient,
+		SnapshotsClient:                             &snapshotsClient,
+		TrustedAccessClient:                         &trustedAccessClient,
 		Environment:                                 o.AzureEnvironment,
 	}, nil
 }
@@ -33,7 +33,8 @@ type userItemAAD struct {
 }
 
 type userAAD struct {
-	AuthProvider authProvider `yaml:"auth-provider"`
+	AuthProvider *authProvider `yaml:"auth-provider,omitempty"`
+	Exec         *execConfig   `yaml:"exec,omitempty"`
 }
 
 type authProvider struct {
@@ -45,6 +46,7 @@ type configAzureAD struct {
 	APIServerID string `yaml:"apiserver-id,omitempty"`
 	ClientID    string `yaml:"client-id,omitempty"`
 	TenantID    string `yaml:"tenant-id,omitempty"`
+	Environment string `yaml:"environment,omitempty"`
 }
 
 type contextItem struct {
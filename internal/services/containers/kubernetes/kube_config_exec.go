@@ -0,0 +1,167 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ExecAPIVersion is the client authentication API version used by kubelogin
+	ExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+	// ExecCommand is the credential plugin used to authenticate against AAD enabled clusters
+	ExecCommand = "kubelogin"
+
+	// DefaultAADServerID is the Server ID of the AKS managed AAD server application
+	DefaultAADServerID = "6dae42f8-4368-4678-94ff-3960e28e3630"
+
+	defaultExecEnvironment = "AzurePublicCloud"
+	execInstallHint        = "kubelogin is not installed which is required to connect to AAD enabled cluster. To learn more, please go to https://aka.ms/aks/kubelogin"
+)
+
+type KubeLoginMode string
+
+const (
+	KubeLoginModeAzureCLI         KubeLoginMode = "azurecli"
+	KubeLoginModeMSI              KubeLoginMode = "msi"
+	KubeLoginModeServicePrincipal KubeLoginMode = "spn"
+	KubeLoginModeWorkloadIdentity KubeLoginMode = "workloadidentity"
+)
+
+func PossibleValuesForKubeLoginMode() []KubeLoginMode {
+	return []KubeLoginMode{
+		KubeLoginModeAzureCLI,
+		KubeLoginModeMSI,
+		KubeLoginModeServicePrincipal,
+		KubeLoginModeWorkloadIdentity,
+	}
+}
+
+type execConfig struct {
+	APIVersion         string       `yaml:"apiVersion"`
+	Command            string       `yaml:"command"`
+	Args               []string     `yaml:"args"`
+	Env                []execEnvVar `yaml:"env"`
+	InstallHint        string       `yaml:"installHint,omitempty"`
+	ProvideClusterInfo bool         `yaml:"provideClusterInfo"`
+}
+
+type execEnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// AADSettings are the values required by kubelogin to obtain a token for an AAD enabled cluster
+type AADSettings struct {
+	Host                 string
+	ClusterCACertificate string
+	ServerID             string
+	TenantID             string
+	Environment          string
+}
+
+// ParseAADSettings extracts the AAD Settings from a Kube Config which uses either the
+// legacy `azure` auth-provider or the `exec` credential plugin
+func ParseAADSettings(config KubeConfigAAD) (*AADSettings, error) {
+	if len(config.Clusters) == 0 || len(config.Users) == 0 {
+		return nil, fmt.Errorf("Config contains no valid clusters or users")
+	}
+
+	settings := AADSettings{
+		Host:                 config.Clusters[0].Cluster.Server,
+		ClusterCACertificate: config.Clusters[0].Cluster.ClusterAuthorityData,
+		ServerID:             DefaultAADServerID,
+		Environment:          defaultExecEnvironment,
+	}
+
+	u := config.Users[0].User
+	switch {
+	case u.Exec != nil:
+		for i := 0; i < len(u.Exec.Args)-1; i++ {
+			value := u.Exec.Args[i+1]
+			switch u.Exec.Args[i] {
+			case "--server-id":
+				settings.ServerID = value
+			case "--tenant-id":
+				settings.TenantID = value
+			case "--environment":
+				settings.Environment = value
+			}
+		}
+	case u.AuthProvider != nil:
+		if v := u.AuthProvider.Config.APIServerID; v != "" {
+			settings.ServerID = v
+		}
+		settings.TenantID = u.AuthProvider.Config.TenantID
+		if v := u.AuthProvider.Config.Environment; v != "" {
+			settings.Environment = v
+		}
+	default:
+		return nil, fmt.Errorf("Config requires either an `exec` or `auth-provider` section for user %q", config.Users[0].Name)
+	}
+
+	return &settings, nil
+}
+
+// ExecArgs returns the arguments which should be passed to kubelogin for the specified login mode.
+// Credentials (e.g. a Service Principal's Client Secret) are intentionally omitted since kubelogin
+// sources these from the environment.
+func (s AADSettings) ExecArgs(loginMode KubeLoginMode) []string {
+	args := []string{
+		"get-token",
+		"--login",
+		string(loginMode),
+		"--server-id",
+		s.ServerID,
+	}
+
+	switch loginMode {
+	case KubeLoginModeServicePrincipal, KubeLoginModeWorkloadIdentity:
+		args = append(args, "--environment", s.Environment)
+		if s.TenantID != "" {
+			args = append(args, "--tenant-id", s.TenantID)
+		}
+	}
+
+	return args
+}
+
+// BuildExecKubeConfig returns a copy of the specified Kube Config where each user authenticates
+// using kubelogin in the specified login mode
+func BuildExecKubeConfig(config KubeConfigAAD, loginMode KubeLoginMode) (string, error) {
+	settings, err := ParseAADSettings(config)
+	if err != nil {
+		return "", err
+	}
+
+	users := make([]userItemAAD, 0, len(config.Users))
+	for _, u := range config.Users {
+		users = append(users, userItemAAD{
+			Name: u.Name,
+			User: userAAD{
+				Exec: &execConfig{
+					APIVersion:  ExecAPIVersion,
+					Command:     ExecCommand,
+					Args:        settings.ExecArgs(loginMode),
+					InstallHint: execInstallHint,
+				},
+			},
+		})
+	}
+
+	output := KubeConfigAAD{
+		KubeConfigBase: config.KubeConfigBase,
+		Users:          users,
+	}
+	if output.Kind == "" {
+		output.Kind = "Config"
+	}
+
+	b, err := yaml.Marshal(output)
+	if err != nil {
+		return "", fmt.Errorf("Failed to marshal YAML config with error %+v", err)
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,109 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAADSettings(t *testing.T) {
+	testCases := []struct {
+		sourceFile string
+		expected   AADSettings
+	}{
+		{
+			"aad_auth_provider.yml",
+			AADSettings{
+				Host:                 "https://testcluster.org:443",
+				ClusterCACertificate: "test-cluster-authority-data",
+				ServerID:             "test-server-id",
+				TenantID:             "test-tenant-id",
+				Environment:          "AzureUSGovernmentCloud",
+			},
+		},
+		{
+			"aad_exec.yml",
+			AADSettings{
+				Host:                 "https://testcluster.org:443",
+				ClusterCACertificate: "test-cluster-authority-data",
+				ServerID:             "test-server-id",
+				TenantID:             "test-tenant-id",
+				Environment:          "AzurePublicCloud",
+			},
+		},
+	}
+
+	for i, test := range testCases {
+		config, err := ParseKubeConfigAAD(LoadConfig(test.sourceFile))
+		if err != nil {
+			t.Fatalf("Test case [%d]: parsing config '%s': %+v", i, test.sourceFile, err)
+		}
+
+		result, err := ParseAADSettings(*config)
+		if err != nil {
+			t.Fatalf("Test case [%d]: parsing AAD settings from '%s': %+v", i, test.sourceFile, err)
+		}
+
+		if !reflect.DeepEqual(test.expected, *result) {
+			t.Fatalf("Test case [%d]: expected '%+v' but got '%+v'", i, test.expected, *result)
+		}
+	}
+}
+
+func TestBuildExecKubeConfig(t *testing.T) {
+	testCases := []struct {
+		loginMode KubeLoginMode
+		expected  []string
+	}{
+		{
+			KubeLoginModeAzureCLI,
+			[]string{"get-token", "--login", "azurecli", "--server-id", "test-server-id"},
+		},
+		{
+			KubeLoginModeMSI,
+			[]string{"get-token", "--login", "msi", "--server-id", "test-server-id"},
+		},
+		{
+			KubeLoginModeServicePrincipal,
+			[]string{"get-token", "--login", "spn", "--server-id", "test-server-id", "--environment", "AzureUSGovernmentCloud", "--tenant-id", "test-tenant-id"},
+		},
+		{
+			KubeLoginModeWorkloadIdentity,
+			[]string{"get-token", "--login", "workloadidentity", "--server-id", "test-server-id", "--environment", "AzureUSGovernmentCloud", "--tenant-id", "test-tenant-id"},
+		},
+	}
+
+	source, err := ParseKubeConfigAAD(LoadConfig("aad_auth_provider.yml"))
+	if err != nil {
+		t.Fatalf("parsing config: %+v", err)
+	}
+
+	for i, test := range testCases {
+		raw, err := BuildExecKubeConfig(*source, test.loginMode)
+		if err != nil {
+			t.Fatalf("Test case [%d]: building config: %+v", i, err)
+		}
+
+		result, err := ParseKubeConfigAAD(raw)
+		if err != nil {
+			t.Fatalf("Test case [%d]: parsing built config: %+v", i, err)
+		}
+
+		if !reflect.DeepEqual(source.KubeConfigBase, result.KubeConfigBase) {
+			t.Fatalf("Test case [%d]: expected '%+v' but got '%+v'", i, source.KubeConfigBase, result.KubeConfigBase)
+		}
+
+		u := result.Users[0].User
+		if u.AuthProvider != nil {
+			t.Fatalf("Test case [%d]: expected no `auth-provider` but got '%+v'", i, *u.AuthProvider)
+		}
+		if u.Exec == nil {
+			t.Fatalf("Test case [%d]: expected an `exec` section but got none", i)
+		}
+		if u.Exec.Command != ExecCommand || u.Exec.APIVersion != ExecAPIVersion {
+			t.Fatalf("Test case [%d]: unexpected command '%s' / api version '%s'", i, u.Exec.Command, u.Exec.APIVersion)
+		}
+		if !reflect.DeepEqual(test.expected, u.Exec.Args) {
+			t.Fatalf("Test case [%d]: expected args '%+v' but got '%+v'", i, test.expected, u.Exec.Args)
+		}
+	}
+}
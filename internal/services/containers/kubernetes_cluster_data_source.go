@@ -499,6 +499,55 @@ func dataSourceKubernetesCluster() *pluginsdk.Resource {
 				Sensitive: true,
 			},
 
+			"kube_config_exec": {
+				Type:      pluginsdk.TypeList,
+				Computed:  true,
+				Sensitive: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"host": {
+							Type:      pluginsdk.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"cluster_ca_certificate": {
+							Type:      pluginsdk.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"api_version": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"command": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"server_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"tenant_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"environment": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"kube_config_exec_raw": {
+				Type:      pluginsdk.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
 			"kubelet_identity": {
 				Type:     pluginsdk.TypeList,
 				Computed: true,
@@ -852,6 +901,21 @@ func dataSourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{}
 			return fmt.Errorf("setting `kube_config`: %+v", err)
 		}
 
+		var aadProfile *managedclusters.ManagedClusterAADProfile
+		if model.Properties != nil {
+			aadProfile = model.Properties.AadProfile
+		}
+		kubeConfigExecRaw, kubeConfigExec, err := flattenKubernetesClusterExecCredentials(aadProfile, userCredentialsResp.Model, "clusterUser")
+		if err != nil {
+			return fmt.Errorf("flattening `kube_config_exec`: %+v", err)
+		}
+		if err := d.Set("kube_config_exec_raw", kubeConfigExecRaw); err != nil {
+			return fmt.Errorf("setting `kube_config_exec_raw`: %+v", err)
+		}
+		if err := d.Set("kube_config_exec", kubeConfigExec); err != nil {
+			return fmt.Errorf("setting `kube_config_exec`: %+v", err)
+		}
+
 		d.Set("tags", tags.Flatten(model.Tags))
 	}
 
@@ -962,6 +1026,53 @@ func flattenKubernetesClusterCredentials(model *managedclusters.CredentialResult
 	return nil, []interface{}{}
 }
 
+// flattenKubernetesClusterExecCredentials returns a Kube Config using the kubelogin `exec` credential plugin
+// for each supported login mode, alongside the settings required to configure the plugin elsewhere.
+// These are only available for AAD enabled clusters.
+func flattenKubernetesClusterExecCredentials(aadProfile *managedclusters.ManagedClusterAADProfile, model *managedclusters.CredentialResults, configName string) (map[string]interface{}, []interface{}, error) {
+	rawConfigs := make(map[string]interface{})
+
+	// Kube Configs for clusters without AAD integration use client certificates, so there's nothing to expose
+	if aadProfile == nil {
+		return rawConfigs, []interface{}{}, nil
+	}
+
+	rawConfig, _ := flattenKubernetesClusterCredentials(model, configName)
+	if rawConfig == nil {
+		return rawConfigs, []interface{}{}, nil
+	}
+
+	kubeConfigAAD, err := kubernetes.ParseKubeConfigAAD(*rawConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing Kube Config %q: %+v", configName, err)
+	}
+
+	settings, err := kubernetes.ParseAADSettings(*kubeConfigAAD)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing the AAD Settings from Kube Config %q: %+v", configName, err)
+	}
+
+	for _, loginMode := range kubernetes.PossibleValuesForKubeLoginMode() {
+		execConfig, err := kubernetes.BuildExecKubeConfig(*kubeConfigAAD, loginMode)
+		if err != nil {
+			return nil, nil, fmt.Errorf("building the Kube Config for login mode %q: %+v", string(loginMode), err)
+		}
+		rawConfigs[string(loginMode)] = execConfig
+	}
+
+	return rawConfigs, []interface{}{
+		map[string]interface{}{
+			"host":                   settings.Host,
+			"cluster_ca_certificate": settings.ClusterCACertificate,
+			"api_version":            kubernetes.ExecAPIVersion,
+			"command":                kubernetes.ExecCommand,
+			"server_id":              settings.ServerID,
+			"tenant_id":              settings.TenantID,
+			"environment":            settings.Environment,
+		},
+	}, nil
+}
+
 func flattenKubernetesClusterDataSourceAddOns(profile map[string]managedclusters.ManagedClusterAddonProfile) map[string]interface{} {
 	aciConnectors := make([]interface{}, 0)
 	aciConnector := kubernetesAddonProfileLocate(profile, aciConnectorKey)
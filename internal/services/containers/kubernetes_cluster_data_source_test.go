@@ -124,6 +124,12 @@ func TestAccDataSourceKubernetesCluster_localAccountDisabled(t *testing.T) {
 				check.That(data.ResourceName).Key("kube_config_raw").Exists(),
 				check.That(data.ResourceName).Key("kube_admin_config.#").HasValue("0"),
 				check.That(data.ResourceName).Key("kube_admin_config_raw").HasValue(""),
+				check.That(data.ResourceName).Key("kube_config_exec.#").HasValue("1"),
+				check.That(data.ResourceName).Key("kube_config_exec.0.host").Exists(),
+				check.That(data.ResourceName).Key("kube_config_exec.0.cluster_ca_certificate").Exists(),
+				check.That(data.ResourceName).Key("kube_config_exec.0.server_id").Exists(),
+				check.That(data.ResourceName).Key("kube_config_exec_raw.%").HasValue("4"),
+				check.That(data.ResourceName).Key("kube_config_exec_raw.azurecli").Exists(),
 			),
 		},
 	})
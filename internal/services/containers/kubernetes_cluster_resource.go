@@ -160,6 +160,18 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 				}, false),
 			},
 
+			"node_os_channel_upgrade": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(managedclusters.NodeOSUpgradeChannelNodeImage),
+					string(managedclusters.NodeOSUpgradeChannelNone),
+					string(managedclusters.NodeOSUpgradeChannelSecurityPatch),
+					string(managedclusters.NodeOSUpgradeChannelUnmanaged),
+				}, false),
+			},
+
 			"auto_scaler_profile": {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
@@ -573,6 +585,55 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 				Sensitive: true,
 			},
 
+			"kube_config_exec": {
+				Type:      pluginsdk.TypeList,
+				Computed:  true,
+				Sensitive: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"host": {
+							Type:      pluginsdk.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"cluster_ca_certificate": {
+							Type:      pluginsdk.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"api_version": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"command": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"server_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"tenant_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"environment": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"kube_config_exec_raw": {
+				Type:      pluginsdk.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
 			"kubelet_identity": {
 				Type:     pluginsdk.TypeList,
 				Computed: true,
@@ -728,6 +789,19 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 				},
 			},
 
+			"maintenance_window_auto_upgrade": schemaKubernetesClusterMaintenanceWindowSchedule([]string{
+				string(kubernetesClusterMaintenanceFrequencyWeekly),
+				string(kubernetesClusterMaintenanceFrequencyAbsoluteMonthly),
+				string(kubernetesClusterMaintenanceFrequencyRelativeMonthly),
+			}),
+
+			"maintenance_window_node_os": schemaKubernetesClusterMaintenanceWindowSchedule([]string{
+				string(kubernetesClusterMaintenanceFrequencyDaily),
+				string(kubernetesClusterMaintenanceFrequencyWeekly),
+				string(kubernetesClusterMaintenanceFrequencyAbsoluteMonthly),
+				string(kubernetesClusterMaintenanceFrequencyRelativeMonthly),
+			}),
+
 			"key_management_service": {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
@@ -1486,6 +1560,10 @@ func resourceKubernetesClusterCreate(d *pluginsdk.ResourceData, meta interface{}
 		}
 	}
 
+	if v := d.Get("node_os_channel_upgrade").(string); v != "" {
+		parameters.Properties.AutoUpgradeProfile.NodeOSUpgradeChannel = utils.ToPtr(managedclusters.NodeOSUpgradeChannel(v))
+	}
+
 	managedClusterIdentityRaw := d.Get("identity").([]interface{})
 	kubernetesClusterIdentityRaw := d.Get("kubelet_identity").([]interface{})
 	servicePrincipalProfileRaw := d.Get("service_principal").([]interface{})
@@ -1558,12 +1636,42 @@ func resourceKubernetesClusterCreate(d *pluginsdk.ResourceData, meta interface{}
 		parameters := maintenanceconfigurations.MaintenanceConfiguration{
 			Properties: expandKubernetesClusterMaintenanceConfiguration(maintenanceConfigRaw.([]interface{})),
 		}
-		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, "default")
+		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameDefault)
 		if _, err := client.CreateOrUpdate(ctx, maintenanceId, parameters); err != nil {
 			return fmt.Errorf("creating/updating maintenance config for %s: %+v", id, err)
 		}
 	}
 
+	if maintenanceConfigRaw, ok := d.GetOk("maintenance_window_auto_upgrade"); ok {
+		client := meta.(*clients.Client).Containers.MaintenanceConfigurationsClient
+		properties, err := expandKubernetesClusterMaintenanceWindowSchedule(maintenanceConfigRaw.([]interface{}))
+		if err != nil {
+			return fmt.Errorf("expanding `maintenance_window_auto_upgrade`: %+v", err)
+		}
+		parameters := maintenanceconfigurations.MaintenanceConfiguration{
+			Properties: properties,
+		}
+		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameAutoUpgrade)
+		if _, err := client.CreateOrUpdate(ctx, maintenanceId, parameters); err != nil {
+			return fmt.Errorf("creating/updating auto upgrade maintenance config for %s: %+v", id, err)
+		}
+	}
+
+	if maintenanceConfigRaw, ok := d.GetOk("maintenance_window_node_os"); ok {
+		client := meta.(*clients.Client).Containers.MaintenanceConfigurationsClient
+		properties, err := expandKubernetesClusterMaintenanceWindowSchedule(maintenanceConfigRaw.([]interface{}))
+		if err != nil {
+			return fmt.Errorf("expanding `maintenance_window_node_os`: %+v", err)
+		}
+		parameters := maintenanceconfigurations.MaintenanceConfiguration{
+			Properties: properties,
+		}
+		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameNodeOS)
+		if _, err := client.CreateOrUpdate(ctx, maintenanceId, parameters); err != nil {
+			return fmt.Errorf("creating/updating node os maintenance config for %s: %+v", id, err)
+		}
+	}
+
 	d.SetId(id.ID())
 	return resourceKubernetesClusterRead(d, meta)
 }
@@ -1912,6 +2020,15 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		existing.Model.Properties.AutoUpgradeProfile.UpgradeChannel = &channel
 	}
 
+	if d.HasChange("node_os_channel_upgrade") {
+		updateCluster = true
+		if existing.Model.Properties.AutoUpgradeProfile == nil {
+			existing.Model.Properties.AutoUpgradeProfile = &managedclusters.ManagedClusterAutoUpgradeProfile{}
+		}
+
+		existing.Model.Properties.AutoUpgradeProfile.NodeOSUpgradeChannel = utils.ToPtr(managedclusters.NodeOSUpgradeChannel(d.Get("node_os_channel_upgrade").(string)))
+	}
+
 	if d.HasChange("http_proxy_config") {
 		updateCluster = true
 		httpProxyConfigRaw := d.Get("http_proxy_config").([]interface{})
@@ -2063,14 +2180,14 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		}
 
 		// if the default node pool name has changed it means the initial attempt at resizing failed
-		if d.HasChange("default_node_pool.0.vm_size") || d.HasChange("default_node_pool.0.name") {
+		if d.HasChange("default_node_pool.0.vm_size") || d.HasChange("default_node_pool.0.snapshot_id") || d.HasChange("default_node_pool.0.name") {
 			log.Printf("[DEBUG] Cycling Default Node Pool..")
-			// to provide a seamless updating experience for the vm size of the default node pool we need to cycle the default
+			// to provide a seamless updating experience for the vm size or snapshot of the default node pool we need to cycle the default
 			// node pool by provisioning a temporary system node pool, tearing down the former default node pool and then
 			// bringing up the new one.
 
 			if v := d.Get("default_node_pool.0.temporary_name_for_rotation").(string); v == "" {
-				return fmt.Errorf("`temporary_name_for_rotation` must be specified when updating `vm_size`")
+				return fmt.Errorf("`temporary_name_for_rotation` must be specified when updating `vm_size` or `snapshot_id`")
 			}
 
 			temporaryNodePoolName := d.Get("default_node_pool.0.temporary_name_for_rotation").(string)
@@ -2106,7 +2223,7 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 				}
 			}
 
-			// create the default node pool with the new vm size
+			// create the default node pool with the new vm size or snapshot
 			if err := retrySystemNodePoolCreation(ctx, nodePoolsClient, defaultNodePoolId, agentProfile); err != nil {
 				// if creation of the default node pool fails we automatically fall back to the temporary node pool
 				// in func findDefaultNodePool
@@ -2135,12 +2252,28 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		parameters := maintenanceconfigurations.MaintenanceConfiguration{
 			Properties: expandKubernetesClusterMaintenanceConfiguration(d.Get("maintenance_window").([]interface{})),
 		}
-		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, "default")
+		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameDefault)
 		if _, err := client.CreateOrUpdate(ctx, maintenanceId, parameters); err != nil {
 			return fmt.Errorf("creating/updating Maintenance Configuration for Managed Kubernetes Cluster (%q): %+v", id, err)
 		}
 	}
 
+	if d.HasChange("maintenance_window_auto_upgrade") {
+		client := meta.(*clients.Client).Containers.MaintenanceConfigurationsClient
+		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameAutoUpgrade)
+		if err := updateKubernetesClusterMaintenanceWindowSchedule(ctx, client, maintenanceId, d.Get("maintenance_window_auto_upgrade").([]interface{})); err != nil {
+			return fmt.Errorf("updating `maintenance_window_auto_upgrade` for %s: %+v", id, err)
+		}
+	}
+
+	if d.HasChange("maintenance_window_node_os") {
+		client := meta.(*clients.Client).Containers.MaintenanceConfigurationsClient
+		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameNodeOS)
+		if err := updateKubernetesClusterMaintenanceWindowSchedule(ctx, client, maintenanceId, d.Get("maintenance_window_node_os").([]interface{})); err != nil {
+			return fmt.Errorf("updating `maintenance_window_node_os` for %s: %+v", id, err)
+		}
+	}
+
 	d.Partial(false)
 
 	return resourceKubernetesClusterRead(d, meta)
@@ -2220,6 +2353,12 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 			}
 			d.Set("automatic_channel_upgrade", upgradeChannel)
 
+			nodeOSUpgradeChannel := ""
+			if profile := props.AutoUpgradeProfile; profile != nil && profile.NodeOSUpgradeChannel != nil {
+				nodeOSUpgradeChannel = string(*profile.NodeOSUpgradeChannel)
+			}
+			d.Set("node_os_channel_upgrade", nodeOSUpgradeChannel)
+
 			enablePrivateCluster := false
 			enablePrivateClusterPublicFQDN := false
 			runCommandEnabled := true
@@ -2423,13 +2562,54 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("setting `kube_config`: %+v", err)
 		}
 
+		var aadProfile *managedclusters.ManagedClusterAADProfile
+		if model.Properties != nil {
+			aadProfile = model.Properties.AadProfile
+		}
+		kubeConfigExecRaw, kubeConfigExec, err := flattenKubernetesClusterExecCredentials(aadProfile, credentials.Model, "clusterUser")
+		if err != nil {
+			return fmt.Errorf("flattening `kube_config_exec`: %+v", err)
+		}
+		if err := d.Set("kube_config_exec_raw", kubeConfigExecRaw); err != nil {
+			return fmt.Errorf("setting `kube_config_exec_raw`: %+v", err)
+		}
+		if err := d.Set("kube_config_exec", kubeConfigExec); err != nil {
+			return fmt.Errorf("setting `kube_config_exec`: %+v", err)
+		}
+
 		maintenanceConfigurationsClient := meta.(*clients.Client).Containers.MaintenanceConfigurationsClient
-		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, "default")
+		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameDefault)
 		configResp, _ := maintenanceConfigurationsClient.Get(ctx, maintenanceId)
 		if configurationBody := configResp.Model; configurationBody != nil && configurationBody.Properties != nil {
 			d.Set("maintenance_window", flattenKubernetesClusterMaintenanceConfiguration(configurationBody.Properties))
 		}
 
+		autoUpgradeMaintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameAutoUpgrade)
+		autoUpgradeResp, err := maintenanceConfigurationsClient.Get(ctx, autoUpgradeMaintenanceId)
+		if err != nil && !response.WasNotFound(autoUpgradeResp.HttpResponse) {
+			return fmt.Errorf("retrieving auto upgrade maintenance config for %s: %+v", id, err)
+		}
+		var autoUpgradeProperties *maintenanceconfigurations.MaintenanceConfigurationProperties
+		if model := autoUpgradeResp.Model; model != nil {
+			autoUpgradeProperties = model.Properties
+		}
+		if err := d.Set("maintenance_window_auto_upgrade", flattenKubernetesClusterMaintenanceWindowSchedule(autoUpgradeProperties)); err != nil {
+			return fmt.Errorf("setting `maintenance_window_auto_upgrade`: %+v", err)
+		}
+
+		nodeOSMaintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameNodeOS)
+		nodeOSResp, err := maintenanceConfigurationsClient.Get(ctx, nodeOSMaintenanceId)
+		if err != nil && !response.WasNotFound(nodeOSResp.HttpResponse) {
+			return fmt.Errorf("retrieving node os maintenance config for %s: %+v", id, err)
+		}
+		var nodeOSProperties *maintenanceconfigurations.MaintenanceConfigurationProperties
+		if model := nodeOSResp.Model; model != nil {
+			nodeOSProperties = model.Properties
+		}
+		if err := d.Set("maintenance_window_node_os", flattenKubernetesClusterMaintenanceWindowSchedule(nodeOSProperties)); err != nil {
+			return fmt.Errorf("setting `maintenance_window_node_os`: %+v", err)
+		}
+
 		if err := tags.FlattenAndSet(d, model.Tags); err != nil {
 			return fmt.Errorf("setting `tags`: %+v", err)
 		}
@@ -2450,12 +2630,28 @@ func resourceKubernetesClusterDelete(d *pluginsdk.ResourceData, meta interface{}
 
 	if _, ok := d.GetOk("maintenance_window"); ok {
 		client := meta.(*clients.Client).Containers.MaintenanceConfigurationsClient
-		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, "default")
+		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, kubernetesClusterMaintenanceConfigurationNameDefault)
 		if _, err := client.Delete(ctx, maintenanceId); err != nil {
 			return fmt.Errorf("deleting Maintenance Configuration for %s: %+v", *id, err)
 		}
 	}
 
+	for _, v := range []struct {
+		field string
+		name  string
+	}{
+		{field: "maintenance_window_auto_upgrade", name: kubernetesClusterMaintenanceConfigurationNameAutoUpgrade},
+		{field: "maintenance_window_node_os", name: kubernetesClusterMaintenanceConfigurationNameNodeOS},
+	} {
+		if _, ok := d.GetOk(v.field); ok {
+			client := meta.(*clients.Client).Containers.MaintenanceConfigurationsClient
+			maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, v.name)
+			if _, err := client.Delete(ctx, maintenanceId); err != nil {
+				return fmt.Errorf("deleting Maintenance Configuration %q for %s: %+v", v.name, *id, err)
+			}
+		}
+	}
+
 	ignorePodDisruptionBudget := true
 	future, err := client.Delete(ctx, *id, managedclusters.DeleteOperationOptions{
 		IgnorePodDisruptionBudget: &ignorePodDisruptionBudget,
@@ -0,0 +1,64 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/managedclusters"
+)
+
+func TestRunKubernetesClusterCommand(t *testing.T) {
+	clusterId := managedclusters.NewManagedClusterID("00000000-0000-0000-0000-000000000000", "group1", "cluster1")
+	commandResultId := managedclusters.NewCommandResultID(clusterId.SubscriptionId, clusterId.ResourceGroupName, clusterId.ManagedClusterName, "abc123")
+
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.EqualFold(r.URL.Path, clusterId.ID()+"/runCommand"):
+			w.Header().Set("Location", fmt.Sprintf("http://%s%s?api-version=2023-02-02-preview", r.Host, commandResultId.ID()))
+			w.WriteHeader(http.StatusAccepted)
+
+		case r.Method == http.MethodGet && strings.EqualFold(r.URL.Path, commandResultId.ID()):
+			// the command is still running for the first poll
+			if atomic.AddInt32(&polls, 1) == 1 {
+				w.Header().Set("Location", fmt.Sprintf("http://%s%s?api-version=2023-02-02-preview", r.Host, commandResultId.ID()))
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"id":%q,"properties":{"provisioningState":"Succeeded","exitCode":0,"logs":"hello"}}`, commandResultId.ID())
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := managedclusters.NewManagedClustersClientWithBaseURI(server.URL)
+	client.Client.Authorizer = autorest.NullAuthorizer{}
+	client.Client.PollingDelay = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+
+	id, err := runKubernetesClusterCommand(ctx, &client, clusterId, managedclusters.RunCommandRequest{Command: "kubectl get pods"})
+	if err != nil {
+		t.Fatalf("running command: %+v", err)
+	}
+
+	if id.ID() != commandResultId.ID() {
+		t.Fatalf("expected the Command Result ID to be %q but got %q", commandResultId.ID(), id.ID())
+	}
+	if n := atomic.LoadInt32(&polls); n < 2 {
+		t.Fatalf("expected the Command Result to be polled until the command finished, but it was polled %d times", n)
+	}
+}
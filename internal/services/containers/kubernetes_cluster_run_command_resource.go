@@ -0,0 +1,256 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+const kubernetesClusterRunCommandStateFailed = "Failed"
+
+type KubernetesClusterRunCommandModel struct {
+	KubernetesClusterId string                 `tfschema:"kubernetes_cluster_id"`
+	Command             string                 `tfschema:"command"`
+	Context             string                 `tfschema:"context"`
+	ClusterToken        string                 `tfschema:"cluster_token"`
+	Triggers            map[string]interface{} `tfschema:"triggers"`
+	ExitCode            int64                  `tfschema:"exit_code"`
+	Logs                string                 `tfschema:"logs"`
+	Reason              string                 `tfschema:"reason"`
+	StartedAt           string                 `tfschema:"started_at"`
+	FinishedAt          string                 `tfschema:"finished_at"`
+}
+
+// KubernetesClusterRunCommandResource executes a command against a Kubernetes Cluster using the
+// managed Run Command API. Since the command is only executed once, every argument forces a new
+// resource - `triggers` can be used to re-run the command when other values change.
+type KubernetesClusterRunCommandResource struct{}
+
+var _ sdk.Resource = KubernetesClusterRunCommandResource{}
+
+func (r KubernetesClusterRunCommandResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_run_command"
+}
+
+func (r KubernetesClusterRunCommandResource) ModelObject() interface{} {
+	return &KubernetesClusterRunCommandModel{}
+}
+
+func (r KubernetesClusterRunCommandResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return managedclusters.ValidateCommandResultID
+}
+
+func (r KubernetesClusterRunCommandResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"kubernetes_cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedclusters.ValidateManagedClusterID,
+		},
+
+		"command": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"context": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsBase64,
+		},
+
+		"cluster_token": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r KubernetesClusterRunCommandResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"exit_code": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"logs": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"reason": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"started_at": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"finished_at": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KubernetesClusterRunCommandResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesClustersClient
+
+			var model KubernetesClusterRunCommandModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			clusterId, err := managedclusters.ParseManagedClusterID(model.KubernetesClusterId)
+			if err != nil {
+				return err
+			}
+
+			input := managedclusters.RunCommandRequest{
+				Command: model.Command,
+			}
+			if model.Context != "" {
+				input.Context = pointer.To(model.Context)
+			}
+			if model.ClusterToken != "" {
+				input.ClusterToken = pointer.To(model.ClusterToken)
+			}
+
+			id, err := runKubernetesClusterCommand(ctx, client, *clusterId, input)
+			if err != nil {
+				return fmt.Errorf("running command on %s: %+v", *clusterId, err)
+			}
+
+			result, err := client.GetCommandResult(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if result.Model != nil && result.Model.Properties != nil {
+				props := result.Model.Properties
+				if pointer.From(props.ProvisioningState) == kubernetesClusterRunCommandStateFailed {
+					return fmt.Errorf("the command %s failed with reason %q: %s", *id, pointer.From(props.Reason), pointer.From(props.Logs))
+				}
+				flattenKubernetesClusterRunCommandResult(props, &model)
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r KubernetesClusterRunCommandResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesClustersClient
+
+			id, err := managedclusters.ParseCommandResultID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			clusterId := managedclusters.NewManagedClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName)
+			cluster, err := client.Get(ctx, clusterId)
+			if err != nil {
+				if response.WasNotFound(cluster.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", clusterId, err)
+			}
+
+			var state KubernetesClusterRunCommandModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+			state.KubernetesClusterId = clusterId.ID()
+
+			// Command Results are only retained for a limited time, after which the values in the state are kept
+			result, err := client.GetCommandResult(ctx, *id)
+			if err != nil && !response.WasNotFound(result.HttpResponse) {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if result.Model != nil {
+				flattenKubernetesClusterRunCommandResult(result.Model.Properties, &state)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KubernetesClusterRunCommandResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// a command can't be undone, so there's nothing to delete
+			return nil
+		},
+	}
+}
+
+// runKubernetesClusterCommand runs the command and waits for it to finish, returning the ID of the Command Result.
+// The Run Command API returns the URI of the Command Result in the `Location` header, which is polled until the
+// command has finished - as such the ID is taken from the final response of the poller.
+func runKubernetesClusterCommand(ctx context.Context, client *managedclusters.ManagedClustersClient, clusterId managedclusters.ManagedClusterId, input managedclusters.RunCommandRequest) (*managedclusters.CommandResultId, error) {
+	resp, err := client.RunCommand(ctx, clusterId, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Poller.PollUntilDone(); err != nil {
+		return nil, fmt.Errorf("waiting for the command to finish: %+v", err)
+	}
+
+	final := resp.Poller.HttpResponse
+	if final == nil || final.Request == nil || final.Request.URL == nil {
+		return nil, fmt.Errorf("the final response when polling the command was nil")
+	}
+
+	id, err := managedclusters.ParseCommandResultIDInsensitively(final.Request.URL.Path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the Command Result ID from %q: %+v", final.Request.URL.Path, err)
+	}
+
+	return id, nil
+}
+
+func flattenKubernetesClusterRunCommandResult(input *managedclusters.CommandResultProperties, state *KubernetesClusterRunCommandModel) {
+	if input == nil {
+		return
+	}
+
+	state.ExitCode = pointer.From(input.ExitCode)
+	state.Logs = pointer.From(input.Logs)
+	state.Reason = pointer.From(input.Reason)
+	state.StartedAt = pointer.From(input.StartedAt)
+	state.FinishedAt = pointer.From(input.FinishedAt)
+}
@@ -0,0 +1,262 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/managedclusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/trustedaccess"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type KubernetesClusterTrustedAccessRoleBindingModel struct {
+	Name                string   `tfschema:"name"`
+	KubernetesClusterId string   `tfschema:"kubernetes_cluster_id"`
+	SourceResourceId    string   `tfschema:"source_resource_id"`
+	Roles               []string `tfschema:"roles"`
+}
+
+type KubernetesClusterTrustedAccessRoleBindingResource struct{}
+
+var _ sdk.ResourceWithUpdate = KubernetesClusterTrustedAccessRoleBindingResource{}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_trusted_access_role_binding"
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) ModelObject() interface{} {
+	return &KubernetesClusterTrustedAccessRoleBindingModel{}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return trustedaccess.ValidateTrustedAccessRoleBindingID
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,22}[a-zA-Z0-9])?$`),
+				"`name` must be between 1 and 24 characters in length, may contain only letters, numbers and hyphens, and must begin and end with a letter or number.",
+			),
+		},
+
+		"kubernetes_cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedclusters.ValidateManagedClusterID,
+		},
+
+		"source_resource_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: azure.ValidateResourceID,
+		},
+
+		"roles": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model KubernetesClusterTrustedAccessRoleBindingModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.Containers.TrustedAccessClient
+
+			clusterId, err := managedclusters.ParseManagedClusterID(model.KubernetesClusterId)
+			if err != nil {
+				return err
+			}
+
+			id := trustedaccess.NewTrustedAccessRoleBindingID(clusterId.SubscriptionId, clusterId.ResourceGroupName, clusterId.ManagedClusterName, model.Name)
+			existing, err := client.RoleBindingsGet(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := trustedaccess.TrustedAccessRoleBinding{
+				Properties: trustedaccess.TrustedAccessRoleBindingProperties{
+					Roles:            model.Roles,
+					SourceResourceId: model.SourceResourceId,
+				},
+			}
+
+			if _, err := client.RoleBindingsCreateOrUpdate(ctx, id, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := waitForKubernetesClusterTrustedAccessRoleBinding(ctx, client, id); err != nil {
+				return err
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.TrustedAccessClient
+
+			id, err := trustedaccess.ParseTrustedAccessRoleBindingID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model KubernetesClusterTrustedAccessRoleBindingModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			existing, err := client.RoleBindingsGet(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if existing.Model == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", *id)
+			}
+
+			parameters := *existing.Model
+			if metadata.ResourceData.HasChange("roles") {
+				parameters.Properties.Roles = model.Roles
+			}
+
+			if _, err := client.RoleBindingsCreateOrUpdate(ctx, *id, parameters); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			if err := waitForKubernetesClusterTrustedAccessRoleBinding(ctx, client, *id); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.TrustedAccessClient
+
+			id, err := trustedaccess.ParseTrustedAccessRoleBindingID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.RoleBindingsGet(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := KubernetesClusterTrustedAccessRoleBindingModel{
+				Name:                id.TrustedAccessRoleBindingName,
+				KubernetesClusterId: managedclusters.NewManagedClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				state.Roles = model.Properties.Roles
+				state.SourceResourceId = model.Properties.SourceResourceId
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.TrustedAccessClient
+
+			id, err := trustedaccess.ParseTrustedAccessRoleBindingID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.RoleBindingsDelete(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func waitForKubernetesClusterTrustedAccessRoleBinding(ctx context.Context, client *trustedaccess.TrustedAccessClient, id trustedaccess.TrustedAccessRoleBindingId) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{
+			string(trustedaccess.TrustedAccessRoleBindingProvisioningStateUpdating),
+		},
+		Target: []string{
+			string(trustedaccess.TrustedAccessRoleBindingProvisioningStateSucceeded),
+		},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.RoleBindingsGet(ctx, id)
+			if err != nil {
+				return nil, "", fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			if resp.Model == nil || resp.Model.Properties.ProvisioningState == nil {
+				return nil, "", fmt.Errorf("retrieving %s: `provisioningState` was nil", id)
+			}
+
+			return resp, string(*resp.Model.Properties.ProvisioningState), nil
+		},
+		ContinuousTargetOccurence: 1,
+		PollInterval:              10 * time.Second,
+		Timeout:                   time.Until(deadline),
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for the provisioning state of %s to become `Succeeded`: %+v", id, err)
+	}
+
+	return nil
+}
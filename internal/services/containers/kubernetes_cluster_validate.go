@@ -52,6 +52,12 @@ func validateKubernetesCluster(d *pluginsdk.ResourceData, cluster *managedcluste
 		}
 	}
 
+	for _, field := range []string{"maintenance_window_auto_upgrade", "maintenance_window_node_os"} {
+		if _, err := expandKubernetesClusterMaintenanceWindowSchedule(d.Get(field).([]interface{})); err != nil {
+			return fmt.Errorf("validating `%s`: %+v", field, err)
+		}
+	}
+
 	// @tombuildsstuff: As of 2020-03-30 it's no longer possible to create a cluster using a Service Principal
 	// for authentication (albeit this worked on 2020-03-27 via API version 2019-10-01 :shrug:). However it's
 	// possible to rotate the Service Principal for an existing Cluster - so this needs to be supported via
@@ -0,0 +1,245 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/sdk/2023-08-15-preview/fleetmembers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ sdk.ResourceWithUpdate = KubernetesFleetMemberResource{}
+
+type KubernetesFleetMemberResource struct{}
+
+func (r KubernetesFleetMemberResource) ModelObject() interface{} {
+	return &KubernetesFleetMemberModel{}
+}
+
+type KubernetesFleetMemberModel struct {
+	Group               string `tfschema:"group"`
+	KubernetesClusterId string `tfschema:"kubernetes_cluster_id"`
+	KubernetesFleetId   string `tfschema:"kubernetes_fleet_id"`
+	Name                string `tfschema:"name"`
+}
+
+func (r KubernetesFleetMemberResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return fleetmembers.ValidateMemberID
+}
+
+func (r KubernetesFleetMemberResource) ResourceType() string {
+	return "azurerm_kubernetes_fleet_member"
+}
+
+func (r KubernetesFleetMemberResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.KubernetesFleetMemberName,
+		},
+
+		"kubernetes_fleet_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: fleetmembers.ValidateFleetID,
+		},
+
+		"kubernetes_cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedclusters.ValidateManagedClusterID,
+		},
+
+		"group": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validate.KubernetesFleetMemberGroupName,
+		},
+	}
+}
+
+func (r KubernetesFleetMemberResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r KubernetesFleetMemberResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetMembersClient
+
+			var config KubernetesFleetMemberModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			fleetId, err := fleetmembers.ParseFleetID(config.KubernetesFleetId)
+			if err != nil {
+				return err
+			}
+
+			id := fleetmembers.NewMemberID(fleetId.SubscriptionId, fleetId.ResourceGroupName, fleetId.FleetName, config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			var payload fleetmembers.FleetMember
+			if err := r.mapKubernetesFleetMemberModelToFleetMember(config, &payload); err != nil {
+				return fmt.Errorf("mapping schema model to sdk model: %+v", err)
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, id, payload, fleetmembers.DefaultCreateOrUpdateOperationOptions()); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesFleetMemberResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetMembersClient
+
+			id, err := fleetmembers.ParseMemberID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config KubernetesFleetMemberModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChange("group") {
+				payload := fleetmembers.FleetMemberUpdate{
+					Properties: &fleetmembers.FleetMemberUpdateProperties{
+						Group: pointer.To(config.Group),
+					},
+				}
+				if err := client.UpdateThenPoll(ctx, *id, payload); err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesFleetMemberResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetMembersClient
+			schema := KubernetesFleetMemberModel{}
+
+			id, err := fleetmembers.ParseMemberID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			fleetId := fleetmembers.NewFleetID(id.SubscriptionId, id.ResourceGroupName, id.FleetName)
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			if model := resp.Model; model != nil {
+				schema.KubernetesFleetId = fleetId.ID()
+				schema.Name = id.MemberName
+				if err := r.mapFleetMemberToKubernetesFleetMemberModel(*model, &schema); err != nil {
+					return fmt.Errorf("flattening model: %+v", err)
+				}
+			}
+
+			return metadata.Encode(&schema)
+		},
+	}
+}
+
+func (r KubernetesFleetMemberResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetMembersClient
+
+			id, err := fleetmembers.ParseMemberID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id, fleetmembers.DefaultDeleteOperationOptions()); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesFleetMemberResource) mapKubernetesFleetMemberModelToFleetMemberProperties(input KubernetesFleetMemberModel, output *fleetmembers.FleetMemberProperties) error {
+	output.ClusterResourceId = pointer.To(input.KubernetesClusterId)
+	if input.Group != "" {
+		output.Group = pointer.To(input.Group)
+	}
+	return nil
+}
+
+func (r KubernetesFleetMemberResource) mapFleetMemberPropertiesToKubernetesFleetMemberModel(input fleetmembers.FleetMemberProperties, output *KubernetesFleetMemberModel) error {
+	clusterId, err := managedclusters.ParseManagedClusterIDInsensitively(pointer.From(input.ClusterResourceId))
+	if err != nil {
+		return err
+	}
+	output.KubernetesClusterId = clusterId.ID()
+	output.Group = pointer.From(input.Group)
+	return nil
+}
+
+func (r KubernetesFleetMemberResource) mapKubernetesFleetMemberModelToFleetMember(input KubernetesFleetMemberModel, output *fleetmembers.FleetMember) error {
+
+	if output.Properties == nil {
+		output.Properties = &fleetmembers.FleetMemberProperties{}
+	}
+	if err := r.mapKubernetesFleetMemberModelToFleetMemberProperties(input, output.Properties); err != nil {
+		return fmt.Errorf("mapping Schema to SDK Field %q / Model %q: %+v", "FleetMemberProperties", "Properties", err)
+	}
+
+	return nil
+}
+
+func (r KubernetesFleetMemberResource) mapFleetMemberToKubernetesFleetMemberModel(input fleetmembers.FleetMember, output *KubernetesFleetMemberModel) error {
+
+	if input.Properties == nil {
+		input.Properties = &fleetmembers.FleetMemberProperties{}
+	}
+	if err := r.mapFleetMemberPropertiesToKubernetesFleetMemberModel(*input.Properties, output); err != nil {
+		return fmt.Errorf("mapping SDK Field %q / Model %q to Schema: %+v", "FleetMemberProperties", "Properties", err)
+	}
+
+	return nil
+}
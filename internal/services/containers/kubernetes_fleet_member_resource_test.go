@@ -0,0 +1,167 @@
+package containers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/sdk/2023-08-15-preview/fleetmembers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KubernetesFleetMemberResource struct{}
+
+func TestAccKubernetesFleetMember_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_fleet_member", "test")
+	r := KubernetesFleetMemberResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKubernetesFleetMember_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_fleet_member", "test")
+	r := KubernetesFleetMemberResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccKubernetesFleetMember_group(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_fleet_member", "test")
+	r := KubernetesFleetMemberResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.group(data, "group1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("group").HasValue("group1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.group(data, "group2"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("group").HasValue("group2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r KubernetesFleetMemberResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := fleetmembers.ParseMemberID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Containers.FleetMembersClient.Get(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.Model != nil), nil
+}
+
+func (r KubernetesFleetMemberResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_fleet_member" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  kubernetes_fleet_id   = azurerm_kubernetes_fleet_manager.test.id
+  name                  = "acctest-${local.random_integer}"
+}
+`, r.template(data))
+}
+
+func (r KubernetesFleetMemberResource) group(data acceptance.TestData, group string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_fleet_member" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  kubernetes_fleet_id   = azurerm_kubernetes_fleet_manager.test.id
+  name                  = "acctest-${local.random_integer}"
+  group                 = %q
+}
+`, r.template(data), group)
+}
+
+func (r KubernetesFleetMemberResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_fleet_member" "import" {
+  kubernetes_cluster_id = azurerm_kubernetes_fleet_member.test.kubernetes_cluster_id
+  kubernetes_fleet_id   = azurerm_kubernetes_fleet_member.test.kubernetes_fleet_id
+  name                  = azurerm_kubernetes_fleet_member.test.name
+}
+`, r.basic(data))
+}
+
+func (r KubernetesFleetMemberResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+locals {
+  random_integer   = %[1]d
+  primary_location = %[2]q
+}
+
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestrg-${local.random_integer}"
+  location = local.primary_location
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks${local.random_integer}"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  dns_prefix          = "acctestaks${local.random_integer}"
+
+  default_node_pool {
+    name       = "default"
+    node_count = 1
+    vm_size    = "Standard_DS2_v2"
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
+
+resource "azurerm_kubernetes_fleet_manager" "test" {
+  location            = azurerm_resource_group.test.location
+  name                = "acctest-${local.random_integer}"
+  resource_group_name = azurerm_resource_group.test.name
+
+  hub_profile {
+    dns_prefix = "acctest"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
@@ -0,0 +1,370 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/sdk/2023-08-15-preview/fleetupdatestrategies"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/sdk/2023-08-15-preview/updateruns"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.Resource = KubernetesFleetUpdateRunResource{}
+
+type KubernetesFleetUpdateRunResource struct{}
+
+func (r KubernetesFleetUpdateRunResource) ModelObject() interface{} {
+	return &KubernetesFleetUpdateRunModel{}
+}
+
+type KubernetesFleetUpdateRunModel struct {
+	FleetUpdateStrategyId string                                     `tfschema:"fleet_update_strategy_id"`
+	KubernetesFleetId     string                                     `tfschema:"kubernetes_fleet_id"`
+	ManagedClusterUpdate  []KubernetesFleetManagedClusterUpdateModel `tfschema:"managed_cluster_update"`
+	Name                  string                                     `tfschema:"name"`
+	Stages                []KubernetesFleetUpdateStageModel          `tfschema:"stage"`
+}
+
+type KubernetesFleetManagedClusterUpdateModel struct {
+	NodeImageSelection []KubernetesFleetNodeImageSelectionModel    `tfschema:"node_image_selection"`
+	Upgrade            []KubernetesFleetManagedClusterUpgradeModel `tfschema:"upgrade"`
+}
+
+type KubernetesFleetManagedClusterUpgradeModel struct {
+	KubernetesVersion string `tfschema:"kubernetes_version"`
+	Type              string `tfschema:"type"`
+}
+
+type KubernetesFleetNodeImageSelectionModel struct {
+	Type string `tfschema:"type"`
+}
+
+func (r KubernetesFleetUpdateRunResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return updateruns.ValidateUpdateRunID
+}
+
+func (r KubernetesFleetUpdateRunResource) ResourceType() string {
+	return "azurerm_kubernetes_fleet_update_run"
+}
+
+func (r KubernetesFleetUpdateRunResource) Arguments() map[string]*pluginsdk.Schema {
+	stages := kubernetesFleetUpdateStageSchema(true)
+	stages.ConflictsWith = []string{"fleet_update_strategy_id"}
+
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.KubernetesFleetUpdateRunName,
+		},
+
+		"kubernetes_fleet_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: updateruns.ValidateFleetID,
+		},
+
+		"managed_cluster_update": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			ForceNew: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"upgrade": {
+						Type:     pluginsdk.TypeList,
+						Required: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"type": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringInSlice(updateruns.PossibleValuesForManagedClusterUpgradeType(), false),
+								},
+
+								"kubernetes_version": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+							},
+						},
+					},
+
+					"node_image_selection": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"type": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringInSlice(updateruns.PossibleValuesForNodeImageSelectionType(), false),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"fleet_update_strategy_id": {
+			Type:          pluginsdk.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ValidateFunc:  fleetupdatestrategies.ValidateUpdateStrategyID,
+			ConflictsWith: []string{"stage"},
+		},
+
+		"stage": stages,
+	}
+}
+
+func (r KubernetesFleetUpdateRunResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r KubernetesFleetUpdateRunResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetUpdateRunsClient
+
+			var config KubernetesFleetUpdateRunModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			fleetId, err := updateruns.ParseFleetID(config.KubernetesFleetId)
+			if err != nil {
+				return err
+			}
+
+			id := updateruns.NewUpdateRunID(fleetId.SubscriptionId, fleetId.ResourceGroupName, fleetId.FleetName, config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := updateruns.UpdateRun{
+				Properties: &updateruns.UpdateRunProperties{
+					ManagedClusterUpdate: expandKubernetesFleetManagedClusterUpdate(config.ManagedClusterUpdate),
+				},
+			}
+			if config.FleetUpdateStrategyId != "" {
+				payload.Properties.UpdateStrategyId = pointer.To(config.FleetUpdateStrategyId)
+			}
+			if len(config.Stages) > 0 {
+				payload.Properties.Strategy = &updateruns.UpdateRunStrategy{
+					Stages: expandKubernetesFleetUpdateRunStages(config.Stages),
+				}
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, id, payload, updateruns.DefaultCreateOrUpdateOperationOptions()); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			// an Update Run doesn't do anything until it's been started
+			if err := client.StartThenPoll(ctx, id); err != nil {
+				return fmt.Errorf("starting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesFleetUpdateRunResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetUpdateRunsClient
+
+			id, err := updateruns.ParseUpdateRunID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := KubernetesFleetUpdateRunModel{
+				KubernetesFleetId: updateruns.NewFleetID(id.SubscriptionId, id.ResourceGroupName, id.FleetName).ID(),
+				Name:              id.UpdateRunName,
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil {
+					state.ManagedClusterUpdate = flattenKubernetesFleetManagedClusterUpdate(props.ManagedClusterUpdate)
+
+					if props.UpdateStrategyId != nil && *props.UpdateStrategyId != "" {
+						strategyId, err := fleetupdatestrategies.ParseUpdateStrategyIDInsensitively(*props.UpdateStrategyId)
+						if err != nil {
+							return err
+						}
+						state.FleetUpdateStrategyId = strategyId.ID()
+					} else if props.Strategy != nil {
+						// the stages are only set when they're defined inline, since when an update strategy is
+						// referenced the API returns a copy of its stages
+						state.Stages = flattenKubernetesFleetUpdateRunStages(props.Strategy.Stages)
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KubernetesFleetUpdateRunResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetUpdateRunsClient
+
+			id, err := updateruns.ParseUpdateRunID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			// an Update Run which is in progress has to be stopped before it can be deleted
+			if model := existing.Model; model != nil && model.Properties != nil && model.Properties.Status != nil && model.Properties.Status.Status != nil {
+				if state := pointer.From(model.Properties.Status.Status.State); state == updateruns.UpdateStateRunning {
+					if err := client.StopThenPoll(ctx, *id); err != nil {
+						return fmt.Errorf("stopping %s: %+v", *id, err)
+					}
+				}
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id, updateruns.DefaultDeleteOperationOptions()); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandKubernetesFleetManagedClusterUpdate(input []KubernetesFleetManagedClusterUpdateModel) updateruns.ManagedClusterUpdate {
+	output := updateruns.ManagedClusterUpdate{}
+	if len(input) == 0 {
+		return output
+	}
+
+	if upgrade := input[0].Upgrade; len(upgrade) > 0 {
+		output.Upgrade = updateruns.ManagedClusterUpgradeSpec{
+			Type: updateruns.ManagedClusterUpgradeType(upgrade[0].Type),
+		}
+		if upgrade[0].KubernetesVersion != "" {
+			output.Upgrade.KubernetesVersion = pointer.To(upgrade[0].KubernetesVersion)
+		}
+	}
+
+	if selection := input[0].NodeImageSelection; len(selection) > 0 {
+		output.NodeImageSelection = &updateruns.NodeImageSelection{
+			Type: updateruns.NodeImageSelectionType(selection[0].Type),
+		}
+	}
+
+	return output
+}
+
+func flattenKubernetesFleetManagedClusterUpdate(input updateruns.ManagedClusterUpdate) []KubernetesFleetManagedClusterUpdateModel {
+	output := KubernetesFleetManagedClusterUpdateModel{
+		Upgrade: []KubernetesFleetManagedClusterUpgradeModel{
+			{
+				KubernetesVersion: pointer.From(input.Upgrade.KubernetesVersion),
+				Type:              string(input.Upgrade.Type),
+			},
+		},
+	}
+
+	if input.NodeImageSelection != nil {
+		output.NodeImageSelection = []KubernetesFleetNodeImageSelectionModel{
+			{
+				Type: string(input.NodeImageSelection.Type),
+			},
+		}
+	}
+
+	return []KubernetesFleetManagedClusterUpdateModel{output}
+}
+
+func expandKubernetesFleetUpdateRunStages(input []KubernetesFleetUpdateStageModel) []updateruns.UpdateStage {
+	output := make([]updateruns.UpdateStage, 0)
+	for _, stage := range input {
+		groups := make([]updateruns.UpdateGroup, 0)
+		for _, group := range stage.Groups {
+			groups = append(groups, updateruns.UpdateGroup{
+				Name: group.Name,
+			})
+		}
+
+		item := updateruns.UpdateStage{
+			Groups: &groups,
+			Name:   stage.Name,
+		}
+		if stage.AfterStageWaitInSeconds > 0 {
+			item.AfterStageWaitInSeconds = pointer.To(stage.AfterStageWaitInSeconds)
+		}
+		output = append(output, item)
+	}
+	return output
+}
+
+func flattenKubernetesFleetUpdateRunStages(input []updateruns.UpdateStage) []KubernetesFleetUpdateStageModel {
+	output := make([]KubernetesFleetUpdateStageModel, 0)
+	for _, stage := range input {
+		groups := make([]KubernetesFleetUpdateGroupModel, 0)
+		if stage.Groups != nil {
+			for _, group := range *stage.Groups {
+				groups = append(groups, KubernetesFleetUpdateGroupModel{
+					Name: group.Name,
+				})
+			}
+		}
+
+		output = append(output, KubernetesFleetUpdateStageModel{
+			AfterStageWaitInSeconds: pointer.From(stage.AfterStageWaitInSeconds),
+			Groups:                  groups,
+			Name:                    stage.Name,
+		})
+	}
+	return output
+}
@@ -0,0 +1,155 @@
+package containers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/sdk/2023-08-15-preview/updateruns"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KubernetesFleetUpdateRunResource struct{}
+
+func TestAccKubernetesFleetUpdateRun_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_fleet_update_run", "test")
+	r := KubernetesFleetUpdateRunResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKubernetesFleetUpdateRun_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_fleet_update_run", "test")
+	r := KubernetesFleetUpdateRunResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccKubernetesFleetUpdateRun_updateStrategy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_fleet_update_run", "test")
+	r := KubernetesFleetUpdateRunResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.updateStrategy(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r KubernetesFleetUpdateRunResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := updateruns.ParseUpdateRunID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Containers.FleetUpdateRunsClient.Get(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.Model != nil), nil
+}
+
+func (r KubernetesFleetUpdateRunResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_fleet_update_run" "test" {
+  name                = "acctest-${local.random_integer}"
+  kubernetes_fleet_id = azurerm_kubernetes_fleet_manager.test.id
+
+  managed_cluster_update {
+    upgrade {
+      type = "NodeImageOnly"
+    }
+
+    node_image_selection {
+      type = "Latest"
+    }
+  }
+
+  stage {
+    name = "stage-1"
+
+    group {
+      name = "group1"
+    }
+  }
+
+  depends_on = [azurerm_kubernetes_fleet_member.test]
+}
+`, KubernetesFleetMemberResource{}.group(data, "group1"))
+}
+
+func (r KubernetesFleetUpdateRunResource) updateStrategy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_fleet_update_run" "test" {
+  name                     = "acctest-${local.random_integer}"
+  kubernetes_fleet_id      = azurerm_kubernetes_fleet_manager.test.id
+  fleet_update_strategy_id = azurerm_kubernetes_fleet_update_strategy.test.id
+
+  managed_cluster_update {
+    upgrade {
+      type = "NodeImageOnly"
+    }
+  }
+
+  depends_on = [azurerm_kubernetes_fleet_member.test]
+}
+`, KubernetesFleetUpdateStrategyResource{}.basic(data))
+}
+
+func (r KubernetesFleetUpdateRunResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_fleet_update_run" "import" {
+  name                = azurerm_kubernetes_fleet_update_run.test.name
+  kubernetes_fleet_id = azurerm_kubernetes_fleet_update_run.test.kubernetes_fleet_id
+
+  managed_cluster_update {
+    upgrade {
+      type = "NodeImageOnly"
+    }
+
+    node_image_selection {
+      type = "Latest"
+    }
+  }
+
+  stage {
+    name = "stage-1"
+
+    group {
+      name = "group1"
+    }
+  }
+}
+`, r.basic(data))
+}
@@ -0,0 +1,299 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/sdk/2023-08-15-preview/fleetupdatestrategies"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.ResourceWithUpdate = KubernetesFleetUpdateStrategyResource{}
+
+type KubernetesFleetUpdateStrategyResource struct{}
+
+func (r KubernetesFleetUpdateStrategyResource) ModelObject() interface{} {
+	return &KubernetesFleetUpdateStrategyModel{}
+}
+
+type KubernetesFleetUpdateStrategyModel struct {
+	KubernetesFleetId string                            `tfschema:"kubernetes_fleet_id"`
+	Name              string                            `tfschema:"name"`
+	Stages            []KubernetesFleetUpdateStageModel `tfschema:"stage"`
+}
+
+type KubernetesFleetUpdateStageModel struct {
+	AfterStageWaitInSeconds int64                             `tfschema:"after_stage_wait_in_seconds"`
+	Groups                  []KubernetesFleetUpdateGroupModel `tfschema:"group"`
+	Name                    string                            `tfschema:"name"`
+}
+
+type KubernetesFleetUpdateGroupModel struct {
+	Name string `tfschema:"name"`
+}
+
+func (r KubernetesFleetUpdateStrategyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return fleetupdatestrategies.ValidateUpdateStrategyID
+}
+
+func (r KubernetesFleetUpdateStrategyResource) ResourceType() string {
+	return "azurerm_kubernetes_fleet_update_strategy"
+}
+
+func (r KubernetesFleetUpdateStrategyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.KubernetesFleetUpdateStrategyName,
+		},
+
+		"kubernetes_fleet_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: fleetupdatestrategies.ValidateFleetID,
+		},
+
+		"stage": kubernetesFleetUpdateStageSchema(false),
+	}
+}
+
+func (r KubernetesFleetUpdateStrategyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r KubernetesFleetUpdateStrategyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetUpdateStrategiesClient
+
+			var config KubernetesFleetUpdateStrategyModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			fleetId, err := fleetupdatestrategies.ParseFleetID(config.KubernetesFleetId)
+			if err != nil {
+				return err
+			}
+
+			id := fleetupdatestrategies.NewUpdateStrategyID(fleetId.SubscriptionId, fleetId.ResourceGroupName, fleetId.FleetName, config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := fleetupdatestrategies.FleetUpdateStrategy{
+				Properties: &fleetupdatestrategies.FleetUpdateStrategyProperties{
+					Strategy: fleetupdatestrategies.UpdateRunStrategy{
+						Stages: expandKubernetesFleetUpdateStrategyStages(config.Stages),
+					},
+				},
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, id, payload, fleetupdatestrategies.DefaultCreateOrUpdateOperationOptions()); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesFleetUpdateStrategyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetUpdateStrategiesClient
+
+			id, err := fleetupdatestrategies.ParseUpdateStrategyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config KubernetesFleetUpdateStrategyModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if existing.Model == nil || existing.Model.Properties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", *id)
+			}
+
+			payload := *existing.Model
+			if metadata.ResourceData.HasChange("stage") {
+				payload.Properties.Strategy.Stages = expandKubernetesFleetUpdateStrategyStages(config.Stages)
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, *id, payload, fleetupdatestrategies.DefaultCreateOrUpdateOperationOptions()); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesFleetUpdateStrategyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetUpdateStrategiesClient
+
+			id, err := fleetupdatestrategies.ParseUpdateStrategyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := KubernetesFleetUpdateStrategyModel{
+				KubernetesFleetId: fleetupdatestrategies.NewFleetID(id.SubscriptionId, id.ResourceGroupName, id.FleetName).ID(),
+				Name:              id.UpdateStrategyName,
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil {
+					state.Stages = flattenKubernetesFleetUpdateStrategyStages(props.Strategy.Stages)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KubernetesFleetUpdateStrategyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.FleetUpdateStrategiesClient
+
+			id, err := fleetupdatestrategies.ParseUpdateStrategyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id, fleetupdatestrategies.DefaultDeleteOperationOptions()); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// kubernetesFleetUpdateStageSchema returns the schema for the stages of an update strategy, which can
+// either be defined within a Fleet Update Strategy or directly within a Fleet Update Run
+func kubernetesFleetUpdateStageSchema(forceNew bool) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Required: !forceNew,
+		Optional: forceNew,
+		ForceNew: forceNew,
+		MinItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ForceNew:     forceNew,
+					ValidateFunc: validate.KubernetesFleetUpdateStageName,
+				},
+
+				"group": {
+					Type:     pluginsdk.TypeList,
+					Required: true,
+					ForceNew: forceNew,
+					MinItems: 1,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"name": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ForceNew:     forceNew,
+								ValidateFunc: validate.KubernetesFleetMemberGroupName,
+							},
+						},
+					},
+				},
+
+				"after_stage_wait_in_seconds": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ForceNew:     forceNew,
+					ValidateFunc: validation.IntBetween(0, 3600),
+				},
+			},
+		},
+	}
+}
+
+func expandKubernetesFleetUpdateStrategyStages(input []KubernetesFleetUpdateStageModel) []fleetupdatestrategies.UpdateStage {
+	output := make([]fleetupdatestrategies.UpdateStage, 0)
+	for _, stage := range input {
+		groups := make([]fleetupdatestrategies.UpdateGroup, 0)
+		for _, group := range stage.Groups {
+			groups = append(groups, fleetupdatestrategies.UpdateGroup{
+				Name: group.Name,
+			})
+		}
+
+		item := fleetupdatestrategies.UpdateStage{
+			Groups: &groups,
+			Name:   stage.Name,
+		}
+		if stage.AfterStageWaitInSeconds > 0 {
+			item.AfterStageWaitInSeconds = pointer.To(stage.AfterStageWaitInSeconds)
+		}
+		output = append(output, item)
+	}
+	return output
+}
+
+func flattenKubernetesFleetUpdateStrategyStages(input []fleetupdatestrategies.UpdateStage) []KubernetesFleetUpdateStageModel {
+	output := make([]KubernetesFleetUpdateStageModel, 0)
+	for _, stage := range input {
+		groups := make([]KubernetesFleetUpdateGroupModel, 0)
+		if stage.Groups != nil {
+			for _, group := range *stage.Groups {
+				groups = append(groups, KubernetesFleetUpdateGroupModel{
+					Name: group.Name,
+				})
+			}
+		}
+
+		output = append(output, KubernetesFleetUpdateStageModel{
+			AfterStageWaitInSeconds: pointer.From(stage.AfterStageWaitInSeconds),
+			Groups:                  groups,
+			Name:                    stage.Name,
+		})
+	}
+	return output
+}
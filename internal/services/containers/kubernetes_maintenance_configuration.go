@@ -0,0 +1,336 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/maintenanceconfigurations"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+const (
+	// kubernetesClusterMaintenanceConfigurationNameDefault is the configuration used by the `maintenance_window` block
+	kubernetesClusterMaintenanceConfigurationNameDefault = "default"
+
+	// kubernetesClusterMaintenanceConfigurationNameAutoUpgrade is the configuration which governs when the cluster
+	// is upgraded according to `automatic_channel_upgrade`
+	kubernetesClusterMaintenanceConfigurationNameAutoUpgrade = "aksManagedAutoUpgradeSchedule"
+
+	// kubernetesClusterMaintenanceConfigurationNameNodeOS is the configuration which governs when the node images
+	// are upgraded according to `node_os_channel_upgrade`
+	kubernetesClusterMaintenanceConfigurationNameNodeOS = "aksManagedNodeOSUpgradeSchedule"
+)
+
+type kubernetesClusterMaintenanceFrequency string
+
+const (
+	kubernetesClusterMaintenanceFrequencyDaily           kubernetesClusterMaintenanceFrequency = "Daily"
+	kubernetesClusterMaintenanceFrequencyWeekly          kubernetesClusterMaintenanceFrequency = "Weekly"
+	kubernetesClusterMaintenanceFrequencyAbsoluteMonthly kubernetesClusterMaintenanceFrequency = "AbsoluteMonthly"
+	kubernetesClusterMaintenanceFrequencyRelativeMonthly kubernetesClusterMaintenanceFrequency = "RelativeMonthly"
+)
+
+func schemaKubernetesClusterMaintenanceWindowSchedule(frequencies []string) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"frequency": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(frequencies, false),
+				},
+
+				"interval": {
+					Type:         pluginsdk.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+
+				"duration": {
+					Type:         pluginsdk.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(4, 24),
+				},
+
+				"day_of_week": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(maintenanceconfigurations.WeekDaySunday),
+						string(maintenanceconfigurations.WeekDayMonday),
+						string(maintenanceconfigurations.WeekDayTuesday),
+						string(maintenanceconfigurations.WeekDayWednesday),
+						string(maintenanceconfigurations.WeekDayThursday),
+						string(maintenanceconfigurations.WeekDayFriday),
+						string(maintenanceconfigurations.WeekDaySaturday),
+					}, false),
+				},
+
+				"week_index": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(maintenanceconfigurations.TypeFirst),
+						string(maintenanceconfigurations.TypeSecond),
+						string(maintenanceconfigurations.TypeThird),
+						string(maintenanceconfigurations.TypeFourth),
+						string(maintenanceconfigurations.TypeLast),
+					}, false),
+				},
+
+				"day_of_month": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(1, 31),
+				},
+
+				"start_time": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "00:00",
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`), "`start_time` must be in the format `HH:MM`"),
+				},
+
+				"utc_offset": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(-|\+)[0-9]{2}:[0-9]{2}$`), "`utc_offset` must be in the format `+HH:MM` or `-HH:MM`"),
+				},
+
+				"start_date": {
+					Type:             pluginsdk.TypeString,
+					Optional:         true,
+					Computed:         true,
+					DiffSuppressFunc: suppress.RFC3339Time,
+					ValidateFunc:     validation.IsRFC3339Time,
+				},
+
+				"not_allowed": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"end": {
+								Type:             pluginsdk.TypeString,
+								Required:         true,
+								DiffSuppressFunc: suppress.RFC3339Time,
+								ValidateFunc:     validation.IsRFC3339Time,
+							},
+
+							"start": {
+								Type:             pluginsdk.TypeString,
+								Required:         true,
+								DiffSuppressFunc: suppress.RFC3339Time,
+								ValidateFunc:     validation.IsRFC3339Time,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandKubernetesClusterMaintenanceWindowSchedule(input []interface{}) (*maintenanceconfigurations.MaintenanceConfigurationProperties, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+	v := input[0].(map[string]interface{})
+
+	frequency := kubernetesClusterMaintenanceFrequency(v["frequency"].(string))
+	interval := int64(v["interval"].(int))
+	dayOfWeek := v["day_of_week"].(string)
+	weekIndex := v["week_index"].(string)
+	dayOfMonth := v["day_of_month"].(int)
+
+	schedule := maintenanceconfigurations.Schedule{}
+	switch frequency {
+	case kubernetesClusterMaintenanceFrequencyDaily:
+		schedule.Daily = &maintenanceconfigurations.DailySchedule{
+			IntervalDays: interval,
+		}
+
+	case kubernetesClusterMaintenanceFrequencyWeekly:
+		if dayOfWeek == "" {
+			return nil, fmt.Errorf("`day_of_week` must be specified when `frequency` is `%s`", frequency)
+		}
+		schedule.Weekly = &maintenanceconfigurations.WeeklySchedule{
+			DayOfWeek:     maintenanceconfigurations.WeekDay(dayOfWeek),
+			IntervalWeeks: interval,
+		}
+
+	case kubernetesClusterMaintenanceFrequencyAbsoluteMonthly:
+		if dayOfMonth == 0 {
+			return nil, fmt.Errorf("`day_of_month` must be specified when `frequency` is `%s`", frequency)
+		}
+		schedule.AbsoluteMonthly = &maintenanceconfigurations.AbsoluteMonthlySchedule{
+			DayOfMonth:     int64(dayOfMonth),
+			IntervalMonths: interval,
+		}
+
+	case kubernetesClusterMaintenanceFrequencyRelativeMonthly:
+		if dayOfWeek == "" || weekIndex == "" {
+			return nil, fmt.Errorf("`day_of_week` and `week_index` must be specified when `frequency` is `%s`", frequency)
+		}
+		schedule.RelativeMonthly = &maintenanceconfigurations.RelativeMonthlySchedule{
+			DayOfWeek:      maintenanceconfigurations.WeekDay(dayOfWeek),
+			IntervalMonths: interval,
+			WeekIndex:      maintenanceconfigurations.Type(weekIndex),
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported `frequency` %q", frequency)
+	}
+
+	if frequency != kubernetesClusterMaintenanceFrequencyAbsoluteMonthly && dayOfMonth != 0 {
+		return nil, fmt.Errorf("`day_of_month` can only be specified when `frequency` is `%s`", kubernetesClusterMaintenanceFrequencyAbsoluteMonthly)
+	}
+	if frequency != kubernetesClusterMaintenanceFrequencyRelativeMonthly && weekIndex != "" {
+		return nil, fmt.Errorf("`week_index` can only be specified when `frequency` is `%s`", kubernetesClusterMaintenanceFrequencyRelativeMonthly)
+	}
+
+	window := maintenanceconfigurations.MaintenanceWindow{
+		DurationHours:   int64(v["duration"].(int)),
+		NotAllowedDates: expandKubernetesClusterMaintenanceWindowDateSpans(v["not_allowed"].(*pluginsdk.Set).List()),
+		Schedule:        schedule,
+		StartTime:       v["start_time"].(string),
+	}
+
+	if startDate := v["start_date"].(string); startDate != "" {
+		parsed, _ := time.Parse(time.RFC3339, startDate)
+		window.StartDate = utils.ToPtr(parsed.Format("2006-01-02"))
+	}
+	if utcOffset := v["utc_offset"].(string); utcOffset != "" {
+		window.UtcOffset = utils.ToPtr(utcOffset)
+	}
+
+	return &maintenanceconfigurations.MaintenanceConfigurationProperties{
+		MaintenanceWindow: &window,
+	}, nil
+}
+
+// updateKubernetesClusterMaintenanceWindowSchedule creates or updates the specified Maintenance Configuration, or
+// removes it when the block has been removed from the configuration
+func updateKubernetesClusterMaintenanceWindowSchedule(ctx context.Context, client *maintenanceconfigurations.MaintenanceConfigurationsClient, id maintenanceconfigurations.MaintenanceConfigurationId, input []interface{}) error {
+	properties, err := expandKubernetesClusterMaintenanceWindowSchedule(input)
+	if err != nil {
+		return err
+	}
+
+	if properties == nil {
+		if _, err := client.Delete(ctx, id); err != nil {
+			return fmt.Errorf("deleting %s: %+v", id, err)
+		}
+		return nil
+	}
+
+	parameters := maintenanceconfigurations.MaintenanceConfiguration{
+		Properties: properties,
+	}
+	if _, err := client.CreateOrUpdate(ctx, id, parameters); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func expandKubernetesClusterMaintenanceWindowDateSpans(input []interface{}) *[]maintenanceconfigurations.DateSpan {
+	results := make([]maintenanceconfigurations.DateSpan, 0)
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		start, _ := time.Parse(time.RFC3339, v["start"].(string))
+		end, _ := time.Parse(time.RFC3339, v["end"].(string))
+		results = append(results, maintenanceconfigurations.DateSpan{
+			Start: start.Format("2006-01-02"),
+			End:   end.Format("2006-01-02"),
+		})
+	}
+	return &results
+}
+
+func flattenKubernetesClusterMaintenanceWindowSchedule(input *maintenanceconfigurations.MaintenanceConfigurationProperties) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil || input.MaintenanceWindow == nil {
+		return results
+	}
+	window := input.MaintenanceWindow
+
+	frequency := ""
+	interval := int64(0)
+	dayOfWeek := ""
+	weekIndex := ""
+	dayOfMonth := int64(0)
+	if schedule := window.Schedule.Daily; schedule != nil {
+		frequency = string(kubernetesClusterMaintenanceFrequencyDaily)
+		interval = schedule.IntervalDays
+	}
+	if schedule := window.Schedule.Weekly; schedule != nil {
+		frequency = string(kubernetesClusterMaintenanceFrequencyWeekly)
+		interval = schedule.IntervalWeeks
+		dayOfWeek = string(schedule.DayOfWeek)
+	}
+	if schedule := window.Schedule.AbsoluteMonthly; schedule != nil {
+		frequency = string(kubernetesClusterMaintenanceFrequencyAbsoluteMonthly)
+		interval = schedule.IntervalMonths
+		dayOfMonth = schedule.DayOfMonth
+	}
+	if schedule := window.Schedule.RelativeMonthly; schedule != nil {
+		frequency = string(kubernetesClusterMaintenanceFrequencyRelativeMonthly)
+		interval = schedule.IntervalMonths
+		dayOfWeek = string(schedule.DayOfWeek)
+		weekIndex = string(schedule.WeekIndex)
+	}
+
+	startDate := ""
+	if window.StartDate != nil {
+		startDate = flattenKubernetesClusterMaintenanceWindowDate(*window.StartDate)
+	}
+
+	results = append(results, map[string]interface{}{
+		"frequency":    frequency,
+		"interval":     int(interval),
+		"duration":     int(window.DurationHours),
+		"day_of_week":  dayOfWeek,
+		"week_index":   weekIndex,
+		"day_of_month": int(dayOfMonth),
+		"start_time":   window.StartTime,
+		"utc_offset":   utils.NormalizeNilableString(window.UtcOffset),
+		"start_date":   startDate,
+		"not_allowed":  flattenKubernetesClusterMaintenanceWindowDateSpans(window.NotAllowedDates),
+	})
+	return results
+}
+
+func flattenKubernetesClusterMaintenanceWindowDateSpans(input *[]maintenanceconfigurations.DateSpan) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		results = append(results, map[string]interface{}{
+			"end":   flattenKubernetesClusterMaintenanceWindowDate(item.End),
+			"start": flattenKubernetesClusterMaintenanceWindowDate(item.Start),
+		})
+	}
+	return results
+}
+
+// flattenKubernetesClusterMaintenanceWindowDate converts the `YYYY-MM-DD` dates returned by the API into RFC3339
+// timestamps, so that they're comparable with the values in the configuration
+func flattenKubernetesClusterMaintenanceWindowDate(input string) string {
+	parsed, err := time.Parse("2006-01-02", input)
+	if err != nil {
+		return input
+	}
+	return parsed.Format(time.RFC3339)
+}
@@ -0,0 +1,39 @@
+package containers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KubernetesNodePoolSnapshotDataSource struct{}
+
+func TestAccDataSourceKubernetesNodePoolSnapshot_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_kubernetes_node_pool_snapshot", "test")
+	r := KubernetesNodePoolSnapshotDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("location").Exists(),
+				check.That(data.ResourceName).Key("source_node_pool_id").Exists(),
+				check.That(data.ResourceName).Key("node_image_version").Exists(),
+				check.That(data.ResourceName).Key("tags.%").HasValue("2"),
+			),
+		},
+	})
+}
+
+func (KubernetesNodePoolSnapshotDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_kubernetes_node_pool_snapshot" "test" {
+  name                = azurerm_kubernetes_node_pool_snapshot.test.name
+  resource_group_name = azurerm_kubernetes_node_pool_snapshot.test.resource_group_name
+}
+`, KubernetesNodePoolSnapshotResource{}.tags(data))
+}
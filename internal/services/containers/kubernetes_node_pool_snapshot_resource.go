@@ -0,0 +1,265 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/agentpools"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/snapshots"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type KubernetesNodePoolSnapshotModel struct {
+	Name              string                 `tfschema:"name"`
+	ResourceGroupName string                 `tfschema:"resource_group_name"`
+	Location          string                 `tfschema:"location"`
+	SourceNodePoolId  string                 `tfschema:"source_node_pool_id"`
+	Tags              map[string]interface{} `tfschema:"tags"`
+	FipsEnabled       bool                   `tfschema:"fips_enabled"`
+	KubernetesVersion string                 `tfschema:"kubernetes_version"`
+	NodeImageVersion  string                 `tfschema:"node_image_version"`
+	OsSku             string                 `tfschema:"os_sku"`
+	OsType            string                 `tfschema:"os_type"`
+	VmSize            string                 `tfschema:"vm_size"`
+}
+
+type KubernetesNodePoolSnapshotResource struct{}
+
+var _ sdk.ResourceWithUpdate = KubernetesNodePoolSnapshotResource{}
+
+func (r KubernetesNodePoolSnapshotResource) ResourceType() string {
+	return "azurerm_kubernetes_node_pool_snapshot"
+}
+
+func (r KubernetesNodePoolSnapshotResource) ModelObject() interface{} {
+	return &KubernetesNodePoolSnapshotModel{}
+}
+
+func (r KubernetesNodePoolSnapshotResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return snapshots.ValidateSnapshotID
+}
+
+func (r KubernetesNodePoolSnapshotResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,62}[a-zA-Z0-9_]$|^[a-zA-Z0-9]$`),
+				"`name` must be between 1 and 64 characters in length, may contain only letters, numbers, underscores, periods and hyphens, must begin with a letter or number and must end with a letter, number or underscore.",
+			),
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"source_node_pool_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: agentpools.ValidateAgentPoolID,
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r KubernetesNodePoolSnapshotResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"fips_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+
+		"kubernetes_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"node_image_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"os_sku": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"os_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"vm_size": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KubernetesNodePoolSnapshotResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model KubernetesNodePoolSnapshotModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.Containers.SnapshotsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			id := snapshots.NewSnapshotID(subscriptionId, model.ResourceGroupName, model.Name)
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := snapshots.Snapshot{
+				Location: location.Normalize(model.Location),
+				Properties: &snapshots.SnapshotProperties{
+					CreationData: &snapshots.CreationData{
+						SourceResourceId: pointer.To(model.SourceNodePoolId),
+					},
+					SnapshotType: pointer.To(snapshots.SnapshotTypeNodePool),
+				},
+				Tags: tags.Expand(model.Tags),
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesNodePoolSnapshotResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+
+			id, err := snapshots.ParseSnapshotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model KubernetesNodePoolSnapshotModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				parameters := snapshots.TagsObject{
+					Tags: tags.Expand(model.Tags),
+				}
+				if _, err := client.UpdateTags(ctx, *id, parameters); err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesNodePoolSnapshotResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+
+			id, err := snapshots.ParseSnapshotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := KubernetesNodePoolSnapshotModel{
+				Name:              id.SnapshotName,
+				ResourceGroupName: id.ResourceGroupName,
+			}
+
+			if model := resp.Model; model != nil {
+				state.Location = location.Normalize(model.Location)
+				state.Tags = tags.Flatten(model.Tags)
+
+				if err := flattenKubernetesNodePoolSnapshotProperties(model.Properties, &state); err != nil {
+					return err
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KubernetesNodePoolSnapshotResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+
+			id, err := snapshots.ParseSnapshotID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func flattenKubernetesNodePoolSnapshotProperties(input *snapshots.SnapshotProperties, state *KubernetesNodePoolSnapshotModel) error {
+	if input == nil {
+		return nil
+	}
+
+	if input.CreationData != nil && input.CreationData.SourceResourceId != nil {
+		sourceNodePoolId, err := agentpools.ParseAgentPoolIDInsensitively(*input.CreationData.SourceResourceId)
+		if err != nil {
+			return fmt.Errorf("parsing `source_node_pool_id`: %+v", err)
+		}
+		state.SourceNodePoolId = sourceNodePoolId.ID()
+	}
+
+	state.FipsEnabled = pointer.From(input.EnableFIPS)
+	state.KubernetesVersion = pointer.From(input.KubernetesVersion)
+	state.NodeImageVersion = pointer.From(input.NodeImageVersion)
+	state.OsSku = string(pointer.From(input.OsSku))
+	state.OsType = string(pointer.From(input.OsType))
+	state.VmSize = pointer.From(input.VMSize)
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/agentpools"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-02-02-preview/snapshots"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type KubernetesNodePoolSnapshotsDataSource struct{}
+
+var _ sdk.DataSource = KubernetesNodePoolSnapshotsDataSource{}
+
+type KubernetesNodePoolSnapshotsDataSourceModel struct {
+	ResourceGroupName string                                `tfschema:"resource_group_name"`
+	SourceNodePoolId  string                                `tfschema:"source_node_pool_id"`
+	TagsFilter        map[string]interface{}                `tfschema:"tags_filter"`
+	LatestSnapshotId  string                                `tfschema:"latest_snapshot_id"`
+	Snapshots         []KubernetesNodePoolSnapshotsSnapshot `tfschema:"snapshots"`
+}
+
+type KubernetesNodePoolSnapshotsSnapshot struct {
+	Id                string                 `tfschema:"id"`
+	Name              string                 `tfschema:"name"`
+	Location          string                 `tfschema:"location"`
+	SourceNodePoolId  string                 `tfschema:"source_node_pool_id"`
+	CreatedAt         string                 `tfschema:"created_at"`
+	KubernetesVersion string                 `tfschema:"kubernetes_version"`
+	NodeImageVersion  string                 `tfschema:"node_image_version"`
+	Tags              map[string]interface{} `tfschema:"tags"`
+}
+
+func (r KubernetesNodePoolSnapshotsDataSource) ResourceType() string {
+	return "azurerm_kubernetes_node_pool_snapshots"
+}
+
+func (r KubernetesNodePoolSnapshotsDataSource) ModelObject() interface{} {
+	return &KubernetesNodePoolSnapshotsDataSourceModel{}
+}
+
+func (r KubernetesNodePoolSnapshotsDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+		"source_node_pool_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: agentpools.ValidateAgentPoolID,
+		},
+
+		"tags_filter": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func (r KubernetesNodePoolSnapshotsDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"latest_snapshot_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"snapshots": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"location": commonschema.LocationComputed(),
+
+					"source_node_pool_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"created_at": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"kubernetes_version": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"node_image_version": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"tags": commonschema.TagsDataSource(),
+				},
+			},
+		},
+	}
+}
+
+func (r KubernetesNodePoolSnapshotsDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.SnapshotsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var state KubernetesNodePoolSnapshotsDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resourceGroupId := commonids.NewResourceGroupID(subscriptionId, state.ResourceGroupName)
+			resp, err := client.ListByResourceGroupComplete(ctx, resourceGroupId)
+			if err != nil {
+				return fmt.Errorf("listing Node Pool Snapshots within %s: %+v", resourceGroupId, err)
+			}
+
+			results, err := filterKubernetesNodePoolSnapshots(resp.Items, state.SourceNodePoolId, tags.Expand(state.TagsFilter))
+			if err != nil {
+				return err
+			}
+			if len(results) == 0 {
+				return fmt.Errorf("unable to find any Node Pool Snapshots matching the specified filters within %s", resourceGroupId)
+			}
+
+			state.Snapshots = results
+			state.LatestSnapshotId = results[0].Id
+
+			metadata.ResourceData.SetId(kubernetesNodePoolSnapshotsID(resourceGroupId, state.SourceNodePoolId, state.TagsFilter))
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+// kubernetesNodePoolSnapshotsID returns an ID for the Data Source which includes the filters, so that multiple
+// instances of the Data Source looking up different Node Pool Snapshots within a Resource Group are distinct
+func kubernetesNodePoolSnapshotsID(resourceGroupId commonids.ResourceGroupId, sourceNodePoolId string, filterTags map[string]interface{}) string {
+	filters := url.Values{}
+	if sourceNodePoolId != "" {
+		filters.Set("sourceNodePoolId", sourceNodePoolId)
+	}
+
+	tagFilters := make([]string, 0)
+	for k, v := range filterTags {
+		tagFilters = append(tagFilters, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(tagFilters)
+	for _, v := range tagFilters {
+		filters.Add("tags", v)
+	}
+
+	id := fmt.Sprintf("%s/nodePoolSnapshots", resourceGroupId.ID())
+	if len(filters) > 0 {
+		id = fmt.Sprintf("%s?%s", id, filters.Encode())
+	}
+	return id
+}
+
+// filterKubernetesNodePoolSnapshots returns the Node Pool Snapshots matching the specified Source Node Pool and Tags,
+// ordered from the most recently created to the oldest
+func filterKubernetesNodePoolSnapshots(input []snapshots.Snapshot, sourceNodePoolId string, filterTags *map[string]string) ([]KubernetesNodePoolSnapshotsSnapshot, error) {
+	results := make([]KubernetesNodePoolSnapshotsSnapshot, 0)
+	createdAt := make(map[string]time.Time)
+
+	for _, item := range input {
+		if item.Properties == nil || pointer.From(item.Properties.SnapshotType) != snapshots.SnapshotTypeNodePool {
+			continue
+		}
+
+		snapshotId, err := snapshots.ParseSnapshotIDInsensitively(pointer.From(item.Id))
+		if err != nil {
+			return nil, err
+		}
+
+		state := KubernetesNodePoolSnapshotModel{}
+		if err := flattenKubernetesNodePoolSnapshotProperties(item.Properties, &state); err != nil {
+			return nil, err
+		}
+
+		if sourceNodePoolId != "" && !strings.EqualFold(sourceNodePoolId, state.SourceNodePoolId) {
+			continue
+		}
+
+		if !kubernetesNodePoolSnapshotMatchesTags(item.Tags, filterTags) {
+			continue
+		}
+
+		result := KubernetesNodePoolSnapshotsSnapshot{
+			Id:                snapshotId.ID(),
+			Name:              snapshotId.SnapshotName,
+			Location:          location.Normalize(item.Location),
+			SourceNodePoolId:  state.SourceNodePoolId,
+			KubernetesVersion: state.KubernetesVersion,
+			NodeImageVersion:  state.NodeImageVersion,
+			Tags:              tags.Flatten(item.Tags),
+		}
+
+		if item.SystemData != nil && item.SystemData.CreatedAt != "" {
+			result.CreatedAt = item.SystemData.CreatedAt
+			if v, err := time.Parse(time.RFC3339, item.SystemData.CreatedAt); err == nil {
+				createdAt[result.Id] = v
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return createdAt[results[i].Id].After(createdAt[results[j].Id])
+	})
+
+	return results, nil
+}
+
+func kubernetesNodePoolSnapshotMatchesTags(input *map[string]string, filterTags *map[string]string) bool {
+	if filterTags == nil {
+		return true
+	}
+
+	existing := pointer.From(input)
+	for k, v := range *filterTags {
+		if existing[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,41 @@
+package containers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KubernetesNodePoolSnapshotsDataSource struct{}
+
+func TestAccDataSourceKubernetesNodePoolSnapshots_tagsFilter(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_kubernetes_node_pool_snapshots", "test")
+	r := KubernetesNodePoolSnapshotsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.tagsFilter(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("snapshots.#").HasValue("1"),
+				check.That(data.ResourceName).Key("snapshots.0.tags.version").HasValue("1"),
+				check.That(data.ResourceName).Key("latest_snapshot_id").Exists(),
+			),
+		},
+	})
+}
+
+func (KubernetesNodePoolSnapshotsDataSource) tagsFilter(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_kubernetes_node_pool_snapshots" "test" {
+  resource_group_name = azurerm_kubernetes_node_pool_snapshot.test.resource_group_name
+
+  tags_filter = {
+    image = "golden"
+  }
+}
+`, KubernetesNodePoolSnapshotResource{}.tags(data))
+}
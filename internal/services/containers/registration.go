@@ -56,7 +56,10 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 }
 
 func (r Registration) DataSources() []sdk.DataSource {
-	dataSources := []sdk.DataSource{}
+	dataSources := []sdk.DataSource{
+		KubernetesNodePoolSnapshotDataSource{},
+		KubernetesNodePoolSnapshotsDataSource{},
+	}
 	dataSources = append(dataSources, r.autoRegistration.DataSources()...)
 	return dataSources
 }
@@ -68,6 +71,12 @@ func (r Registration) Resources() []sdk.Resource {
 		ContainerRegistryTokenPasswordResource{},
 		ContainerConnectedRegistryResource{},
 		KubernetesClusterExtensionResource{},
+		KubernetesClusterRunCommandResource{},
+		KubernetesClusterTrustedAccessRoleBindingResource{},
+		KubernetesFleetMemberResource{},
+		KubernetesFleetUpdateRunResource{},
+		KubernetesFleetUpdateStrategyResource{},
+		KubernetesNodePoolSnapshotResource{},
 	}
 	resources = append(resources, r.autoRegistration.Resources()...)
 	return resources
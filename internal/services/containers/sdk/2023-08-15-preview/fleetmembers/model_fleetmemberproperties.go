@@ -0,0 +1,10 @@
+package fleetmembers
+
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License. See NOTICE.txt in the project root for license information.
+
+type FleetMemberProperties struct {
+	ClusterResourceId *string                       `json:"clusterResourceId,omitempty"`
+	Group             *string                       `json:"group,omitempty"`
+	ProvisioningState *FleetMemberProvisioningState `json:"provisioningState,omitempty"`
+}
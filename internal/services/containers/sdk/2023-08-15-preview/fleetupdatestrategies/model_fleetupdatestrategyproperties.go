@@ -0,0 +1,9 @@
+package fleetupdatestrategies
+
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License. See NOTICE.txt in the project root for license information.
+
+type FleetUpdateStrategyProperties struct {
+	ProvisioningState *FleetUpdateStrategyProvisioningState `json:"provisioningState,omitempty"`
+	Strategy          UpdateRunStrategy                     `json:"strategy"`
+}
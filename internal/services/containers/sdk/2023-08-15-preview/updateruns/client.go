@@ -0,0 +1,18 @@
+package updateruns
+
+import "github.com/Azure/go-autorest/autorest"
+
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License. See NOTICE.txt in the project root for license information.
+
+type UpdateRunsClient struct {
+	Client  autorest.Client
+	baseUri string
+}
+
+func NewUpdateRunsClientWithBaseURI(endpoint string) UpdateRunsClient {
+	return UpdateRunsClient{
+		Client:  autorest.NewClientWithUserAgent(userAgent()),
+		baseUri: endpoint,
+	}
+}
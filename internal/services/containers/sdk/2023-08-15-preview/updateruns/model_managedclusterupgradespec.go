@@ -0,0 +1,9 @@
+package updateruns
+
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License. See NOTICE.txt in the project root for license information.
+
+type ManagedClusterUpgradeSpec struct {
+	KubernetesVersion *string                   `json:"kubernetesVersion,omitempty"`
+	Type              ManagedClusterUpgradeType `json:"type"`
+}
@@ -0,0 +1,8 @@
+package updateruns
+
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License. See NOTICE.txt in the project root for license information.
+
+type UpdateRunStatus struct {
+	Status *UpdateStatus `json:"status,omitempty"`
+}
@@ -0,0 +1,12 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func KubernetesFleetMemberName(v interface{}, k string) (warnings []string, errors []error) {
+	return validation.StringMatch(regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,48}[a-z0-9])?$`), fmt.Sprintf("%q must be between 1 and 50 lowercase alphanumeric characters or dashes, and must start and end with an alphanumeric character", k))(v, k)
+}
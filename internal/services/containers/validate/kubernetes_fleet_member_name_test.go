@@ -0,0 +1,59 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+)
+
+func TestKubernetesFleetMemberName(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "a",
+			ErrCount: 0,
+		},
+		{
+			Value:    "member-1",
+			ErrCount: 0,
+		},
+		{
+			Value:    "-member",
+			ErrCount: 1,
+		},
+		{
+			Value:    "member-",
+			ErrCount: 1,
+		},
+		{
+			Value:    "Member",
+			ErrCount: 1,
+		},
+		{
+			Value:    "member_1",
+			ErrCount: 1,
+		},
+		{
+			Value:    "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwx",
+			ErrCount: 0,
+		},
+		{
+			Value:    "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxy",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validate.KubernetesFleetMemberName(tc.Value, "name")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d validation errors for the Kubernetes Fleet Member Name %q but got %d: %v", tc.ErrCount, tc.Value, len(errors), errors)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func KubernetesFleetMemberGroupName(v interface{}, k string) (warnings []string, errors []error) {
+	return kubernetesFleetChildName(v, k)
+}
+
+func KubernetesFleetUpdateRunName(v interface{}, k string) (warnings []string, errors []error) {
+	return kubernetesFleetChildName(v, k)
+}
+
+func KubernetesFleetUpdateStageName(v interface{}, k string) (warnings []string, errors []error) {
+	return kubernetesFleetChildName(v, k)
+}
+
+func KubernetesFleetUpdateStrategyName(v interface{}, k string) (warnings []string, errors []error) {
+	return kubernetesFleetChildName(v, k)
+}
+
+// kubernetesFleetChildName validates the names of the groups, stages, update strategies and update runs
+// within a Fleet, which follow the same rules as the name of a Fleet Member
+func kubernetesFleetChildName(v interface{}, k string) (warnings []string, errors []error) {
+	return validation.StringMatch(regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,48}[a-z0-9])?$`), fmt.Sprintf("%q must be between 1 and 50 lowercase alphanumeric characters or dashes, and must start and end with an alphanumeric character", k))(v, k)
+}
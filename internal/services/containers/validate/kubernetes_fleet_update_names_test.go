@@ -0,0 +1,69 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestKubernetesFleetUpdateNames(t *testing.T) {
+	validators := map[string]pluginsdk.SchemaValidateFunc{
+		"KubernetesFleetMemberGroupName":    validate.KubernetesFleetMemberGroupName,
+		"KubernetesFleetUpdateRunName":      validate.KubernetesFleetUpdateRunName,
+		"KubernetesFleetUpdateStageName":    validate.KubernetesFleetUpdateStageName,
+		"KubernetesFleetUpdateStrategyName": validate.KubernetesFleetUpdateStrategyName,
+	}
+
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "a",
+			ErrCount: 0,
+		},
+		{
+			Value:    "stage-1",
+			ErrCount: 0,
+		},
+		{
+			Value:    "-stage",
+			ErrCount: 1,
+		},
+		{
+			Value:    "stage-",
+			ErrCount: 1,
+		},
+		{
+			Value:    "Stage",
+			ErrCount: 1,
+		},
+		{
+			Value:    "stage_1",
+			ErrCount: 1,
+		},
+		{
+			Value:    "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwx",
+			ErrCount: 0,
+		},
+		{
+			Value:    "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxy",
+			ErrCount: 1,
+		},
+	}
+
+	for name, validator := range validators {
+		for _, tc := range cases {
+			_, errors := validator(tc.Value, "name")
+
+			if len(errors) != tc.ErrCount {
+				t.Fatalf("Expected %d validation errors from %s for %q but got %d: %v", tc.ErrCount, name, tc.Value, len(errors), errors)
+			}
+		}
+	}
+}
@@ -0,0 +1,436 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dns/2018-05-01/recordsets"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dns/zonefile"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// dnsZoneRecordSetsRecordTypes are the record types which can be managed within a DNS Zone
+var dnsZoneRecordSetsRecordTypes = []string{
+	string(recordsets.RecordTypeA),
+	string(recordsets.RecordTypeAAAA),
+	string(recordsets.RecordTypeCAA),
+	string(recordsets.RecordTypeCNAME),
+	string(recordsets.RecordTypeMX),
+	string(recordsets.RecordTypeNS),
+	string(recordsets.RecordTypePTR),
+	string(recordsets.RecordTypeSRV),
+	string(recordsets.RecordTypeTXT),
+}
+
+func resourceDnsZoneRecordSets() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceDnsZoneRecordSetsCreate,
+		Read:   resourceDnsZoneRecordSetsRead,
+		Update: resourceDnsZoneRecordSetsUpdate,
+		Delete: resourceDnsZoneRecordSetsDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
+			_, err := parseDnsZoneRecordSetsID(id)
+			return err
+		}, zonefile.ImportRecordSets),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: zonefile.RecordSetsSchema(commonschema.ResourceGroupName(), dnsZoneRecordSetsRecordTypes),
+	}
+}
+
+func resourceDnsZoneRecordSetsCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Dns.RecordSets
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := recordsets.NewDnsZoneID(subscriptionId, d.Get("resource_group_name").(string), d.Get("zone_name").(string))
+
+	if err := newDnsZoneRecordSets(client, id).Create(ctx, d); err != nil {
+		return err
+	}
+
+	return resourceDnsZoneRecordSetsRead(d, meta)
+}
+
+func resourceDnsZoneRecordSetsRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Dns.RecordSets
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parseDnsZoneRecordSetsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return newDnsZoneRecordSets(client, *id).Read(ctx, d)
+}
+
+func resourceDnsZoneRecordSetsUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Dns.RecordSets
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parseDnsZoneRecordSetsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := newDnsZoneRecordSets(client, *id).Update(ctx, d); err != nil {
+		return err
+	}
+
+	return resourceDnsZoneRecordSetsRead(d, meta)
+}
+
+func resourceDnsZoneRecordSetsDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Dns.RecordSets
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parseDnsZoneRecordSetsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return newDnsZoneRecordSets(client, *id).Delete(ctx, d)
+}
+
+// parseDnsZoneRecordSetsID parses the ID of the Record Sets within a DNS Zone, in the format `{dnsZoneId}/recordSets`
+func parseDnsZoneRecordSetsID(input string) (*recordsets.DnsZoneId, error) {
+	zoneId, err := zonefile.ParseRecordSetsID(input)
+	if err != nil {
+		return nil, err
+	}
+	return recordsets.ParseDnsZoneID(zoneId)
+}
+
+func newDnsZoneRecordSets(client *recordsets.RecordSetsClient, id recordsets.DnsZoneId) zonefile.ZoneRecordSets {
+	return zonefile.ZoneRecordSets{
+		ResourceType:      "azurerm_dns_zone_record_sets",
+		ZoneId:            id,
+		ZoneName:          id.DnsZoneName,
+		ResourceGroupName: id.ResourceGroupName,
+		RecordTypes:       dnsZoneRecordSetsRecordTypes,
+		Client: dnsZoneRecordSetsClient{
+			client: client,
+			zoneId: id,
+		},
+	}
+}
+
+// dnsZoneRecordSetsClient performs the operations on the Record Sets within a single DNS Zone
+type dnsZoneRecordSetsClient struct {
+	client *recordsets.RecordSetsClient
+	zoneId recordsets.DnsZoneId
+}
+
+var _ zonefile.Client = dnsZoneRecordSetsClient{}
+
+func (c dnsZoneRecordSetsClient) List(ctx context.Context) (*[]zonefile.RecordSet, error) {
+	resp, err := c.client.ListAllByDnsZone(ctx, c.zoneId, recordsets.DefaultListAllByDnsZoneOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing Record Sets within %s: %+v", c.zoneId, err)
+	}
+
+	results := make([]zonefile.RecordSet, 0)
+	if resp.Model != nil {
+		for _, v := range *resp.Model {
+			set, err := flattenDnsZoneRecordSet(v, c.zoneId.DnsZoneName)
+			if err != nil {
+				return nil, fmt.Errorf("flattening Record Sets within %s: %+v", c.zoneId, err)
+			}
+			if set != nil {
+				results = append(results, *set)
+			}
+		}
+	}
+
+	return &results, nil
+}
+
+func (c dnsZoneRecordSetsClient) CreateOrUpdate(ctx context.Context, set zonefile.RecordSet) error {
+	id := recordsets.NewRecordTypeID(c.zoneId.SubscriptionId, c.zoneId.ResourceGroupName, c.zoneId.DnsZoneName, recordsets.RecordType(set.Type), set.Name)
+	parameters, err := expandDnsZoneRecordSet(set)
+	if err != nil {
+		return err
+	}
+	if _, err := c.client.CreateOrUpdate(ctx, id, *parameters, recordsets.DefaultCreateOrUpdateOperationOptions()); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+	return nil
+}
+
+func (c dnsZoneRecordSetsClient) Delete(ctx context.Context, set zonefile.RecordSet) error {
+	id := recordsets.NewRecordTypeID(c.zoneId.SubscriptionId, c.zoneId.ResourceGroupName, c.zoneId.DnsZoneName, recordsets.RecordType(set.Type), set.Name)
+	if _, err := c.client.Delete(ctx, id, recordsets.DefaultDeleteOperationOptions()); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+	return nil
+}
+
+func expandDnsZoneRecordSet(input zonefile.RecordSet) (*recordsets.RecordSet, error) {
+	ttl := input.TTL
+	props := recordsets.RecordSetProperties{
+		TTL: &ttl,
+	}
+
+	fields := make([][]string, 0)
+	for _, record := range input.Records {
+		v, err := zonefile.Fields(record)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, v)
+	}
+
+	switch input.Type {
+	case zonefile.RecordTypeA:
+		records := make([]recordsets.ARecord, 0)
+		for _, v := range fields {
+			records = append(records, recordsets.ARecord{IPv4Address: &v[0]})
+		}
+		props.ARecords = &records
+
+	case zonefile.RecordTypeAAAA:
+		records := make([]recordsets.AaaaRecord, 0)
+		for _, v := range fields {
+			records = append(records, recordsets.AaaaRecord{IPv6Address: &v[0]})
+		}
+		props.AAAARecords = &records
+
+	case zonefile.RecordTypeCAA:
+		records := make([]recordsets.CaaRecord, 0)
+		for _, v := range fields {
+			flags, err := strconv.ParseInt(v[0], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, recordsets.CaaRecord{
+				Flags: &flags,
+				Tag:   &v[1],
+				Value: &v[2],
+			})
+		}
+		props.CaaRecords = &records
+
+	case zonefile.RecordTypeCNAME:
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("a CNAME Record Set must contain exactly one record but got %d", len(fields))
+		}
+		props.CNAMERecord = &recordsets.CnameRecord{
+			Cname: dnsZoneRecordSetsDomainName(fields[0][0]),
+		}
+
+	case zonefile.RecordTypeMX:
+		records := make([]recordsets.MxRecord, 0)
+		for _, v := range fields {
+			preference, err := strconv.ParseInt(v[0], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, recordsets.MxRecord{
+				Preference: &preference,
+				Exchange:   dnsZoneRecordSetsDomainName(v[1]),
+			})
+		}
+		props.MXRecords = &records
+
+	case zonefile.RecordTypeNS:
+		records := make([]recordsets.NsRecord, 0)
+		for _, v := range fields {
+			records = append(records, recordsets.NsRecord{Nsdname: dnsZoneRecordSetsDomainName(v[0])})
+		}
+		props.NSRecords = &records
+
+	case zonefile.RecordTypePTR:
+		records := make([]recordsets.PtrRecord, 0)
+		for _, v := range fields {
+			records = append(records, recordsets.PtrRecord{Ptrdname: dnsZoneRecordSetsDomainName(v[0])})
+		}
+		props.PTRRecords = &records
+
+	case zonefile.RecordTypeSRV:
+		records := make([]recordsets.SrvRecord, 0)
+		for _, v := range fields {
+			values := make([]int64, 0)
+			for _, field := range v[0:3] {
+				value, err := strconv.ParseInt(field, 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+			records = append(records, recordsets.SrvRecord{
+				Priority: &values[0],
+				Weight:   &values[1],
+				Port:     &values[2],
+				Target:   dnsZoneRecordSetsDomainName(v[3]),
+			})
+		}
+		props.SRVRecords = &records
+
+	case zonefile.RecordTypeTXT:
+		records := make([]recordsets.TxtRecord, 0)
+		for _, v := range fields {
+			value := strings.Join(v, "")
+
+			segments := make([]string, 0)
+			for len(value) > 254 {
+				segments = append(segments, value[:254])
+				value = value[254:]
+			}
+			segments = append(segments, value)
+
+			records = append(records, recordsets.TxtRecord{Value: &segments})
+		}
+		props.TXTRecords = &records
+
+	default:
+		return nil, fmt.Errorf("the record type %q is not supported", input.Type)
+	}
+
+	return &recordsets.RecordSet{
+		Name:       utils.String(input.Name),
+		Properties: &props,
+	}, nil
+}
+
+// flattenDnsZoneRecordSet converts the Record Set into its zone file representation, returning nil for
+// alias Record Sets since these can't be represented within a zone file
+func flattenDnsZoneRecordSet(input recordsets.RecordSet, zoneName string) (*zonefile.RecordSet, error) {
+	if input.Name == nil || input.Type == nil || input.Properties == nil {
+		return nil, nil
+	}
+	props := input.Properties
+	if props.TargetResource != nil && props.TargetResource.Id != nil && *props.TargetResource.Id != "" {
+		return nil, nil
+	}
+
+	recordType := *input.Type
+	if i := strings.LastIndex(recordType, "/"); i != -1 {
+		recordType = recordType[i+1:]
+	}
+
+	ttl := int64(0)
+	if props.TTL != nil {
+		ttl = *props.TTL
+	}
+
+	records := make([]string, 0)
+	switch recordsets.RecordType(recordType) {
+	case recordsets.RecordTypeA:
+		if props.ARecords != nil {
+			for _, v := range *props.ARecords {
+				records = append(records, pointer.From(v.IPv4Address))
+			}
+		}
+	case recordsets.RecordTypeAAAA:
+		if props.AAAARecords != nil {
+			for _, v := range *props.AAAARecords {
+				records = append(records, pointer.From(v.IPv6Address))
+			}
+		}
+	case recordsets.RecordTypeCAA:
+		if props.CaaRecords != nil {
+			for _, v := range *props.CaaRecords {
+				flags := int64(0)
+				if v.Flags != nil {
+					flags = *v.Flags
+				}
+				records = append(records, fmt.Sprintf("%d %s %s", flags, pointer.From(v.Tag), zonefile.Quote(pointer.From(v.Value))))
+			}
+		}
+	case recordsets.RecordTypeCNAME:
+		if props.CNAMERecord != nil && props.CNAMERecord.Cname != nil {
+			records = append(records, dnsZoneRecordSetsFqdn(*props.CNAMERecord.Cname))
+		}
+	case recordsets.RecordTypeMX:
+		if props.MXRecords != nil {
+			for _, v := range *props.MXRecords {
+				preference := int64(0)
+				if v.Preference != nil {
+					preference = *v.Preference
+				}
+				records = append(records, fmt.Sprintf("%d %s", preference, dnsZoneRecordSetsFqdn(pointer.From(v.Exchange))))
+			}
+		}
+	case recordsets.RecordTypeNS:
+		if props.NSRecords != nil {
+			for _, v := range *props.NSRecords {
+				records = append(records, dnsZoneRecordSetsFqdn(pointer.From(v.Nsdname)))
+			}
+		}
+	case recordsets.RecordTypePTR:
+		if props.PTRRecords != nil {
+			for _, v := range *props.PTRRecords {
+				records = append(records, dnsZoneRecordSetsFqdn(pointer.From(v.Ptrdname)))
+			}
+		}
+	case recordsets.RecordTypeSOA:
+		if v := props.SOARecord; v != nil {
+			records = append(records, fmt.Sprintf("%s %s %d %d %d %d %d", dnsZoneRecordSetsFqdn(pointer.From(v.Host)), dnsZoneRecordSetsFqdn(pointer.From(v.Email)), pointer.From(v.SerialNumber), pointer.From(v.RefreshTime), pointer.From(v.RetryTime), pointer.From(v.ExpireTime), pointer.From(v.MinimumTTL)))
+		}
+	case recordsets.RecordTypeSRV:
+		if props.SRVRecords != nil {
+			for _, v := range *props.SRVRecords {
+				records = append(records, fmt.Sprintf("%d %d %d %s", pointer.From(v.Priority), pointer.From(v.Weight), pointer.From(v.Port), dnsZoneRecordSetsFqdn(pointer.From(v.Target))))
+			}
+		}
+	case recordsets.RecordTypeTXT:
+		if props.TXTRecords != nil {
+			for _, v := range *props.TXTRecords {
+				value := ""
+				if v.Value != nil {
+					value = strings.Join(*v.Value, "")
+				}
+				records = append(records, zonefile.Quote(value))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("the record type %q is not supported", recordType)
+	}
+
+	// the records returned from the API are normalised in the same way as those in a zone file, so that the two can be compared
+	set, err := zonefile.Normalise(zonefile.RecordSet{
+		Name:    *input.Name,
+		Type:    recordType,
+		TTL:     ttl,
+		Records: records,
+	}, zoneName)
+	if err != nil {
+		return nil, fmt.Errorf("normalising the %s Record Set %q: %+v", recordType, *input.Name, err)
+	}
+
+	return set, nil
+}
+
+// dnsZoneRecordSetsFqdn returns the fully-qualified form (with a trailing dot) of a domain name returned from the API
+func dnsZoneRecordSetsFqdn(input string) string {
+	return strings.TrimSuffix(input, ".") + "."
+}
+
+// dnsZoneRecordSetsDomainName returns the domain name in the format used by the API (without a trailing dot)
+func dnsZoneRecordSetsDomainName(input string) *string {
+	value := strings.TrimSuffix(input, ".")
+	return &value
+}
@@ -0,0 +1,319 @@
+package dns_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dns/2018-05-01/recordsets"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dns/zonefile"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type DnsZoneRecordSetsResource struct{}
+
+func TestAccDnsZoneRecordSets_zoneFile(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dns_zone_record_sets", "test")
+	r := DnsZoneRecordSetsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.zoneFile(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("mode").HasValue("Additive"),
+				data.CheckWithClientForResource(r.recordSetExists("www", recordsets.RecordTypeA, true), data.ResourceName),
+				data.CheckWithClientForResource(r.recordSetExists("old", recordsets.RecordTypeCNAME, true), data.ResourceName),
+			),
+		},
+		{
+			Config: r.zoneFileUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.recordSetExists("www", recordsets.RecordTypeA, true), data.ResourceName),
+				data.CheckWithClientForResource(r.recordSetExists("old", recordsets.RecordTypeCNAME, false), data.ResourceName),
+				data.CheckWithClientForResource(r.recordSetExists("old", recordsets.RecordTypeA, true), data.ResourceName),
+			),
+		},
+	})
+}
+
+func TestAccDnsZoneRecordSets_recordSets(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dns_zone_record_sets", "test")
+	r := DnsZoneRecordSetsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.recordSets(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("record_set.#").HasValue("3"),
+			),
+		},
+		data.ImportStep("mode", "record_set", "zone_file"),
+	})
+}
+
+func TestAccDnsZoneRecordSets_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dns_zone_record_sets", "test")
+	r := DnsZoneRecordSetsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.recordSets(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(data),
+			ExpectError: acceptance.RequiresImportError("azurerm_dns_zone_record_sets"),
+		},
+	})
+}
+
+func TestAccDnsZoneRecordSets_authoritative(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dns_zone_record_sets", "test")
+	r := DnsZoneRecordSetsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.additive(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.createUnmanagedRecordSet("unmanaged"), data.ResourceName),
+			),
+		},
+		{
+			Config: r.authoritative(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.recordSetExists("www", recordsets.RecordTypeA, true), data.ResourceName),
+				data.CheckWithClientForResource(r.recordSetExists("unmanaged", recordsets.RecordTypeA, false), data.ResourceName),
+			),
+		},
+		data.ImportStep("zone_file"),
+	})
+}
+
+// zoneId returns the ID of the zone from the ID of the Record Sets within it
+func (DnsZoneRecordSetsResource) zoneId(state *pluginsdk.InstanceState) (*recordsets.DnsZoneId, error) {
+	zoneId, err := zonefile.ParseRecordSetsID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	return recordsets.ParseDnsZoneID(zoneId)
+}
+
+func (DnsZoneRecordSetsResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := DnsZoneRecordSetsResource{}.zoneId(state)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Dns.RecordSets.ListAllByDnsZone(ctx, *id, recordsets.DefaultListAllByDnsZoneOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing Record Sets within %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.Model != nil), nil
+}
+
+func (DnsZoneRecordSetsResource) recordSetExists(name string, recordType recordsets.RecordType, shouldExist bool) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		zoneId, err := DnsZoneRecordSetsResource{}.zoneId(state)
+		if err != nil {
+			return err
+		}
+
+		id := recordsets.NewRecordTypeID(zoneId.SubscriptionId, zoneId.ResourceGroupName, zoneId.DnsZoneName, recordType, name)
+		resp, err := clients.Dns.RecordSets.Get(ctx, id)
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				if shouldExist {
+					return fmt.Errorf("%s was not found", id)
+				}
+				return nil
+			}
+			return fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+
+		if !shouldExist {
+			return fmt.Errorf("%s still exists", id)
+		}
+		return nil
+	}
+}
+
+func (DnsZoneRecordSetsResource) createUnmanagedRecordSet(name string) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		zoneId, err := DnsZoneRecordSetsResource{}.zoneId(state)
+		if err != nil {
+			return err
+		}
+
+		id := recordsets.NewRecordTypeID(zoneId.SubscriptionId, zoneId.ResourceGroupName, zoneId.DnsZoneName, recordsets.RecordTypeA, name)
+		parameters := recordsets.RecordSet{
+			Properties: &recordsets.RecordSetProperties{
+				TTL: utils.Int64(300),
+				ARecords: &[]recordsets.ARecord{
+					{
+						IPv4Address: utils.String("10.0.0.100"),
+					},
+				},
+			},
+		}
+		if _, err := clients.Dns.RecordSets.CreateOrUpdate(ctx, id, parameters, recordsets.DefaultCreateOrUpdateOperationOptions()); err != nil {
+			return fmt.Errorf("creating %s: %+v", id, err)
+		}
+
+		return nil
+	}
+}
+
+func (DnsZoneRecordSetsResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_dns_zone" "test" {
+  name                = "acctestzone%d.com"
+  resource_group_name = azurerm_resource_group.test.name
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
+func (r DnsZoneRecordSetsResource) zoneFile(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+
+  zone_file = <<ZONE
+$TTL 300
+@       IN MX    10 mail
+@       IN TXT   "v=spf1 mx -all"
+@       IN CAA   0 issue "letsencrypt.org"
+mail    IN A     10.0.0.10
+www     IN A     10.0.0.1
+        IN A     10.0.0.2
+old     IN CNAME www
+_sip._tcp 600 IN SRV 10 60 5060 sip.example.com.
+ZONE
+}
+`, r.template(data))
+}
+
+func (r DnsZoneRecordSetsResource) zoneFileUpdated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+
+  zone_file = <<ZONE
+$TTL 600
+@       IN MX    10 mail
+@       IN TXT   "v=spf1 mx -all"
+mail    IN A     10.0.0.10
+www     IN A     10.0.0.1
+old     IN A     10.0.0.3
+ZONE
+}
+`, r.template(data))
+}
+
+func (r DnsZoneRecordSetsResource) recordSets(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+
+  record_set {
+    name    = "www"
+    type    = "A"
+    ttl     = 300
+    records = ["10.0.0.1", "10.0.0.2"]
+  }
+
+  record_set {
+    name    = "ipv6"
+    type    = "AAAA"
+    ttl     = 300
+    records = ["2001:db8::1"]
+  }
+
+  record_set {
+    name    = "@"
+    type    = "MX"
+    ttl     = 3600
+    records = ["10 mail1.contoso.com.", "20 mail2.contoso.com."]
+  }
+}
+`, r.template(data))
+}
+
+func (r DnsZoneRecordSetsResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dns_zone_record_sets" "import" {
+  zone_name           = azurerm_dns_zone_record_sets.test.zone_name
+  resource_group_name = azurerm_dns_zone_record_sets.test.resource_group_name
+
+  record_set {
+    name    = "www"
+    type    = "A"
+    ttl     = 300
+    records = ["10.0.0.1", "10.0.0.2"]
+  }
+}
+`, r.recordSets(data))
+}
+
+func (r DnsZoneRecordSetsResource) additive(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+
+  zone_file = <<ZONE
+www 300 IN A 10.0.0.1
+ZONE
+}
+`, r.template(data))
+}
+
+func (r DnsZoneRecordSetsResource) authoritative(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  mode                = "Authoritative"
+
+  zone_file = <<ZONE
+www 300 IN A 10.0.0.1
+ZONE
+}
+`, r.template(data))
+}
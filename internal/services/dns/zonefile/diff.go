@@ -0,0 +1,101 @@
+package zonefile
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/workerpool"
+)
+
+// Changes are the operations required to make the Record Sets within a zone match those which are desired
+type Changes struct {
+	// Upserts are the Record Sets which need to be created or updated
+	Upserts []RecordSet
+
+	// Deletes are the Record Sets which need to be removed
+	Deletes []RecordSet
+}
+
+// Diff compares the existing Record Sets within a zone to the desired Record Sets, per Record Set. When
+// `authoritative` is true any existing Record Set which isn't desired is deleted, otherwise only those
+// Record Sets which were previously managed (identified by their Key) are deleted. Record Sets which are
+// managed by the zone itself (see IsZoneManaged) are never changed.
+func Diff(existing []RecordSet, desired []RecordSet, previouslyManaged []string, authoritative bool) Changes {
+	existingByKey := make(map[string]RecordSet, len(existing))
+	for _, v := range existing {
+		existingByKey[v.Key()] = v
+	}
+	desiredByKey := make(map[string]RecordSet, len(desired))
+	for _, v := range desired {
+		desiredByKey[v.Key()] = v
+	}
+
+	changes := Changes{
+		Upserts: make([]RecordSet, 0),
+		Deletes: make([]RecordSet, 0),
+	}
+
+	for _, v := range desired {
+		if v.IsZoneManaged() {
+			continue
+		}
+		if current, ok := existingByKey[v.Key()]; !ok || !recordSetsEqual(current, v) {
+			changes.Upserts = append(changes.Upserts, v)
+		}
+	}
+
+	candidates := make([]RecordSet, 0)
+	if authoritative {
+		candidates = existing
+	} else {
+		for _, key := range previouslyManaged {
+			if v, ok := existingByKey[key]; ok {
+				candidates = append(candidates, v)
+			}
+		}
+	}
+	for _, v := range candidates {
+		if v.IsZoneManaged() {
+			continue
+		}
+		if _, ok := desiredByKey[v.Key()]; !ok {
+			changes.Deletes = append(changes.Deletes, v)
+		}
+	}
+
+	Sort(changes.Upserts)
+	Sort(changes.Deletes)
+
+	return changes
+}
+
+// Conflicts returns the existing Record Sets which would be taken over when first managing the desired Record
+// Sets - which are those matching a desired Record Set or, when `authoritative` is true, any existing Record
+// Set. Record Sets which are managed by the zone itself (see IsZoneManaged) never conflict.
+func Conflicts(existing []RecordSet, desired []RecordSet, authoritative bool) []RecordSet {
+	desiredByKey := make(map[string]struct{}, len(desired))
+	for _, v := range desired {
+		desiredByKey[v.Key()] = struct{}{}
+	}
+
+	results := make([]RecordSet, 0)
+	for _, v := range existing {
+		if v.IsZoneManaged() {
+			continue
+		}
+		if _, ok := desiredByKey[v.Key()]; ok || authoritative {
+			results = append(results, v)
+		}
+	}
+
+	Sort(results)
+
+	return results
+}
+
+// Apply runs the operation for each of the specified Record Sets, with at most `parallelism` operations
+// running at once, returning the errors from all of the operations which failed
+func Apply(input []RecordSet, parallelism int, operation func(set RecordSet) error) error {
+	return workerpool.Run(input, parallelism, func(set RecordSet) string {
+		return fmt.Sprintf("Record Set %q", set.Key())
+	}, operation)
+}
@@ -0,0 +1,393 @@
+package zonefile
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+const (
+	ModeAdditive      = "Additive"
+	ModeAuthoritative = "Authoritative"
+
+	// DefaultTTL is the TTL used for records in a zone file which don't specify one
+	DefaultTTL = 3600
+
+	defaultParallelism = 10
+
+	// recordSetsIDSuffix is appended to the ID of the zone to form the ID of the Record Sets within it
+	recordSetsIDSuffix = "/recordSets"
+)
+
+// Client performs the operations on the Record Sets within a single zone, which differ between the
+// (Public) DNS and Private DNS APIs
+type Client interface {
+	// List returns all of the Record Sets within the zone, or nil if the zone doesn't exist
+	List(ctx context.Context) (*[]RecordSet, error)
+
+	// CreateOrUpdate creates or updates the Record Set within the zone
+	CreateOrUpdate(ctx context.Context, set RecordSet) error
+
+	// Delete deletes the Record Set from the zone
+	Delete(ctx context.Context, set RecordSet) error
+}
+
+// ZoneRecordSets manages the Record Sets within a zone on behalf of the `azurerm_dns_zone_record_sets`
+// and `azurerm_private_dns_zone_record_sets` resources, which share the same schema
+type ZoneRecordSets struct {
+	// ResourceType is the name of the Terraform resource, e.g. `azurerm_dns_zone_record_sets`
+	ResourceType string
+
+	ZoneId            resourceids.ResourceId
+	ZoneName          string
+	ResourceGroupName string
+
+	// RecordTypes are the record types which are supported within the zone
+	RecordTypes []string
+
+	Client Client
+}
+
+// RecordSetsSchema returns the schema shared by the resources managing the Record Sets within a zone
+func RecordSetsSchema(resourceGroupName *pluginsdk.Schema, recordTypes []string) map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"zone_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"resource_group_name": resourceGroupName,
+
+		"mode": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Default:  ModeAdditive,
+			ValidateFunc: validation.StringInSlice([]string{
+				ModeAdditive,
+				ModeAuthoritative,
+			}, false),
+		},
+
+		"zone_file": {
+			Type:             pluginsdk.TypeString,
+			Optional:         true,
+			ExactlyOneOf:     []string{"zone_file", "record_set"},
+			ValidateFunc:     validation.StringIsNotEmpty,
+			DiffSuppressFunc: zoneFileDiffSuppress,
+		},
+
+		"record_set": {
+			Type:         pluginsdk.TypeSet,
+			Optional:     true,
+			ExactlyOneOf: []string{"zone_file", "record_set"},
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"type": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(recordTypes, false),
+					},
+
+					"ttl": {
+						Type:         pluginsdk.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntBetween(0, 2147483647),
+					},
+
+					"records": {
+						Type:     pluginsdk.TypeSet,
+						Required: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+		},
+
+		"parallelism": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      defaultParallelism,
+			ValidateFunc: validation.IntBetween(1, 50),
+		},
+	}
+}
+
+// RecordSetsID returns the ID of the Record Sets within the zone, in the format `{zoneId}/recordSets`
+func RecordSetsID(zoneId resourceids.ResourceId) string {
+	return zoneId.ID() + recordSetsIDSuffix
+}
+
+// ParseRecordSetsID returns the ID of the zone from the ID of the Record Sets within it
+func ParseRecordSetsID(input string) (string, error) {
+	zoneId := strings.TrimSuffix(input, recordSetsIDSuffix)
+	if zoneId == input || zoneId == "" {
+		return "", fmt.Errorf("expected an ID in the format `{zoneId}%s` but got %q", recordSetsIDSuffix, input)
+	}
+	return zoneId, nil
+}
+
+// ImportRecordSets configures the Record Sets being imported, which are managed in their entirety since
+// there's no configuration to scope them by
+func ImportRecordSets(_ context.Context, d *pluginsdk.ResourceData, _ interface{}) ([]*pluginsdk.ResourceData, error) {
+	d.Set("mode", ModeAuthoritative)
+	d.Set("parallelism", defaultParallelism)
+	return []*pluginsdk.ResourceData{d}, nil
+}
+
+func (z ZoneRecordSets) Create(ctx context.Context, d *pluginsdk.ResourceData) error {
+	desired, err := z.expand(d.Get("zone_file").(string), d.Get("record_set").(*pluginsdk.Set).List())
+	if err != nil {
+		return err
+	}
+
+	existing, err := z.list(ctx)
+	if err != nil {
+		return err
+	}
+
+	authoritative := d.Get("mode").(string) == ModeAuthoritative
+	if conflicts := Conflicts(existing, desired, authoritative); len(conflicts) > 0 {
+		for _, v := range conflicts {
+			log.Printf("[DEBUG] the Record Set %q already exists within %s", v.Key(), z.ZoneId)
+		}
+		return tf.ImportAsExistsError(z.ResourceType, RecordSetsID(z.ZoneId))
+	}
+
+	if err := z.apply(ctx, Diff(existing, desired, []string{}, authoritative), d.Get("parallelism").(int)); err != nil {
+		return err
+	}
+
+	d.SetId(RecordSetsID(z.ZoneId))
+
+	return nil
+}
+
+func (z ZoneRecordSets) Update(ctx context.Context, d *pluginsdk.ResourceData) error {
+	desired, err := z.expand(d.Get("zone_file").(string), d.Get("record_set").(*pluginsdk.Set).List())
+	if err != nil {
+		return err
+	}
+
+	oldZoneFile, _ := d.GetChange("zone_file")
+	oldRecordSets, _ := d.GetChange("record_set")
+	previous, err := z.expand(oldZoneFile.(string), oldRecordSets.(*pluginsdk.Set).List())
+	if err != nil {
+		return fmt.Errorf("expanding the previous Record Sets: %+v", err)
+	}
+
+	existing, err := z.list(ctx)
+	if err != nil {
+		return err
+	}
+
+	authoritative := d.Get("mode").(string) == ModeAuthoritative
+	return z.apply(ctx, Diff(existing, desired, keys(previous), authoritative), d.Get("parallelism").(int))
+}
+
+func (z ZoneRecordSets) Read(ctx context.Context, d *pluginsdk.ResourceData) error {
+	existing, err := z.Client.List(ctx)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		log.Printf("[DEBUG] %s was not found - removing from state", z.ZoneId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("zone_name", z.ZoneName)
+	d.Set("resource_group_name", z.ResourceGroupName)
+
+	zoneFile := d.Get("zone_file").(string)
+	configured, err := z.expand(zoneFile, d.Get("record_set").(*pluginsdk.Set).List())
+	if err != nil {
+		// the configuration is validated prior to being applied, so this can only happen when it's been changed
+		// outside of Terraform - in which case we'll pull in the current state of the zone instead
+		configured = make([]RecordSet, 0)
+	}
+
+	managedKeys := make(map[string]struct{})
+	for _, v := range configured {
+		managedKeys[v.Key()] = struct{}{}
+	}
+
+	authoritative := d.Get("mode").(string) == ModeAuthoritative
+	actual := make([]RecordSet, 0)
+	for _, v := range *existing {
+		if v.IsZoneManaged() {
+			continue
+		}
+		if _, ok := managedKeys[v.Key()]; ok || authoritative {
+			actual = append(actual, v)
+		}
+	}
+
+	// the configuration is retained when it's equivalent, to avoid a diff from the formatting of the records
+	if Equal(WithoutZoneManaged(configured), actual) {
+		return nil
+	}
+
+	if zoneFile != "" || d.Get("record_set").(*pluginsdk.Set).Len() == 0 {
+		d.Set("zone_file", Render(z.ZoneName, actual))
+		return nil
+	}
+
+	if err := d.Set("record_set", flattenRecordSets(actual)); err != nil {
+		return fmt.Errorf("setting `record_set`: %+v", err)
+	}
+
+	return nil
+}
+
+func (z ZoneRecordSets) Delete(ctx context.Context, d *pluginsdk.ResourceData) error {
+	managed, err := z.expand(d.Get("zone_file").(string), d.Get("record_set").(*pluginsdk.Set).List())
+	if err != nil {
+		return err
+	}
+
+	existing, err := z.Client.List(ctx)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+
+	return z.apply(ctx, Diff(*existing, []RecordSet{}, keys(managed), false), d.Get("parallelism").(int))
+}
+
+// list returns all of the Record Sets within the zone, returning an error if the zone doesn't exist
+func (z ZoneRecordSets) list(ctx context.Context) ([]RecordSet, error) {
+	existing, err := z.Client.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("%s was not found", z.ZoneId)
+	}
+	return *existing, nil
+}
+
+func (z ZoneRecordSets) apply(ctx context.Context, changes Changes, parallelism int) error {
+	// deletions are applied first, since a CNAME can't co-exist with another Record Set using the same name
+	err := Apply(changes.Deletes, parallelism, func(set RecordSet) error {
+		return z.Client.Delete(ctx, set)
+	})
+	if err != nil {
+		return fmt.Errorf("deleting Record Sets within %s: %+v", z.ZoneId, err)
+	}
+
+	err = Apply(changes.Upserts, parallelism, func(set RecordSet) error {
+		return z.Client.CreateOrUpdate(ctx, set)
+	})
+	if err != nil {
+		return fmt.Errorf("creating/updating Record Sets within %s: %+v", z.ZoneId, err)
+	}
+
+	return nil
+}
+
+// expand returns the Record Sets defined by either the `zone_file` or the `record_set` blocks
+func (z ZoneRecordSets) expand(zoneFile string, input []interface{}) ([]RecordSet, error) {
+	results := make([]RecordSet, 0)
+	if zoneFile != "" {
+		sets, err := Parse(zoneFile, z.ZoneName, DefaultTTL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `zone_file`: %+v", err)
+		}
+		results = sets
+	} else {
+		keys := make(map[string]struct{})
+		for _, item := range input {
+			v := item.(map[string]interface{})
+
+			set, err := Normalise(RecordSet{
+				Name:    v["name"].(string),
+				Type:    v["type"].(string),
+				TTL:     int64(v["ttl"].(int)),
+				Records: *utils.ExpandStringSlice(v["records"].(*pluginsdk.Set).List()),
+			}, z.ZoneName)
+			if err != nil {
+				return nil, fmt.Errorf("expanding `record_set` %q (%s): %+v", v["name"].(string), v["type"].(string), err)
+			}
+
+			if _, ok := keys[set.Key()]; ok {
+				return nil, fmt.Errorf("`record_set` %q (%s) is specified more than once", set.Name, set.Type)
+			}
+			keys[set.Key()] = struct{}{}
+			results = append(results, *set)
+		}
+	}
+
+	supported := make(map[string]struct{}, len(z.RecordTypes))
+	for _, v := range z.RecordTypes {
+		supported[v] = struct{}{}
+	}
+	for _, v := range results {
+		if v.IsZoneManaged() {
+			continue
+		}
+		if _, ok := supported[v.Type]; !ok {
+			return nil, fmt.Errorf("the Record Set %q uses the record type %q which isn't supported within %s", v.Name, v.Type, z.ZoneId)
+		}
+	}
+
+	return results, nil
+}
+
+func flattenRecordSets(input []RecordSet) []interface{} {
+	results := make([]interface{}, 0)
+	for _, v := range input {
+		results = append(results, map[string]interface{}{
+			"name":    v.Name,
+			"type":    v.Type,
+			"ttl":     int(v.TTL),
+			"records": v.Records,
+		})
+	}
+	return results
+}
+
+func zoneFileDiffSuppress(_, old, new string, d *pluginsdk.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+
+	zoneName := d.Get("zone_name").(string)
+	oldRecordSets, err := Parse(old, zoneName, DefaultTTL)
+	if err != nil {
+		return false
+	}
+	newRecordSets, err := Parse(new, zoneName, DefaultTTL)
+	if err != nil {
+		return false
+	}
+
+	return Equal(WithoutZoneManaged(oldRecordSets), WithoutZoneManaged(newRecordSets))
+}
+
+func keys(input []RecordSet) []string {
+	results := make([]string, 0)
+	for _, v := range input {
+		results = append(results, v.Key())
+	}
+	return results
+}
@@ -0,0 +1,141 @@
+package zonefile
+
+import (
+	"fmt"
+	"strings"
+)
+
+type token struct {
+	value  string
+	quoted bool
+}
+
+// line is a logical line within a zone file, which may span multiple physical lines when parentheses are used
+type line struct {
+	tokens []token
+
+	// blankOwner is whether the line begins with whitespace, meaning that the owner of the previous record is used
+	blankOwner bool
+
+	// number is the (physical) line number on which this line begins
+	number int
+}
+
+// tokenize splits the zone file into logical lines of tokens, removing comments, parentheses and quoting
+func tokenize(input string) ([]line, error) {
+	results := make([]line, 0)
+
+	var current *line
+	var value strings.Builder
+	hasValue := false
+	quoted := false
+	inQuotes := false
+	inComment := false
+	depth := 0
+	lineNumber := 1
+	atLineStart := true
+
+	endToken := func() {
+		if hasValue {
+			current.tokens = append(current.tokens, token{
+				value:  value.String(),
+				quoted: quoted,
+			})
+		}
+		value.Reset()
+		hasValue = false
+		quoted = false
+	}
+	endLine := func() {
+		endToken()
+		if current != nil && len(current.tokens) > 0 {
+			results = append(results, *current)
+		}
+		current = nil
+	}
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		if current == nil {
+			current = &line{
+				blankOwner: atLineStart && (c == ' ' || c == '\t'),
+				number:     lineNumber,
+			}
+		}
+		atLineStart = false
+
+		if inComment {
+			if c != '\n' {
+				continue
+			}
+			inComment = false
+		}
+
+		if inQuotes {
+			switch c {
+			case '\\':
+				if i+1 < len(runes) {
+					i++
+					value.WriteRune(runes[i])
+				}
+			case '"':
+				inQuotes = false
+			case '\n':
+				return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+			default:
+				value.WriteRune(c)
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inQuotes = true
+			hasValue = true
+			quoted = true
+		case ';':
+			endToken()
+			inComment = true
+		case '(':
+			endToken()
+			depth++
+		case ')':
+			endToken()
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unexpected `)`", lineNumber)
+			}
+			depth--
+		case ' ', '\t', '\r':
+			endToken()
+		case '\n':
+			if depth > 0 {
+				endToken()
+			} else {
+				endLine()
+			}
+			lineNumber++
+			atLineStart = true
+		case '\\':
+			hasValue = true
+			if i+1 < len(runes) {
+				i++
+				value.WriteRune(runes[i])
+			}
+		default:
+			hasValue = true
+			value.WriteRune(c)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unterminated `(`", lineNumber)
+	}
+	endLine()
+
+	return results, nil
+}
@@ -0,0 +1,505 @@
+// Package zonefile parses and renders RFC 1035 (BIND) zone files, diffs the Record Sets they describe and
+// manages those Record Sets within a zone, so that it can be shared between the (Public) DNS and Private DNS services.
+package zonefile
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	RecordTypeA     = "A"
+	RecordTypeAAAA  = "AAAA"
+	RecordTypeCAA   = "CAA"
+	RecordTypeCNAME = "CNAME"
+	RecordTypeMX    = "MX"
+	RecordTypeNS    = "NS"
+	RecordTypePTR   = "PTR"
+	RecordTypeSOA   = "SOA"
+	RecordTypeSRV   = "SRV"
+	RecordTypeTXT   = "TXT"
+
+	// ApexName is the relative name used for the apex of the zone
+	ApexName = "@"
+
+	// txtSegmentLength is the maximum length of a single character-string within a TXT record
+	txtSegmentLength = 255
+)
+
+// RecordSet is a set of records sharing the same name and type. Names are relative to the zone (lower-cased,
+// with ApexName used for the apex) and each record is held in its canonical RDATA presentation format, where
+// domain names are fully-qualified (with a trailing dot) and character-strings are quoted.
+type RecordSet struct {
+	Name    string
+	Type    string
+	TTL     int64
+	Records []string
+}
+
+// Key returns the value uniquely identifying this Record Set within a zone, in the format `name/TYPE`
+func (r RecordSet) Key() string {
+	return fmt.Sprintf("%s/%s", r.Name, r.Type)
+}
+
+// IsZoneManaged returns whether this Record Set is maintained by Azure DNS rather than by the user,
+// which is the case for the SOA record and the NS records at the apex of the zone.
+func (r RecordSet) IsZoneManaged() bool {
+	return r.Type == RecordTypeSOA || (r.Type == RecordTypeNS && r.Name == ApexName)
+}
+
+// WithoutZoneManaged returns the Record Sets which aren't maintained by Azure DNS (see IsZoneManaged)
+func WithoutZoneManaged(input []RecordSet) []RecordSet {
+	results := make([]RecordSet, 0)
+	for _, v := range input {
+		if !v.IsZoneManaged() {
+			results = append(results, v)
+		}
+	}
+	return results
+}
+
+// Parse parses the contents of a zone file for the zone `origin` into a sorted list of Record Sets. Records
+// with the same name and type are grouped into a single Record Set, and records without a TTL use the most
+// recent `$TTL` directive, falling back to `defaultTTL`.
+func Parse(input string, origin string, defaultTTL int64) ([]RecordSet, error) {
+	zone := fqdn(origin)
+	lines, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	currentOrigin := zone
+	currentTTL := defaultTTL
+	previousOwner := ""
+	sets := make(map[string]*RecordSet)
+
+	for _, line := range lines {
+		tokens := line.tokens
+
+		if !line.blankOwner && !tokens[0].quoted && strings.HasPrefix(tokens[0].value, "$") {
+			directive := strings.ToUpper(tokens[0].value)
+			switch directive {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: expected a single domain name for `$ORIGIN`", line.number)
+				}
+				currentOrigin = absoluteName(tokens[1].value, currentOrigin)
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: expected a single value for `$TTL`", line.number)
+				}
+				ttl, err := parseTTL(tokens[1].value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %+v", line.number, err)
+				}
+				currentTTL = ttl
+			default:
+				return nil, fmt.Errorf("line %d: the directive %q is not supported", line.number, tokens[0].value)
+			}
+			continue
+		}
+
+		owner := previousOwner
+		if !line.blankOwner {
+			owner = absoluteName(tokens[0].value, currentOrigin)
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("line %d: the record doesn't specify an owner name", line.number)
+		}
+		previousOwner = owner
+
+		name, err := relativeName(owner, zone)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %+v", line.number, err)
+		}
+
+		ttl := currentTTL
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			if v, err := parseTTL(tokens[0].value); err == nil && unicode.IsDigit(rune(tokens[0].value[0])) {
+				ttl = v
+				tokens = tokens[1:]
+				continue
+			}
+			if class := strings.ToUpper(tokens[0].value); class == "IN" {
+				tokens = tokens[1:]
+				continue
+			} else if class == "CH" || class == "CS" || class == "HS" {
+				return nil, fmt.Errorf("line %d: the class %q is not supported, only `IN` records are supported", line.number, tokens[0].value)
+			}
+			break
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: the record doesn't specify a type", line.number)
+		}
+
+		recordType := strings.ToUpper(tokens[0].value)
+		record, err := normaliseRecord(recordType, tokens[1:], currentOrigin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %+v", line.number, err)
+		}
+
+		key := RecordSet{Name: name, Type: recordType}.Key()
+		set, ok := sets[key]
+		if !ok {
+			set = &RecordSet{
+				Name: name,
+				Type: recordType,
+				TTL:  ttl,
+			}
+			sets[key] = set
+		}
+		if set.TTL != ttl {
+			return nil, fmt.Errorf("line %d: the records for %q must all use the same TTL but got %d and %d", line.number, key, set.TTL, ttl)
+		}
+		set.Records = append(set.Records, record)
+	}
+
+	results := make([]RecordSet, 0, len(sets))
+	for _, set := range sets {
+		results = append(results, *set)
+	}
+	Sort(results)
+
+	return results, nil
+}
+
+// Normalise returns the canonical form of a Record Set specified for the zone `origin`, where the name may be
+// relative to the zone or fully-qualified and the records are specified in RDATA presentation format.
+func Normalise(input RecordSet, origin string) (*RecordSet, error) {
+	zone := fqdn(origin)
+
+	name, err := relativeName(absoluteName(input.Name, zone), zone)
+	if err != nil {
+		return nil, err
+	}
+
+	recordType := strings.ToUpper(input.Type)
+	records := make([]string, 0, len(input.Records))
+	for _, v := range input.Records {
+		lines, err := tokenize(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("parsing the record %q: %+v", v, err)
+		}
+		if len(lines) != 1 || lines[0].blankOwner {
+			return nil, fmt.Errorf("parsing the record %q: expected a single record", v)
+		}
+
+		record, err := normaliseRecord(recordType, lines[0].tokens, zone)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the record %q: %+v", v, err)
+		}
+		records = append(records, record)
+	}
+
+	return &RecordSet{
+		Name:    name,
+		Type:    recordType,
+		TTL:     input.TTL,
+		Records: sortedRecords(records),
+	}, nil
+}
+
+// Render returns the zone file for the zone `origin` containing the specified Record Sets
+func Render(origin string, input []RecordSet) string {
+	sets := make([]RecordSet, len(input))
+	copy(sets, input)
+	Sort(sets)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("$ORIGIN %s\n", fqdn(origin)))
+	for _, set := range sets {
+		for _, record := range set.Records {
+			if set.Type == RecordTypeTXT {
+				record = renderTxtRecord(record)
+			}
+			sb.WriteString(fmt.Sprintf("%s\t%d\tIN\t%s\t%s\n", set.Name, set.TTL, set.Type, record))
+		}
+	}
+
+	return sb.String()
+}
+
+// Fields splits a record in RDATA presentation format into its fields, removing any quoting
+func Fields(record string) ([]string, error) {
+	lines, err := tokenize(record)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]string, 0)
+	for _, line := range lines {
+		for _, token := range line.tokens {
+			results = append(results, token.value)
+		}
+	}
+
+	return results, nil
+}
+
+// Quote returns the value as a quoted character-string, escaping any quotes and backslashes
+func Quote(input string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(input) + `"`
+}
+
+// Sort sorts the Record Sets by name (with the apex first) and then by type, and sorts the records within each
+func Sort(input []RecordSet) {
+	for i := range input {
+		input[i].Records = sortedRecords(input[i].Records)
+	}
+
+	sort.Slice(input, func(i, j int) bool {
+		if input[i].Name != input[j].Name {
+			if input[i].Name == ApexName || input[j].Name == ApexName {
+				return input[i].Name == ApexName
+			}
+			return input[i].Name < input[j].Name
+		}
+		return input[i].Type < input[j].Type
+	})
+}
+
+// Equal returns whether the two lists contain the same Record Sets, ignoring the order of both the Record Sets
+// and the records within them
+func Equal(first []RecordSet, second []RecordSet) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	values := make(map[string]RecordSet, len(first))
+	for _, v := range first {
+		values[v.Key()] = v
+	}
+	for _, v := range second {
+		existing, ok := values[v.Key()]
+		if !ok || !recordSetsEqual(existing, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func recordSetsEqual(first RecordSet, second RecordSet) bool {
+	if first.Key() != second.Key() || first.TTL != second.TTL {
+		return false
+	}
+
+	firstRecords := sortedRecords(first.Records)
+	secondRecords := sortedRecords(second.Records)
+	if len(firstRecords) != len(secondRecords) {
+		return false
+	}
+	for i := range firstRecords {
+		if firstRecords[i] != secondRecords[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func normaliseRecord(recordType string, tokens []token, origin string) (string, error) {
+	expectFields := func(count int) error {
+		if len(tokens) != count {
+			return fmt.Errorf("expected %d fields for a %s record but got %d", count, recordType, len(tokens))
+		}
+		return nil
+	}
+
+	switch recordType {
+	case RecordTypeA, RecordTypeAAAA:
+		if err := expectFields(1); err != nil {
+			return "", err
+		}
+		addr, err := netip.ParseAddr(tokens[0].value)
+		if err != nil || addr.Zone() != "" || (recordType == RecordTypeA) != addr.Is4() {
+			return "", fmt.Errorf("%q is not a valid address for a %s record", tokens[0].value, recordType)
+		}
+		return addr.String(), nil
+
+	case RecordTypeCNAME, RecordTypeNS, RecordTypePTR:
+		if err := expectFields(1); err != nil {
+			return "", err
+		}
+		return absoluteName(tokens[0].value, origin), nil
+
+	case RecordTypeMX:
+		if err := expectFields(2); err != nil {
+			return "", err
+		}
+		preference, err := parseUint(tokens[0].value, 16, "preference")
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %s", preference, absoluteName(tokens[1].value, origin)), nil
+
+	case RecordTypeSRV:
+		if err := expectFields(4); err != nil {
+			return "", err
+		}
+		values := make([]uint64, 0, 3)
+		for i, field := range []string{"priority", "weight", "port"} {
+			v, err := parseUint(tokens[i].value, 16, field)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, v)
+		}
+		return fmt.Sprintf("%d %d %d %s", values[0], values[1], values[2], absoluteName(tokens[3].value, origin)), nil
+
+	case RecordTypeCAA:
+		if err := expectFields(3); err != nil {
+			return "", err
+		}
+		flags, err := parseUint(tokens[0].value, 8, "flags")
+		if err != nil {
+			return "", err
+		}
+		tag := strings.ToLower(tokens[1].value)
+		if tag == "" || strings.IndexFunc(tag, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) != -1 {
+			return "", fmt.Errorf("%q is not a valid tag for a CAA record", tokens[1].value)
+		}
+		return fmt.Sprintf("%d %s %s", flags, tag, Quote(tokens[2].value)), nil
+
+	case RecordTypeTXT:
+		if len(tokens) == 0 {
+			return "", fmt.Errorf("expected at least one character-string for a TXT record")
+		}
+		var value strings.Builder
+		for _, t := range tokens {
+			value.WriteString(t.value)
+		}
+		return Quote(value.String()), nil
+
+	case RecordTypeSOA:
+		if err := expectFields(7); err != nil {
+			return "", err
+		}
+		serial, err := parseUint(tokens[2].value, 32, "serial")
+		if err != nil {
+			return "", err
+		}
+		values := []string{absoluteName(tokens[0].value, origin), absoluteName(tokens[1].value, origin), strconv.FormatUint(serial, 10)}
+		for _, t := range tokens[3:] {
+			v, err := parseTTL(t.value)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, strconv.FormatInt(v, 10))
+		}
+		return strings.Join(values, " "), nil
+	}
+
+	return "", fmt.Errorf("the record type %q is not supported", recordType)
+}
+
+func renderTxtRecord(record string) string {
+	fields, err := Fields(record)
+	if err != nil || len(fields) != 1 {
+		return record
+	}
+
+	value := fields[0]
+	segments := make([]string, 0)
+	for len(value) > txtSegmentLength {
+		segments = append(segments, Quote(value[:txtSegmentLength]))
+		value = value[txtSegmentLength:]
+	}
+	segments = append(segments, Quote(value))
+
+	return strings.Join(segments, " ")
+}
+
+func sortedRecords(input []string) []string {
+	unique := make(map[string]struct{}, len(input))
+	results := make([]string, 0, len(input))
+	for _, v := range input {
+		if _, ok := unique[v]; ok {
+			continue
+		}
+		unique[v] = struct{}{}
+		results = append(results, v)
+	}
+	sort.Strings(results)
+	return results
+}
+
+// fqdn returns the lower-cased, fully-qualified form of the domain name (with a trailing dot)
+func fqdn(input string) string {
+	return strings.TrimSuffix(strings.ToLower(input), ".") + "."
+}
+
+// absoluteName returns the fully-qualified form of a domain name which may be relative to `origin`
+func absoluteName(input string, origin string) string {
+	if input == ApexName {
+		return origin
+	}
+	if strings.HasSuffix(input, ".") {
+		return strings.ToLower(input)
+	}
+	return strings.ToLower(input) + "." + origin
+}
+
+// relativeName returns the name relative to the zone, or ApexName for the apex of the zone
+func relativeName(name string, zone string) (string, error) {
+	if name == zone {
+		return ApexName, nil
+	}
+	if !strings.HasSuffix(name, "."+zone) {
+		return "", fmt.Errorf("the name %q isn't within the zone %q", name, zone)
+	}
+	return strings.TrimSuffix(name, "."+zone), nil
+}
+
+func parseUint(input string, bitSize int, field string) (uint64, error) {
+	v, err := strconv.ParseUint(input, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid %s", input, field)
+	}
+	return v, nil
+}
+
+// parseTTL parses a TTL which is either a number of seconds or a BIND-style duration such as `1h30m`
+func parseTTL(input string) (int64, error) {
+	if v, err := strconv.ParseUint(input, 10, 31); err == nil {
+		return int64(v), nil
+	}
+
+	units := map[byte]int64{
+		's': 1,
+		'm': 60,
+		'h': 60 * 60,
+		'd': 24 * 60 * 60,
+		'w': 7 * 24 * 60 * 60,
+	}
+
+	total := int64(0)
+	value := ""
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		if c >= '0' && c <= '9' {
+			value += string(c)
+			continue
+		}
+		multiplier, ok := units[byte(unicode.ToLower(rune(c)))]
+		if !ok || value == "" {
+			return 0, fmt.Errorf("%q is not a valid TTL", input)
+		}
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a valid TTL", input)
+		}
+		total += v * multiplier
+		value = ""
+	}
+	if value != "" || total == 0 || total > 2147483647 {
+		return 0, fmt.Errorf("%q is not a valid TTL", input)
+	}
+
+	return total, nil
+}
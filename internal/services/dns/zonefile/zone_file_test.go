@@ -0,0 +1,315 @@
+package zonefile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testData := []struct {
+		name          string
+		input         string
+		expected      []RecordSet
+		expectedError bool
+	}{
+		{
+			name:     "empty",
+			input:    "; nothing to see here\n\n",
+			expected: []RecordSet{},
+		},
+		{
+			name: "relative and absolute names",
+			input: `
+$TTL 300
+@            IN A     10.0.0.1
+www          IN CNAME @
+api.example.com. 60 IN A 10.0.0.2
+WWW2         CNAME    other.example.org.
+`,
+			expected: []RecordSet{
+				{Name: "@", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+				{Name: "api", Type: "A", TTL: 60, Records: []string{"10.0.0.2"}},
+				{Name: "www", Type: "CNAME", TTL: 300, Records: []string{"example.com."}},
+				{Name: "www2", Type: "CNAME", TTL: 300, Records: []string{"other.example.org."}},
+			},
+		},
+		{
+			name: "records are grouped into record sets",
+			input: `
+mail 3600 IN A 10.0.0.2
+     3600 IN A 10.0.0.1 ; the owner is inherited
+@    1h   IN MX 20 mail
+@    1h   IN MX 10 mail.example.com.
+`,
+			expected: []RecordSet{
+				{Name: "@", Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com.", "20 mail.example.com."}},
+				{Name: "mail", Type: "A", TTL: 3600, Records: []string{"10.0.0.1", "10.0.0.2"}},
+			},
+		},
+		{
+			name: "origin directive",
+			input: `
+$ORIGIN sub.example.com.
+host 300 IN AAAA 2001:DB8::0001
+_sip._tcp 300 IN SRV 10 60 5060 host
+`,
+			expected: []RecordSet{
+				{Name: "_sip._tcp.sub", Type: "SRV", TTL: 300, Records: []string{"10 60 5060 host.sub.example.com."}},
+				{Name: "host.sub", Type: "AAAA", TTL: 300, Records: []string{"2001:db8::1"}},
+			},
+		},
+		{
+			name: "quoted strings and parentheses",
+			input: `
+@ 300 IN TXT ( "v=spf1 include:spf.example.net"
+               " -all" )
+@ 300 IN CAA 0 issue "letsencrypt.org"
+@ 300 IN CAA 0 iodef "mailto:security@example.com"
+q 300 IN TXT "semi;colon \"quoted\""
+`,
+			expected: []RecordSet{
+				{Name: "@", Type: "CAA", TTL: 300, Records: []string{`0 iodef "mailto:security@example.com"`, `0 issue "letsencrypt.org"`}},
+				{Name: "@", Type: "TXT", TTL: 300, Records: []string{`"v=spf1 include:spf.example.net -all"`}},
+				{Name: "q", Type: "TXT", TTL: 300, Records: []string{`"semi;colon \"quoted\""`}},
+			},
+		},
+		{
+			name: "soa record",
+			input: `
+@ 3600 IN SOA ns1-01.azure-dns.com. azuredns-hostmaster.microsoft.com. (
+    1     ; serial
+    3600  ; refresh
+    300   ; retry
+    2419200 ; expire
+    300 )
+`,
+			expected: []RecordSet{
+				{Name: "@", Type: "SOA", TTL: 3600, Records: []string{"ns1-01.azure-dns.com. azuredns-hostmaster.microsoft.com. 1 3600 300 2419200 300"}},
+			},
+		},
+		{
+			name:          "name outside of the zone",
+			input:         "www.example.org. 300 IN A 10.0.0.1",
+			expectedError: true,
+		},
+		{
+			name:          "mismatched ttls",
+			input:         "www 300 IN A 10.0.0.1\nwww 600 IN A 10.0.0.2",
+			expectedError: true,
+		},
+		{
+			name:          "invalid address",
+			input:         "www 300 IN A 2001:db8::1",
+			expectedError: true,
+		},
+		{
+			name:          "unsupported type",
+			input:         "www 300 IN HINFO cpu os",
+			expectedError: true,
+		},
+		{
+			name:          "unsupported class",
+			input:         "www 300 CH A 10.0.0.1",
+			expectedError: true,
+		},
+		{
+			name:          "unterminated quote",
+			input:         "www 300 IN TXT \"hello\n",
+			expectedError: true,
+		},
+		{
+			name:          "unterminated parentheses",
+			input:         "www 300 IN MX ( 10 mail",
+			expectedError: true,
+		},
+		{
+			name:          "no owner",
+			input:         "  300 IN A 10.0.0.1",
+			expectedError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := Parse(v.input, "Example.com", 3600)
+		if v.expectedError {
+			if err == nil {
+				t.Fatalf("expected an error but got %+v", actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestRenderRoundTrip(t *testing.T) {
+	input := []RecordSet{
+		{Name: "www", Type: "A", TTL: 300, Records: []string{"10.0.0.2", "10.0.0.1"}},
+		{Name: "@", Type: "NS", TTL: 172800, Records: []string{"ns1-01.azure-dns.com."}},
+		{Name: "long", Type: "TXT", TTL: 300, Records: []string{Quote(strings.Repeat("a", 300))}},
+		{Name: "@", Type: "CAA", TTL: 300, Records: []string{`0 issue "letsencrypt.org"`}},
+	}
+
+	rendered := Render("example.com", input)
+	if !strings.HasPrefix(rendered, "$ORIGIN example.com.\n@\t") {
+		t.Fatalf("expected the zone file to start with the origin and the apex records but got %q", rendered)
+	}
+
+	actual, err := Parse(rendered, "example.com", 3600)
+	if err != nil {
+		t.Fatalf("parsing the rendered zone file: %+v", err)
+	}
+	if !Equal(input, actual) {
+		t.Fatalf("expected %+v but got %+v", input, actual)
+	}
+}
+
+func TestNormalise(t *testing.T) {
+	actual, err := Normalise(RecordSet{
+		Name:    "Mail.example.com.",
+		Type:    "mx",
+		TTL:     300,
+		Records: []string{"20 backup", "10 mail.example.com."},
+	}, "example.com")
+	if err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+
+	expected := &RecordSet{
+		Name:    "mail",
+		Type:    "MX",
+		TTL:     300,
+		Records: []string{"10 mail.example.com.", "20 backup.example.com."},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+
+	if _, err := Normalise(RecordSet{Name: "www", Type: "A", Records: []string{"10.0.0.1 10.0.0.2"}}, "example.com"); err == nil {
+		t.Fatalf("expected an error for a record with too many fields")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	existing := []RecordSet{
+		{Name: "@", Type: "NS", TTL: 172800, Records: []string{"ns1-01.azure-dns.com."}},
+		{Name: "@", Type: "SOA", TTL: 3600, Records: []string{"ns1-01.azure-dns.com. azuredns-hostmaster.microsoft.com. 1 3600 300 2419200 300"}},
+		{Name: "unchanged", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+		{Name: "changed", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+		{Name: "removed", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+		{Name: "unmanaged", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+	}
+	desired := []RecordSet{
+		{Name: "@", Type: "NS", TTL: 3600, Records: []string{"ns1.example.org."}},
+		{Name: "unchanged", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+		{Name: "changed", Type: "A", TTL: 600, Records: []string{"10.0.0.1"}},
+		{Name: "added", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+	}
+	previouslyManaged := []string{"unchanged/A", "changed/A", "removed/A", "gone/A"}
+
+	keys := func(input []RecordSet) []string {
+		results := make([]string, 0)
+		for _, v := range input {
+			results = append(results, v.Key())
+		}
+		return results
+	}
+
+	additive := Diff(existing, desired, previouslyManaged, false)
+	if expected := []string{"added/A", "changed/A"}; !reflect.DeepEqual(keys(additive.Upserts), expected) {
+		t.Fatalf("expected the upserts to be %+v but got %+v", expected, keys(additive.Upserts))
+	}
+	if expected := []string{"removed/A"}; !reflect.DeepEqual(keys(additive.Deletes), expected) {
+		t.Fatalf("expected the deletes to be %+v but got %+v", expected, keys(additive.Deletes))
+	}
+
+	authoritative := Diff(existing, desired, previouslyManaged, true)
+	if expected := []string{"added/A", "changed/A"}; !reflect.DeepEqual(keys(authoritative.Upserts), expected) {
+		t.Fatalf("expected the upserts to be %+v but got %+v", expected, keys(authoritative.Upserts))
+	}
+	if expected := []string{"removed/A", "unmanaged/A"}; !reflect.DeepEqual(keys(authoritative.Deletes), expected) {
+		t.Fatalf("expected the deletes to be %+v but got %+v", expected, keys(authoritative.Deletes))
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	existing := []RecordSet{
+		{Name: "@", Type: "NS", TTL: 172800, Records: []string{"ns1-01.azure-dns.com."}},
+		{Name: "@", Type: "SOA", TTL: 3600, Records: []string{"ns1-01.azure-dns.com. azuredns-hostmaster.microsoft.com. 1 3600 300 2419200 300"}},
+		{Name: "www", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+		{Name: "unmanaged", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+	}
+	desired := []RecordSet{
+		{Name: "@", Type: "NS", TTL: 3600, Records: []string{"ns1.example.org."}},
+		{Name: "www", Type: "A", TTL: 600, Records: []string{"10.0.0.2"}},
+		{Name: "added", Type: "A", TTL: 300, Records: []string{"10.0.0.1"}},
+	}
+
+	if actual, expected := keys(Conflicts(existing, desired, false)), []string{"www/A"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the additive conflicts to be %+v but got %+v", expected, actual)
+	}
+	if actual, expected := keys(Conflicts(existing, desired, true)), []string{"unmanaged/A", "www/A"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the authoritative conflicts to be %+v but got %+v", expected, actual)
+	}
+	if actual := Conflicts(existing[0:2], desired, true); len(actual) != 0 {
+		t.Fatalf("expected the Record Sets managed by the zone not to conflict but got %+v", actual)
+	}
+}
+
+func TestParseRecordSetsID(t *testing.T) {
+	testData := []struct {
+		Name   string
+		Input  string
+		Error  bool
+		Expect string
+	}{
+		{
+			Name:  "Empty",
+			Input: "",
+			Error: true,
+		},
+		{
+			Name:  "Missing Suffix",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/dnsZones/zone1",
+			Error: true,
+		},
+		{
+			Name:  "Only Suffix",
+			Input: "/recordSets",
+			Error: true,
+		},
+		{
+			Name:   "Valid",
+			Input:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/dnsZones/zone1/recordSets",
+			Expect: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/dnsZones/zone1",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := ParseRecordSetsID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual != v.Expect {
+			t.Fatalf("Expected %q but got %q", v.Expect, actual)
+		}
+	}
+}
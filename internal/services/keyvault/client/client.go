@@ -1,7 +1,10 @@
 package client
 
 import (
+	"fmt"
+
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2021-10-01/keyvault" // nolint: staticcheck
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	keyvaultmgmt "github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
 )
@@ -11,6 +14,8 @@ type Client struct {
 	ManagementClient *keyvaultmgmt.BaseClient
 	VaultsClient     *keyvault.VaultsClient
 	options          *common.ClientOptions
+
+	managedHSMAuthorizer autorest.Authorizer
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -28,6 +33,8 @@ func NewClient(o *common.ClientOptions) *Client {
 		ManagementClient: &managementClient,
 		VaultsClient:     &vaultsClient,
 		options:          o,
+
+		managedHSMAuthorizer: o.ManagedHSMAuthorizer,
 	}
 }
 
@@ -36,3 +43,41 @@ func (c Client) KeyVaultClientForSubscription(subscriptionId string) *keyvault.V
 	c.options.ConfigureClient(&vaultsClient.Client, c.options.ResourceManagerAuthorizer)
 	return &vaultsClient
 }
+
+// ManagedHSMDataPlaneClient returns a client for the Keys within a Managed HSM, which (unlike Key Vaults)
+// requires a token scoped to the Managed HSM endpoint
+func (c Client) ManagedHSMDataPlaneClient() (*keyvaultmgmt.BaseClient, error) {
+	if c.managedHSMAuthorizer == nil {
+		return nil, fmt.Errorf("Managed HSM is not supported in this Azure Environment")
+	}
+	client := keyvaultmgmt.New()
+	c.options.ConfigureClient(&client.Client, c.managedHSMAuthorizer)
+	return &client, nil
+}
+
+func (c Client) ManagedHSMSecurityDomainClient() (*keyvaultmgmt.HSMSecurityDomainClient, error) {
+	if c.managedHSMAuthorizer == nil {
+		return nil, fmt.Errorf("Managed HSM is not supported in this Azure Environment")
+	}
+	client := keyvaultmgmt.NewHSMSecurityDomainClient()
+	c.options.ConfigureClient(&client.Client, c.managedHSMAuthorizer)
+	return &client, nil
+}
+
+func (c Client) ManagedHSMRoleAssignmentsClient() (*keyvaultmgmt.RoleAssignmentsClient, error) {
+	if c.managedHSMAuthorizer == nil {
+		return nil, fmt.Errorf("Managed HSM is not supported in this Azure Environment")
+	}
+	client := keyvaultmgmt.NewRoleAssignmentsClient()
+	c.options.ConfigureClient(&client.Client, c.managedHSMAuthorizer)
+	return &client, nil
+}
+
+func (c Client) ManagedHSMRoleDefinitionsClient() (*keyvaultmgmt.RoleDefinitionsClient, error) {
+	if c.managedHSMAuthorizer == nil {
+		return nil, fmt.Errorf("Managed HSM is not supported in this Azure Environment")
+	}
+	client := keyvaultmgmt.NewRoleDefinitionsClient()
+	c.options.ConfigureClient(&client.Client, c.managedHSMAuthorizer)
+	return &client, nil
+}
@@ -5,19 +5,25 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/cache"
 	resourcesClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
-var (
-	keyVaultsCache = map[string]keyVaultDetails{}
-	keysmith       = &sync.RWMutex{}
-	lock           = map[string]*sync.RWMutex{}
+const (
+	// keyVaultCacheTTL is how long the details of a Key Vault are cached for before they're looked up
+	// again, so that Key Vaults which have been deleted and recreated outside of Terraform are picked up
+	keyVaultCacheTTL = 10 * time.Minute
+
+	// keyVaultCacheMaxEntries is the maximum number of Key Vaults which are cached at any one time
+	keyVaultCacheMaxEntries = 1000
 )
 
+var keyVaultsCache = cache.New[keyVaultDetails](keyVaultCacheTTL, keyVaultCacheMaxEntries)
+
 type keyVaultDetails struct {
 	keyVaultId       string
 	dataPlaneBaseUri string
@@ -26,26 +32,19 @@ type keyVaultDetails struct {
 
 func (c *Client) AddToCache(keyVaultId commonids.KeyVaultId, dataPlaneUri string) {
 	cacheKey := c.cacheKeyForKeyVault(keyVaultId.VaultName)
-	keysmith.Lock()
-	keyVaultsCache[cacheKey] = keyVaultDetails{
+	keyVaultsCache.Set(cacheKey, keyVaultDetails{
 		keyVaultId:       keyVaultId.ID(),
 		dataPlaneBaseUri: dataPlaneUri,
 		resourceGroup:    keyVaultId.ResourceGroupName,
-	}
-	keysmith.Unlock()
+	})
 }
 
 func (c *Client) BaseUriForKeyVault(ctx context.Context, keyVaultId commonids.KeyVaultId) (*string, error) {
 	cacheKey := c.cacheKeyForKeyVault(keyVaultId.VaultName)
-	keysmith.Lock()
-	if lock[cacheKey] == nil {
-		lock[cacheKey] = &sync.RWMutex{}
-	}
-	keysmith.Unlock()
-	lock[cacheKey].Lock()
-	defer lock[cacheKey].Unlock()
+	unlock := keyVaultsCache.Lock(cacheKey)
+	defer unlock()
 
-	if v, ok := keyVaultsCache[cacheKey]; ok {
+	if v, ok := c.cachedKeyVault(keyVaultId); ok {
 		return &v.dataPlaneBaseUri, nil
 	}
 
@@ -58,6 +57,7 @@ func (c *Client) BaseUriForKeyVault(ctx context.Context, keyVaultId commonids.Ke
 	resp, err := vaultsClient.Get(ctx, keyVaultId.ResourceGroupName, keyVaultId.VaultName)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
+			keyVaultsCache.Delete(cacheKey)
 			return nil, fmt.Errorf("%s was not found", keyVaultId)
 		}
 		return nil, fmt.Errorf("retrieving %s: %+v", keyVaultId, err)
@@ -74,21 +74,17 @@ func (c *Client) BaseUriForKeyVault(ctx context.Context, keyVaultId commonids.Ke
 
 func (c *Client) Exists(ctx context.Context, keyVaultId commonids.KeyVaultId) (bool, error) {
 	cacheKey := c.cacheKeyForKeyVault(keyVaultId.VaultName)
-	keysmith.Lock()
-	if lock[cacheKey] == nil {
-		lock[cacheKey] = &sync.RWMutex{}
-	}
-	keysmith.Unlock()
-	lock[cacheKey].Lock()
-	defer lock[cacheKey].Unlock()
+	unlock := keyVaultsCache.Lock(cacheKey)
+	defer unlock()
 
-	if _, ok := keyVaultsCache[cacheKey]; ok {
+	if _, ok := c.cachedKeyVault(keyVaultId); ok {
 		return true, nil
 	}
 
 	resp, err := c.VaultsClient.Get(ctx, keyVaultId.ResourceGroupName, keyVaultId.VaultName)
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
+			keyVaultsCache.Delete(cacheKey)
 			return false, nil
 		}
 		return false, fmt.Errorf("retrieving %s: %+v", keyVaultId, err)
@@ -110,15 +106,10 @@ func (c *Client) KeyVaultIDFromBaseUrl(ctx context.Context, resourcesClient *res
 	}
 
 	cacheKey := c.cacheKeyForKeyVault(*keyVaultName)
-	keysmith.Lock()
-	if lock[cacheKey] == nil {
-		lock[cacheKey] = &sync.RWMutex{}
-	}
-	keysmith.Unlock()
-	lock[cacheKey].Lock()
-	defer lock[cacheKey].Unlock()
+	unlock := keyVaultsCache.Lock(cacheKey)
+	defer unlock()
 
-	if v, ok := keyVaultsCache[cacheKey]; ok {
+	if v, ok := keyVaultsCache.Get(cacheKey); ok {
 		return &v.keyVaultId, nil
 	}
 
@@ -163,16 +154,24 @@ func (c *Client) KeyVaultIDFromBaseUrl(ctx context.Context, resourcesClient *res
 	return nil, nil
 }
 
+// Purge removes the specified Key Vault from the cache, and should be called when the Key Vault is deleted
 func (c *Client) Purge(keyVaultId commonids.KeyVaultId) {
 	cacheKey := c.cacheKeyForKeyVault(keyVaultId.VaultName)
-	keysmith.Lock()
-	if lock[cacheKey] == nil {
-		lock[cacheKey] = &sync.RWMutex{}
+	unlock := keyVaultsCache.Lock(cacheKey)
+	keyVaultsCache.Delete(cacheKey)
+	unlock()
+}
+
+// cachedKeyVault returns the cached details for the specified Key Vault. Since Key Vault names are globally
+// unique but a Key Vault can be deleted and recreated in another Resource Group or Subscription, the cached
+// details are only used when they're for the same Resource ID.
+func (c *Client) cachedKeyVault(keyVaultId commonids.KeyVaultId) (*keyVaultDetails, bool) {
+	v, ok := keyVaultsCache.Get(c.cacheKeyForKeyVault(keyVaultId.VaultName))
+	if !ok || !strings.EqualFold(v.keyVaultId, keyVaultId.ID()) {
+		return nil, false
 	}
-	keysmith.Unlock()
-	lock[cacheKey].Lock()
-	delete(keyVaultsCache, cacheKey)
-	lock[cacheKey].Unlock()
+
+	return &v, true
 }
 
 func (c *Client) cacheKeyForKeyVault(name string) string {
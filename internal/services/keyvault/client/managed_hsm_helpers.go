@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/cache"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	resourcesClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// managedHSMsCache is a cache of the Resource IDs of Managed HSMs, keyed by the (lower-cased) name of the Managed HSM
+var managedHSMsCache = cache.New[string](keyVaultCacheTTL, keyVaultCacheMaxEntries)
+
+// BaseUriForManagedHSM returns the Data Plane URI for the specified Managed HSM
+func (c *Client) BaseUriForManagedHSM(ctx context.Context, managedHSMId parse.ManagedHSMId) (*string, error) {
+	resp, err := c.ManagedHsmClient.Get(ctx, managedHSMId.ResourceGroup, managedHSMId.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil, fmt.Errorf("%s was not found", managedHSMId)
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", managedHSMId, err)
+	}
+
+	if resp.Properties == nil || resp.Properties.HsmURI == nil {
+		return nil, fmt.Errorf("retrieving %s: `properties.HsmUri` was nil", managedHSMId)
+	}
+
+	return resp.Properties.HsmURI, nil
+}
+
+// ManagedHSMExists returns whether the specified Managed HSM exists, so that the Data Plane items within it can be
+// removed from the state when the Managed HSM has been deleted
+func (c *Client) ManagedHSMExists(ctx context.Context, managedHSMId parse.ManagedHSMId) (bool, error) {
+	resp, err := c.ManagedHsmClient.Get(ctx, managedHSMId.ResourceGroup, managedHSMId.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return false, nil
+		}
+		return false, fmt.Errorf("retrieving %s: %+v", managedHSMId, err)
+	}
+
+	return true, nil
+}
+
+// ManagedHSMIDFromBaseUrl returns the Resource ID of the Managed HSM with the specified Data Plane URI, which is
+// looked up using a filtered query for Managed HSMs with that name within the current Subscription. Since this is
+// only required when the Resource ID of the Managed HSM isn't known (e.g. when importing), an error is returned when
+// the Managed HSM can't be found, rather than assuming it's been deleted.
+func (c *Client) ManagedHSMIDFromBaseUrl(ctx context.Context, resourcesClient *resourcesClient.Client, managedHSMBaseUrl string) (*parse.ManagedHSMId, error) {
+	name, err := parseManagedHSMNameFromBaseUrl(managedHSMBaseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := strings.ToLower(*name)
+	unlock := managedHSMsCache.Lock(cacheKey)
+	defer unlock()
+
+	if v, ok := managedHSMsCache.Get(cacheKey); ok {
+		return parse.ManagedHSMID(v)
+	}
+
+	filter := fmt.Sprintf("resourceType eq 'Microsoft.KeyVault/managedHSMs' and name eq '%s'", *name)
+	result, err := resourcesClient.ResourcesClient.List(ctx, filter, "", utils.Int32(5))
+	if err != nil {
+		return nil, fmt.Errorf("listing resources matching %q: %+v", filter, err)
+	}
+
+	for result.NotDone() {
+		for _, v := range result.Values() {
+			if v.ID == nil {
+				continue
+			}
+
+			id, err := parse.ManagedHSMID(*v.ID)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %q: %+v", *v.ID, err)
+			}
+			if !strings.EqualFold(id.Name, *name) {
+				continue
+			}
+
+			managedHSMsCache.Set(cacheKey, id.ID())
+			return id, nil
+		}
+
+		if err := result.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("iterating over results: %+v", err)
+		}
+	}
+
+	return nil, fmt.Errorf("unable to find a Managed HSM named %q within Subscription %q", *name, c.ManagedHsmClient.SubscriptionID)
+}
+
+func parseManagedHSMNameFromBaseUrl(input string) (*string, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// https://the-hsm.managedhsm.azure.net
+	// https://the-hsm.managedhsm.usgovcloudapi.net
+	// https://the-hsm.managedhsm.azure.cn
+
+	segments := strings.Split(uri.Host, ".")
+	if len(segments) < 3 || segments[1] != "managedhsm" {
+		return nil, fmt.Errorf("expected a URI in the format `the-managed-hsm-name.managedhsm.**` but got %q", uri.Host)
+	}
+	return &segments[0], nil
+}
@@ -148,3 +148,20 @@ func nestedItemResourceImporter(ctx context.Context, d *pluginsdk.ResourceData,
 
 	return []*pluginsdk.ResourceData{d}, nil
 }
+
+func managedHSMNestedItemResourceImporter(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	resourcesClient := meta.(*clients.Client).Resource
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return []*pluginsdk.ResourceData{d}, fmt.Errorf("parsing ID %q for Managed HSM Child import: %v", d.Id(), err)
+	}
+
+	managedHSMId, err := keyVaultsClient.ManagedHSMIDFromBaseUrl(ctx, resourcesClient, id.KeyVaultBaseUrl)
+	if err != nil {
+		return []*pluginsdk.ResourceData{d}, fmt.Errorf("retrieving the Resource ID the Managed HSM at URL %q: %s", id.KeyVaultBaseUrl, err)
+	}
+	d.Set("managed_hsm_id", managedHSMId.ID())
+
+	return []*pluginsdk.ResourceData{d}, nil
+}
@@ -0,0 +1,148 @@
+package keyvault
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// buildKeyVaultCertificatePemBundle validates that the PEM encoded certificate chain is ordered from the leaf
+// certificate through to the root (or the last intermediate), and that the private key matches the leaf
+// certificate - returning the bundle of the private key and certificates in the format expected by Key Vault
+func buildKeyVaultCertificatePemBundle(certificateChain, privateKey string) (string, error) {
+	certificates, err := parseKeyVaultCertificatePemChain(certificateChain)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := parseKeyVaultCertificatePemPrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateKeyVaultCertificateChainOrder(certificates); err != nil {
+		return "", err
+	}
+
+	if !keyVaultCertificatePublicKeysMatch(certificates[0].PublicKey, key) {
+		return "", fmt.Errorf("the private key doesn't match the public key of the first (leaf) certificate %q", certificates[0].Subject.String())
+	}
+
+	// Key Vault requires an unencrypted PKCS#8 private key
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("encoding the private key as PKCS#8: %+v", err)
+	}
+
+	buf := bytes.Buffer{}
+	if err := pem.Encode(&buf, &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}); err != nil {
+		return "", fmt.Errorf("encoding the private key: %+v", err)
+	}
+	for _, certificate := range certificates {
+		if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}); err != nil {
+			return "", fmt.Errorf("encoding certificate %q: %+v", certificate.Subject.String(), err)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+func parseKeyVaultCertificatePemChain(input string) ([]*x509.Certificate, error) {
+	certificates := make([]*x509.Certificate, 0)
+
+	rest := []byte(input)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("expected only `CERTIFICATE` PEM blocks within the certificate chain but got %q - the private key should be specified separately", block.Type)
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate %d within the certificate chain: %+v", len(certificates)+1, err)
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, fmt.Errorf("the certificate chain contains data which isn't PEM encoded")
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificates were found within the certificate chain")
+	}
+
+	return certificates, nil
+}
+
+func parseKeyVaultCertificatePemPrivateKey(input string) (crypto.Signer, error) {
+	block, rest := pem.Decode([]byte(input))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key was found")
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, fmt.Errorf("expected a single PEM encoded private key")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		return nil, fmt.Errorf("encrypted private keys aren't supported - the private key must be unencrypted")
+	default:
+		return nil, fmt.Errorf("expected a `PRIVATE KEY`, `RSA PRIVATE KEY` or `EC PRIVATE KEY` PEM block but got %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing the private key: %+v", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return signer, nil
+}
+
+// validateKeyVaultCertificateChainOrder checks that each certificate was issued by the certificate which follows it
+func validateKeyVaultCertificateChainOrder(certificates []*x509.Certificate) error {
+	for i := 0; i < len(certificates)-1; i++ {
+		certificate := certificates[i]
+		issuer := certificates[i+1]
+
+		if err := certificate.CheckSignatureFrom(issuer); err != nil {
+			return fmt.Errorf("certificate %d (%q) wasn't issued by certificate %d (%q) - the certificates must be ordered from the leaf certificate through to the root: %+v", i+1, certificate.Subject.String(), i+2, issuer.Subject.String(), err)
+		}
+	}
+
+	return nil
+}
+
+func keyVaultCertificatePublicKeysMatch(publicKey interface{}, privateKey crypto.Signer) bool {
+	switch v := privateKey.Public().(type) {
+	case *rsa.PublicKey:
+		return v.Equal(publicKey)
+	case *ecdsa.PublicKey:
+		return v.Equal(publicKey)
+	case ed25519.PublicKey:
+		return v.Equal(publicKey)
+	}
+
+	return false
+}
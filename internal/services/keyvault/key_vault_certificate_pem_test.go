@@ -0,0 +1,185 @@
+package keyvault
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testKeyVaultCertificate struct {
+	certificate *x509.Certificate
+	key         crypto.Signer
+}
+
+func (c testKeyVaultCertificate) pem() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.certificate.Raw}))
+}
+
+func newTestKeyVaultCertificate(t *testing.T, commonName string, isCA bool, parent *testKeyVaultCertificate) testKeyVaultCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %+v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign
+	}
+
+	issuer := template
+	var issuerKey crypto.Signer = key
+	if parent != nil {
+		issuer = parent.certificate
+		issuerKey = parent.key
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatalf("creating certificate %q: %+v", commonName, err)
+	}
+	certificate, err := x509.ParseCertificate(raw)
+	if err != nil {
+		t.Fatalf("parsing certificate %q: %+v", commonName, err)
+	}
+
+	return testKeyVaultCertificate{
+		certificate: certificate,
+		key:         key,
+	}
+}
+
+func TestBuildKeyVaultCertificatePemBundle(t *testing.T) {
+	root := newTestKeyVaultCertificate(t, "root", true, nil)
+	intermediate := newTestKeyVaultCertificate(t, "intermediate", true, &root)
+	leaf := newTestKeyVaultCertificate(t, "leaf", false, &intermediate)
+
+	ecKey, err := x509.MarshalECPrivateKey(leaf.key.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatalf("marshalling key: %+v", err)
+	}
+	ecKeyPem := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecKey}))
+
+	pkcs8Key, err := x509.MarshalPKCS8PrivateKey(leaf.key)
+	if err != nil {
+		t.Fatalf("marshalling key: %+v", err)
+	}
+	pkcs8KeyPem := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Key}))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %+v", err)
+	}
+	rsaKeyPem := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+
+	testData := []struct {
+		name          string
+		chain         string
+		privateKey    string
+		expectedError string
+	}{
+		{
+			name:       "leaf only",
+			chain:      leaf.pem(),
+			privateKey: pkcs8KeyPem,
+		},
+		{
+			name:       "ordered chain",
+			chain:      leaf.pem() + intermediate.pem() + root.pem(),
+			privateKey: ecKeyPem,
+		},
+		{
+			name:       "ordered chain without the root",
+			chain:      leaf.pem() + intermediate.pem(),
+			privateKey: pkcs8KeyPem,
+		},
+		{
+			name:          "unordered chain",
+			chain:         intermediate.pem() + leaf.pem() + root.pem(),
+			privateKey:    pkcs8KeyPem,
+			expectedError: "must be ordered from the leaf certificate",
+		},
+		{
+			name:          "missing intermediate",
+			chain:         leaf.pem() + root.pem(),
+			privateKey:    pkcs8KeyPem,
+			expectedError: "must be ordered from the leaf certificate",
+		},
+		{
+			name:          "mismatched private key",
+			chain:         leaf.pem() + intermediate.pem(),
+			privateKey:    rsaKeyPem,
+			expectedError: "doesn't match the public key",
+		},
+		{
+			name:          "private key within the chain",
+			chain:         pkcs8KeyPem + leaf.pem(),
+			privateKey:    pkcs8KeyPem,
+			expectedError: "expected only `CERTIFICATE` PEM blocks",
+		},
+		{
+			name:          "empty chain",
+			chain:         "",
+			privateKey:    pkcs8KeyPem,
+			expectedError: "no PEM encoded certificates",
+		},
+		{
+			name:          "chain containing other data",
+			chain:         leaf.pem() + "hello world",
+			privateKey:    pkcs8KeyPem,
+			expectedError: "isn't PEM encoded",
+		},
+		{
+			name:          "encrypted private key",
+			chain:         leaf.pem(),
+			privateKey:    string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("encrypted")})),
+			expectedError: "encrypted private keys aren't supported",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		bundle, err := buildKeyVaultCertificatePemBundle(v.chain, v.privateKey)
+		if v.expectedError != "" {
+			if err == nil {
+				t.Fatalf("expected an error containing %q but got none", v.expectedError)
+			}
+			if !strings.Contains(err.Error(), v.expectedError) {
+				t.Fatalf("expected an error containing %q but got %q", v.expectedError, err.Error())
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		// the bundle should contain the private key (as PKCS#8) followed by the certificates in order
+		block, rest := pem.Decode([]byte(bundle))
+		if block == nil || block.Type != "PRIVATE KEY" {
+			t.Fatalf("expected the bundle to start with a `PRIVATE KEY` block")
+		}
+		certificates, err := parseKeyVaultCertificatePemChain(string(rest))
+		if err != nil {
+			t.Fatalf("parsing the certificates within the bundle: %+v", err)
+		}
+		if !certificates[0].Equal(leaf.certificate) {
+			t.Fatalf("expected the first certificate within the bundle to be the leaf certificate")
+		}
+	}
+}
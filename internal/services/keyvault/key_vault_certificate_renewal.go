@@ -0,0 +1,147 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+const (
+	keyVaultCertificateRenewalStatusInProgress = "InProgress"
+	keyVaultCertificateRenewalStatusManual     = "Manual"
+)
+
+// flattenKeyVaultCertificateRenewal returns the renewal status of the Certificate, and the date at which it's
+// next due to be renewed (or the Certificate Contacts are due to be emailed) as defined by the lifetime actions
+func flattenKeyVaultCertificateRenewal(policy *keyvault.CertificatePolicy, attributes *keyvault.CertificateAttributes, pendingOperation *keyvault.CertificateOperation) (string, string) {
+	if pendingOperation != nil && pendingOperation.Status != nil && strings.EqualFold(*pendingOperation.Status, "inProgress") {
+		return keyVaultCertificateRenewalStatusInProgress, ""
+	}
+
+	if policy == nil || policy.LifetimeActions == nil {
+		return keyVaultCertificateRenewalStatusManual, ""
+	}
+
+	// when both are defined, the Certificate is renewed automatically
+	var lifetimeAction *keyvault.LifetimeAction
+	for _, v := range *policy.LifetimeActions {
+		action := v
+		if action.Action == nil {
+			continue
+		}
+
+		if action.Action.ActionType == keyvault.CertificatePolicyActionAutoRenew {
+			lifetimeAction = &action
+			break
+		}
+		if action.Action.ActionType == keyvault.CertificatePolicyActionEmailContacts && lifetimeAction == nil {
+			lifetimeAction = &action
+		}
+	}
+	if lifetimeAction == nil {
+		return keyVaultCertificateRenewalStatusManual, ""
+	}
+
+	// the status is the Action Type, either `AutoRenew` or `EmailContacts`
+	status := string(lifetimeAction.Action.ActionType)
+	if lifetimeAction.Trigger == nil || attributes == nil || attributes.Expires == nil {
+		return status, ""
+	}
+
+	expires := time.Time(*attributes.Expires)
+	if days := lifetimeAction.Trigger.DaysBeforeExpiry; days != nil {
+		return status, expires.AddDate(0, 0, -int(*days)).Format(time.RFC3339)
+	}
+
+	if percentage := lifetimeAction.Trigger.LifetimePercentage; percentage != nil && attributes.NotBefore != nil {
+		notBefore := time.Time(*attributes.NotBefore)
+		lifetime := expires.Sub(notBefore)
+		return status, notBefore.Add(lifetime / 100 * time.Duration(*percentage)).Format(time.RFC3339)
+	}
+
+	return status, ""
+}
+
+// renewKeyVaultCertificate requests a new version of the Certificate using its existing policy, waiting for the
+// resulting Certificate Operation to complete before returning the ID of the new version
+func renewKeyVaultCertificate(ctx context.Context, client *keyvault.BaseClient, id parse.NestedItemId, timeout time.Duration) (*parse.NestedItemId, error) {
+	existing, err := client.GetCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Key Vault Certificate %q: %+v", id.Name, err)
+	}
+
+	if existing.Policy == nil || existing.Policy.IssuerParameters == nil || existing.Policy.IssuerParameters.Name == nil || strings.EqualFold(*existing.Policy.IssuerParameters.Name, "unknown") {
+		return nil, fmt.Errorf("Key Vault Certificate %q can't be renewed since it's not issued by Key Vault (the Issuer is `Unknown`) - a new version of the Certificate must be imported instead", id.Name)
+	}
+
+	parameters := keyvault.CertificateCreateParameters{
+		CertificatePolicy: existing.Policy,
+		Tags:              existing.Tags,
+	}
+	log.Printf("[DEBUG] Renewing Key Vault Certificate %q in Vault %q", id.Name, id.KeyVaultBaseUrl)
+	if _, err := client.CreateCertificate(ctx, id.KeyVaultBaseUrl, id.Name, parameters); err != nil {
+		return nil, fmt.Errorf("renewing Key Vault Certificate %q: %+v", id.Name, err)
+	}
+
+	log.Printf("[DEBUG] Waiting for the renewal of Key Vault Certificate %q in Vault %q to complete", id.Name, id.KeyVaultBaseUrl)
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending:    []string{"inProgress"},
+		Target:     []string{"completed"},
+		Refresh:    keyVaultCertificateOperationRefreshFunc(ctx, client, id.KeyVaultBaseUrl, id.Name),
+		MinTimeout: 15 * time.Second,
+		Timeout:    timeout,
+	}
+	// as with creation, non-Self issuers can take some time to process the request
+	if !strings.EqualFold(*existing.Policy.IssuerParameters.Name, "self") {
+		stateConf.PollInterval = 30 * time.Second
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return nil, fmt.Errorf("waiting for the renewal of Key Vault Certificate %q to complete: %+v", id.Name, err)
+	}
+
+	renewed, err := client.GetCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Key Vault Certificate %q: %+v", id.Name, err)
+	}
+	if renewed.ID == nil {
+		return nil, fmt.Errorf("retrieving Key Vault Certificate %q: `id` was nil", id.Name)
+	}
+
+	return parse.ParseNestedItemID(*renewed.ID)
+}
+
+func keyVaultCertificateOperationRefreshFunc(ctx context.Context, client *keyvault.BaseClient, keyVaultBaseUrl string, name string) pluginsdk.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		operation, err := client.GetCertificateOperation(ctx, keyVaultBaseUrl, name)
+		if err != nil {
+			return nil, "", fmt.Errorf("retrieving the Pending Operation for Certificate %q in Vault %q: %+v", name, keyVaultBaseUrl, err)
+		}
+
+		status := ""
+		if operation.Status != nil {
+			status = *operation.Status
+		}
+
+		switch strings.ToLower(status) {
+		case "inprogress":
+			return operation, "inProgress", nil
+		case "completed":
+			return operation, "completed", nil
+		}
+
+		details := ""
+		if operation.StatusDetails != nil {
+			details = *operation.StatusDetails
+		}
+		if operation.Error != nil && operation.Error.Message != nil {
+			details = fmt.Sprintf("%s: %s", details, *operation.Error.Message)
+		}
+		return nil, "", fmt.Errorf("the Pending Operation for Certificate %q in Vault %q has the status %q: %s", name, keyVaultBaseUrl, status, details)
+	}
+}
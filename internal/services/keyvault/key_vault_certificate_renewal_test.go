@@ -0,0 +1,95 @@
+package keyvault
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+func TestFlattenKeyVaultCertificateRenewal(t *testing.T) {
+	notBefore := date.UnixTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	expires := date.UnixTime(time.Date(2022, 12, 27, 0, 0, 0, 0, time.UTC))
+	attributes := &keyvault.CertificateAttributes{
+		NotBefore: &notBefore,
+		Expires:   &expires,
+	}
+
+	policyWithActions := func(actions ...keyvault.LifetimeAction) *keyvault.CertificatePolicy {
+		return &keyvault.CertificatePolicy{
+			LifetimeActions: &actions,
+		}
+	}
+	action := func(actionType keyvault.CertificatePolicyAction, trigger keyvault.Trigger) keyvault.LifetimeAction {
+		return keyvault.LifetimeAction{
+			Action:  &keyvault.Action{ActionType: actionType},
+			Trigger: &trigger,
+		}
+	}
+
+	testData := []struct {
+		name             string
+		policy           *keyvault.CertificatePolicy
+		pendingOperation *keyvault.CertificateOperation
+		expectedStatus   string
+		expectedDate     string
+	}{
+		{
+			name:           "no policy",
+			expectedStatus: "Manual",
+		},
+		{
+			name:           "no lifetime actions",
+			policy:         policyWithActions(),
+			expectedStatus: "Manual",
+		},
+		{
+			name:           "auto renew days before expiry",
+			policy:         policyWithActions(action(keyvault.CertificatePolicyActionAutoRenew, keyvault.Trigger{DaysBeforeExpiry: utils.Int32(30)})),
+			expectedStatus: "AutoRenew",
+			expectedDate:   "2022-11-27T00:00:00Z",
+		},
+		{
+			name:           "email contacts lifetime percentage",
+			policy:         policyWithActions(action(keyvault.CertificatePolicyActionEmailContacts, keyvault.Trigger{LifetimePercentage: utils.Int32(50)})),
+			expectedStatus: "EmailContacts",
+			expectedDate:   "2022-06-30T00:00:00Z",
+		},
+		{
+			name: "auto renew takes precedence",
+			policy: policyWithActions(
+				action(keyvault.CertificatePolicyActionEmailContacts, keyvault.Trigger{DaysBeforeExpiry: utils.Int32(60)}),
+				action(keyvault.CertificatePolicyActionAutoRenew, keyvault.Trigger{DaysBeforeExpiry: utils.Int32(30)}),
+			),
+			expectedStatus: "AutoRenew",
+			expectedDate:   "2022-11-27T00:00:00Z",
+		},
+		{
+			name:             "pending operation",
+			policy:           policyWithActions(action(keyvault.CertificatePolicyActionAutoRenew, keyvault.Trigger{DaysBeforeExpiry: utils.Int32(30)})),
+			pendingOperation: &keyvault.CertificateOperation{Status: utils.String("inProgress")},
+			expectedStatus:   "InProgress",
+		},
+		{
+			name:             "completed operation",
+			policy:           policyWithActions(action(keyvault.CertificatePolicyActionAutoRenew, keyvault.Trigger{DaysBeforeExpiry: utils.Int32(30)})),
+			pendingOperation: &keyvault.CertificateOperation{Status: utils.String("completed")},
+			expectedStatus:   "AutoRenew",
+			expectedDate:     "2022-11-27T00:00:00Z",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		status, nextRenewalDate := flattenKeyVaultCertificateRenewal(v.policy, attributes, v.pendingOperation)
+		if status != v.expectedStatus {
+			t.Fatalf("expected the status to be %q but got %q", v.expectedStatus, status)
+		}
+		if nextRenewalDate != v.expectedDate {
+			t.Fatalf("expected the next renewal date to be %q but got %q", v.expectedDate, nextRenewalDate)
+		}
+	}
+}
@@ -71,19 +71,47 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 					Schema: map[string]*pluginsdk.Schema{
 						"contents": {
 							Type:         pluginsdk.TypeString,
-							Required:     true,
+							Optional:     true,
 							Sensitive:    true,
 							ValidateFunc: validation.StringIsNotEmpty,
+							ExactlyOneOf: []string{
+								"certificate.0.contents",
+								"certificate.0.pem_certificate",
+							},
 						},
 						"password": {
-							Type:      pluginsdk.TypeString,
-							Optional:  true,
-							Sensitive: true,
+							Type:          pluginsdk.TypeString,
+							Optional:      true,
+							Sensitive:     true,
+							ConflictsWith: []string{"certificate.0.pem_certificate"},
+						},
+						"pem_certificate": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							ExactlyOneOf: []string{
+								"certificate.0.contents",
+								"certificate.0.pem_certificate",
+							},
+							RequiredWith: []string{"certificate.0.pem_private_key"},
+						},
+						"pem_private_key": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							RequiredWith: []string{"certificate.0.pem_certificate"},
 						},
 					},
 				},
 			},
 
+			"renewal_trigger": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
 			"certificate_policy": {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
@@ -418,11 +446,47 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"renewal_status": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"next_renewal_date": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"tags": tags.Schema(),
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceKeyVaultCertificateCustomizeDiff),
 	}
 }
 
+func resourceKeyVaultCertificateCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	// validate the PEM encoded certificate chain and private key at plan time, where they're known
+	if d.NewValueKnown("certificate.0.pem_certificate") && d.NewValueKnown("certificate.0.pem_private_key") {
+		pemCertificate := d.Get("certificate.0.pem_certificate").(string)
+		pemPrivateKey := d.Get("certificate.0.pem_private_key").(string)
+		if pemCertificate != "" && pemPrivateKey != "" {
+			if _, err := buildKeyVaultCertificatePemBundle(pemCertificate, pemPrivateKey); err != nil {
+				return fmt.Errorf("validating `certificate.0.pem_certificate` and `certificate.0.pem_private_key`: %+v", err)
+			}
+		}
+	}
+
+	if d.Id() != "" && d.HasChange("renewal_trigger") {
+		// renewing the certificate creates a new version
+		for _, key := range []string{"version", "secret_id", "resource_manager_id", "certificate_attribute", "certificate_data", "certificate_data_base64", "thumbprint", "renewal_status", "next_renewal_date"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return fmt.Errorf("setting `%s` to known after apply: %+v", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceKeyVaultCertificateCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
 	client := meta.(*clients.Client).KeyVault.ManagementClient
@@ -459,7 +523,10 @@ func resourceKeyVaultCertificateCreate(d *pluginsdk.ResourceData, meta interface
 
 	if v, ok := d.GetOk("certificate"); ok {
 		// Import
-		certificate := expandKeyVaultCertificate(v)
+		certificate, err := expandKeyVaultCertificate(v)
+		if err != nil {
+			return fmt.Errorf("expanding `certificate`: %+v", err)
+		}
 		importParameters := keyvault.CertificateImportParameters{
 			Base64EncodedCertificate: utils.String(certificate.CertificateData),
 			Password:                 utils.String(certificate.CertificatePassword),
@@ -575,7 +642,10 @@ func resourceKeyVaultCertificateUpdate(d *schema.ResourceData, meta interface{})
 	if d.HasChange("certificate") {
 		if v, ok := d.GetOk("certificate"); ok {
 			// Import new version of certificate
-			certificate := expandKeyVaultCertificate(v)
+			certificate, err := expandKeyVaultCertificate(v)
+			if err != nil {
+				return fmt.Errorf("expanding `certificate`: %+v", err)
+			}
 			importParameters := keyvault.CertificateImportParameters{
 				Base64EncodedCertificate: utils.String(certificate.CertificateData),
 				Password:                 utils.String(certificate.CertificatePassword),
@@ -590,6 +660,14 @@ func resourceKeyVaultCertificateUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if d.HasChange("renewal_trigger") {
+		renewedId, err := renewKeyVaultCertificate(ctx, client, *id, d.Timeout(pluginsdk.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+		d.SetId(renewedId.ID())
+	}
+
 	if d.HasChange("tags") {
 		patch := keyvault.CertificateUpdateParameters{}
 		if t, ok := d.GetOk("tags"); ok {
@@ -723,6 +801,20 @@ func resourceKeyVaultCertificateRead(d *pluginsdk.ResourceData, meta interface{}
 	}
 	d.Set("thumbprint", thumbprint)
 
+	operation, err := client.GetCertificateOperation(ctx, id.KeyVaultBaseUrl, id.Name)
+	if err != nil && !utils.ResponseWasNotFound(operation.Response) {
+		return fmt.Errorf("retrieving the Pending Operation for Key Vault Certificate %q: %+v", id.Name, err)
+	}
+	// there's no pending operation for certificates which have been imported
+	var pendingOperation *keyvault.CertificateOperation
+	if err == nil {
+		pendingOperation = &operation
+	}
+
+	renewalStatus, nextRenewalDate := flattenKeyVaultCertificateRenewal(cert.Policy, cert.Attributes, pendingOperation)
+	d.Set("renewal_status", renewalStatus)
+	d.Set("next_renewal_date", nextRenewalDate)
+
 	return tags.FlattenAndSet(d, cert.Tags)
 }
 
@@ -1104,12 +1196,23 @@ type KeyVaultCertificateImportParameters struct {
 	CertificatePassword string
 }
 
-func expandKeyVaultCertificate(v interface{}) KeyVaultCertificateImportParameters {
+func expandKeyVaultCertificate(v interface{}) (*KeyVaultCertificateImportParameters, error) {
 	certs := v.([]interface{})
 	cert := certs[0].(map[string]interface{})
 
-	return KeyVaultCertificateImportParameters{
+	if pemCertificate := cert["pem_certificate"].(string); pemCertificate != "" {
+		bundle, err := buildKeyVaultCertificatePemBundle(pemCertificate, cert["pem_private_key"].(string))
+		if err != nil {
+			return nil, err
+		}
+
+		return &KeyVaultCertificateImportParameters{
+			CertificateData: base64.StdEncoding.EncodeToString([]byte(bundle)),
+		}, nil
+	}
+
+	return &KeyVaultCertificateImportParameters{
 		CertificateData:     cert["contents"].(string),
 		CertificatePassword: cert["password"].(string),
-	}
+	}, nil
 }
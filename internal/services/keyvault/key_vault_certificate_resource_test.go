@@ -3,6 +3,7 @@ package keyvault_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
@@ -380,6 +381,88 @@ func TestAccKeyVaultCertificate_updatedImportedCertificate(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultCertificate_importPEMWithPrivateKey(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_certificate", "test")
+	r := KeyVaultCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.importPEMWithPrivateKey(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("certificate_data").Exists(),
+				check.That(data.ResourceName).Key("thumbprint").Exists(),
+				check.That(data.ResourceName).Key("renewal_status").Exists(),
+			),
+		},
+		data.ImportStep("certificate"),
+	})
+}
+
+func TestAccKeyVaultCertificate_importPEMUnorderedChain(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_certificate", "test")
+	r := KeyVaultCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.importPEMUnorderedChain(data),
+			ExpectError: regexp.MustCompile("must be ordered from the leaf certificate"),
+		},
+	})
+}
+
+func TestAccKeyVaultCertificate_renewalTrigger(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_certificate", "test")
+	r := KeyVaultCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basicGenerate(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("renewal_status").HasValue("AutoRenew"),
+				check.That(data.ResourceName).Key("next_renewal_date").Exists(),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.renewalTrigger(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("renewal_status").HasValue("AutoRenew"),
+			),
+		},
+		data.ImportStep("renewal_trigger"),
+		{
+			Config: r.renewalTrigger(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("renewal_status").HasValue("AutoRenew"),
+			),
+		},
+		data.ImportStep("renewal_trigger"),
+	})
+}
+
+func TestAccKeyVaultCertificate_renewalTriggerImported(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_certificate", "test")
+	r := KeyVaultCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basicImportPFX(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("certificate"),
+		{
+			Config:      r.basicImportPFXRenewalTrigger(data),
+			ExpectError: regexp.MustCompile("can't be renewed since it's not issued by Key Vault"),
+		},
+	})
+}
+
 func (t KeyVaultCertificateResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	keyVaultsClient := clients.KeyVault
 	client := clients.KeyVault.ManagementClient
@@ -562,6 +645,124 @@ resource "azurerm_key_vault_certificate" "test" {
 `, r.template(data), data.RandomString)
 }
 
+func (r KeyVaultCertificateResource) importPEMWithPrivateKey(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_certificate" "test" {
+  name         = "acctestcert%s"
+  key_vault_id = azurerm_key_vault.test.id
+
+  certificate {
+    pem_certificate = file("testdata/rsa_chain.pem")
+    pem_private_key = file("testdata/rsa_chain_key.pem")
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r KeyVaultCertificateResource) importPEMUnorderedChain(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_certificate" "test" {
+  name         = "acctestcert%s"
+  key_vault_id = azurerm_key_vault.test.id
+
+  certificate {
+    pem_certificate = file("testdata/rsa_chain_unordered.pem")
+    pem_private_key = file("testdata/rsa_chain_key.pem")
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r KeyVaultCertificateResource) basicImportPFXRenewalTrigger(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_certificate" "test" {
+  name            = "acctestcert%s"
+  key_vault_id    = azurerm_key_vault.test.id
+  renewal_trigger = "first"
+
+  certificate {
+    contents = filebase64("testdata/keyvaultcert.pfx")
+    password = ""
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r KeyVaultCertificateResource) renewalTrigger(data acceptance.TestData, trigger string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_certificate" "test" {
+  name            = "acctestcert%s"
+  key_vault_id    = azurerm_key_vault.test.id
+  renewal_trigger = %q
+
+  certificate_policy {
+    issuer_parameters {
+      name = "Self"
+    }
+
+    key_properties {
+      exportable = true
+      key_size   = 2048
+      key_type   = "RSA"
+      reuse_key  = true
+    }
+
+    lifetime_action {
+      action {
+        action_type = "AutoRenew"
+      }
+
+      trigger {
+        days_before_expiry = 30
+      }
+    }
+
+    secret_properties {
+      content_type = "application/x-pkcs12"
+    }
+
+    x509_certificate_properties {
+      key_usage = [
+        "cRLSign",
+        "dataEncipherment",
+        "digitalSignature",
+        "keyAgreement",
+        "keyEncipherment",
+        "keyCertSign",
+      ]
+
+      subject            = "CN=hello-world"
+      validity_in_months = 12
+    }
+  }
+}
+`, r.template(data), data.RandomString, trigger)
+}
+
 func (r KeyVaultCertificateResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
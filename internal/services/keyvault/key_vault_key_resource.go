@@ -136,68 +136,7 @@ func resourceKeyVaultKey() *pluginsdk.Resource {
 				ValidateFunc: validation.IsRFC3339Time,
 			},
 
-			"rotation_policy": {
-				Type:     pluginsdk.TypeList,
-				Optional: true,
-				Computed: true,
-				MaxItems: 1,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"expire_after": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validate.ISO8601DurationBetween("P28D", "P100Y"),
-							AtLeastOneOf: []string{
-								"rotation_policy.0.expire_after",
-								"rotation_policy.0.automatic",
-							},
-							RequiredWith: []string{
-								"rotation_policy.0.expire_after",
-								"rotation_policy.0.notify_before_expiry",
-							},
-						},
-
-						// <= expiry_time - 7, >=7
-						"notify_before_expiry": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validate.ISO8601DurationBetween("P7D", "P36493D"),
-							RequiredWith: []string{
-								"rotation_policy.0.expire_after",
-								"rotation_policy.0.notify_before_expiry",
-							},
-						},
-
-						"automatic": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							MaxItems: 1,
-							Elem: &pluginsdk.Resource{
-								Schema: map[string]*pluginsdk.Schema{
-									"time_after_creation": {
-										Type:         pluginsdk.TypeString,
-										Optional:     true,
-										ValidateFunc: validate.ISO8601Duration,
-										AtLeastOneOf: []string{
-											"rotation_policy.0.automatic.0.time_after_creation",
-											"rotation_policy.0.automatic.0.time_before_expiry",
-										},
-									},
-									"time_before_expiry": {
-										Type:         pluginsdk.TypeString,
-										Optional:     true,
-										ValidateFunc: validate.ISO8601Duration,
-										AtLeastOneOf: []string{
-											"rotation_policy.0.automatic.0.time_after_creation",
-											"rotation_policy.0.automatic.0.time_before_expiry",
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+			"rotation_policy": keyVaultKeyRotationPolicySchema(),
 
 			// Computed
 			"version": {
@@ -533,51 +472,8 @@ func resourceKeyVaultKeyRead(d *pluginsdk.ResourceData, meta interface{}) error
 	d.Set("version", id.Version)
 	d.Set("versionless_id", id.VersionlessID())
 	if key := resp.Key; key != nil {
-		if key.Kty == keyvault.JSONWebKeyTypeRSA || key.Kty == keyvault.JSONWebKeyTypeRSAHSM {
-			nBytes, err := base64.RawURLEncoding.DecodeString(*key.N)
-			if err != nil {
-				return fmt.Errorf("failed to decode N: %+v", err)
-			}
-			eBytes, err := base64.RawURLEncoding.DecodeString(*key.E)
-			if err != nil {
-				return fmt.Errorf("failed to decode E: %+v", err)
-			}
-			publicKey := &rsa.PublicKey{
-				N: big.NewInt(0).SetBytes(nBytes),
-				E: int(big.NewInt(0).SetBytes(eBytes).Uint64()),
-			}
-			err = readPublicKey(d, publicKey)
-			if err != nil {
-				return fmt.Errorf("failed to read public key: %+v", err)
-			}
-		} else if key.Kty == keyvault.JSONWebKeyTypeEC || key.Kty == keyvault.JSONWebKeyTypeECHSM {
-			// do ec keys
-			xBytes, err := base64.RawURLEncoding.DecodeString(*key.X)
-			if err != nil {
-				return fmt.Errorf("failed to decode X: %+v", err)
-			}
-			yBytes, err := base64.RawURLEncoding.DecodeString(*key.Y)
-			if err != nil {
-				return fmt.Errorf("failed to decode Y: %+v", err)
-			}
-			publicKey := &ecdsa.PublicKey{
-				X: big.NewInt(0).SetBytes(xBytes),
-				Y: big.NewInt(0).SetBytes(yBytes),
-			}
-			switch key.Crv {
-			case keyvault.JSONWebKeyCurveNameP256:
-				publicKey.Curve = elliptic.P256()
-			case keyvault.JSONWebKeyCurveNameP384:
-				publicKey.Curve = elliptic.P384()
-			case keyvault.JSONWebKeyCurveNameP521:
-				publicKey.Curve = elliptic.P521()
-			}
-			if publicKey.Curve != nil {
-				err = readPublicKey(d, publicKey)
-				if err != nil {
-					return fmt.Errorf("failed to read public key: %+v", err)
-				}
-			}
+		if err := flattenKeyVaultKeyPublicKey(d, key); err != nil {
+			return err
 		}
 	}
 
@@ -685,6 +581,125 @@ func (d deleteAndPurgeKey) NestedItemHasBeenPurged(ctx context.Context) (autores
 	return resp.Response, err
 }
 
+// keyVaultKeyRotationPolicySchema returns the schema for the Rotation Policy of a Key, which is shared between
+// Keys within a Key Vault and Keys within a Managed HSM
+func keyVaultKeyRotationPolicySchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"expire_after": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validate.ISO8601DurationBetween("P28D", "P100Y"),
+					AtLeastOneOf: []string{
+						"rotation_policy.0.expire_after",
+						"rotation_policy.0.automatic",
+					},
+					RequiredWith: []string{
+						"rotation_policy.0.expire_after",
+						"rotation_policy.0.notify_before_expiry",
+					},
+				},
+
+				// <= expiry_time - 7, >=7
+				"notify_before_expiry": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validate.ISO8601DurationBetween("P7D", "P36493D"),
+					RequiredWith: []string{
+						"rotation_policy.0.expire_after",
+						"rotation_policy.0.notify_before_expiry",
+					},
+				},
+
+				"automatic": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"time_after_creation": {
+								Type:         pluginsdk.TypeString,
+								Optional:     true,
+								ValidateFunc: validate.ISO8601Duration,
+								AtLeastOneOf: []string{
+									"rotation_policy.0.automatic.0.time_after_creation",
+									"rotation_policy.0.automatic.0.time_before_expiry",
+								},
+							},
+							"time_before_expiry": {
+								Type:         pluginsdk.TypeString,
+								Optional:     true,
+								ValidateFunc: validate.ISO8601Duration,
+								AtLeastOneOf: []string{
+									"rotation_policy.0.automatic.0.time_after_creation",
+									"rotation_policy.0.automatic.0.time_before_expiry",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenKeyVaultKeyPublicKey sets the PEM and OpenSSH encoded public key for RSA and EC keys
+func flattenKeyVaultKeyPublicKey(d *pluginsdk.ResourceData, key *keyvault.JSONWebKey) error {
+	if key.Kty == keyvault.JSONWebKeyTypeRSA || key.Kty == keyvault.JSONWebKeyTypeRSAHSM {
+		nBytes, err := base64.RawURLEncoding.DecodeString(*key.N)
+		if err != nil {
+			return fmt.Errorf("failed to decode N: %+v", err)
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(*key.E)
+		if err != nil {
+			return fmt.Errorf("failed to decode E: %+v", err)
+		}
+		publicKey := &rsa.PublicKey{
+			N: big.NewInt(0).SetBytes(nBytes),
+			E: int(big.NewInt(0).SetBytes(eBytes).Uint64()),
+		}
+		err = readPublicKey(d, publicKey)
+		if err != nil {
+			return fmt.Errorf("failed to read public key: %+v", err)
+		}
+	} else if key.Kty == keyvault.JSONWebKeyTypeEC || key.Kty == keyvault.JSONWebKeyTypeECHSM {
+		// do ec keys
+		xBytes, err := base64.RawURLEncoding.DecodeString(*key.X)
+		if err != nil {
+			return fmt.Errorf("failed to decode X: %+v", err)
+		}
+		yBytes, err := base64.RawURLEncoding.DecodeString(*key.Y)
+		if err != nil {
+			return fmt.Errorf("failed to decode Y: %+v", err)
+		}
+		publicKey := &ecdsa.PublicKey{
+			X: big.NewInt(0).SetBytes(xBytes),
+			Y: big.NewInt(0).SetBytes(yBytes),
+		}
+		switch key.Crv {
+		case keyvault.JSONWebKeyCurveNameP256:
+			publicKey.Curve = elliptic.P256()
+		case keyvault.JSONWebKeyCurveNameP384:
+			publicKey.Curve = elliptic.P384()
+		case keyvault.JSONWebKeyCurveNameP521:
+			publicKey.Curve = elliptic.P521()
+		}
+		if publicKey.Curve != nil {
+			err = readPublicKey(d, publicKey)
+			if err != nil {
+				return fmt.Errorf("failed to read public key: %+v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func expandKeyVaultKeyOptions(d *pluginsdk.ResourceData) *[]keyvault.JSONWebKeyOperation {
 	options := d.Get("key_opts").([]interface{})
 	results := make([]keyvault.JSONWebKeyOperation, 0, len(options))
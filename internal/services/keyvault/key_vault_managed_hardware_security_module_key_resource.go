@@ -0,0 +1,480 @@
+package keyvault
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+func resourceKeyVaultManagedHardwareSecurityModuleKey() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceKeyVaultManagedHardwareSecurityModuleKeyCreate,
+		Read:   resourceKeyVaultManagedHardwareSecurityModuleKeyRead,
+		Update: resourceKeyVaultManagedHardwareSecurityModuleKeyUpdate,
+		Delete: resourceKeyVaultManagedHardwareSecurityModuleKeyDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
+			_, err := parse.ParseNestedItemID(id)
+			return err
+		}, managedHSMNestedItemResourceImporter),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: keyVaultValidate.NestedItemName,
+			},
+
+			"managed_hsm_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: keyVaultValidate.ManagedHSMID,
+			},
+
+			"key_type": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ForceNew: true,
+				// only HSM backed keys can be created within a Managed HSM
+				ValidateFunc: validation.StringInSlice([]string{
+					string(keyvault.JSONWebKeyTypeECHSM),
+					string(keyvault.JSONWebKeyTypeOctHSM),
+					string(keyvault.JSONWebKeyTypeRSAHSM),
+				}, false),
+			},
+
+			"key_size": {
+				Type:          pluginsdk.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"curve"},
+			},
+
+			"key_opts": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(keyvault.JSONWebKeyOperationDecrypt),
+						string(keyvault.JSONWebKeyOperationEncrypt),
+						string(keyvault.JSONWebKeyOperationImport),
+						string(keyvault.JSONWebKeyOperationSign),
+						string(keyvault.JSONWebKeyOperationUnwrapKey),
+						string(keyvault.JSONWebKeyOperationVerify),
+						string(keyvault.JSONWebKeyOperationWrapKey),
+					}, false),
+				},
+			},
+
+			"curve": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(keyvault.JSONWebKeyCurveNameP256),
+					string(keyvault.JSONWebKeyCurveNameP256K),
+					string(keyvault.JSONWebKeyCurveNameP384),
+					string(keyvault.JSONWebKeyCurveNameP521),
+				}, false),
+				ConflictsWith: []string{"key_size"},
+			},
+
+			"not_before_date": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"expiration_date": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"rotation_policy": keyVaultKeyRotationPolicySchema(),
+
+			// Computed
+			"version": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"versionless_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"n": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"e": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"x": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"y": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"public_key_pem": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"public_key_openssh": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func resourceKeyVaultManagedHardwareSecurityModuleKeyCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	client, err := keyVaultsClient.ManagedHSMDataPlaneClient()
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	managedHSMId, err := parse.ManagedHSMID(d.Get("managed_hsm_id").(string))
+	if err != nil {
+		return err
+	}
+
+	managedHSMBaseUri, err := keyVaultsClient.BaseUriForManagedHSM(ctx, *managedHSMId)
+	if err != nil {
+		return fmt.Errorf("looking up the Data Plane URI for Key %q from %s: %+v", name, *managedHSMId, err)
+	}
+
+	existing, err := client.GetKey(ctx, *managedHSMBaseUri, name, "")
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing Key %q (Managed HSM %q): %s", name, *managedHSMBaseUri, err)
+		}
+	}
+
+	if existing.Key != nil && existing.Key.Kid != nil && *existing.Key.Kid != "" {
+		return tf.ImportAsExistsError("azurerm_key_vault_managed_hardware_security_module_key", *existing.Key.Kid)
+	}
+
+	parameters := keyvault.KeyCreateParameters{
+		Kty:    keyvault.JSONWebKeyType(d.Get("key_type").(string)),
+		KeyOps: expandKeyVaultKeyOptions(d),
+		KeyAttributes: &keyvault.KeyAttributes{
+			Enabled: utils.Bool(true),
+		},
+
+		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	switch parameters.Kty {
+	case keyvault.JSONWebKeyTypeECHSM:
+		curveName, ok := d.GetOk("curve")
+		if !ok {
+			return fmt.Errorf("`curve` is required when creating an `EC-HSM` key")
+		}
+		parameters.Curve = keyvault.JSONWebKeyCurveName(curveName.(string))
+	case keyvault.JSONWebKeyTypeRSAHSM, keyvault.JSONWebKeyTypeOctHSM:
+		keySize, ok := d.GetOk("key_size")
+		if !ok {
+			return fmt.Errorf("`key_size` is required when creating an `RSA-HSM` or `oct-HSM` key")
+		}
+		parameters.KeySize = utils.Int32(int32(keySize.(int)))
+	}
+
+	if v, ok := d.GetOk("not_before_date"); ok {
+		notBeforeDate, _ := time.Parse(time.RFC3339, v.(string)) // validated by schema
+		notBeforeUnixTime := date.UnixTime(notBeforeDate)
+		parameters.KeyAttributes.NotBefore = &notBeforeUnixTime
+	}
+
+	if v, ok := d.GetOk("expiration_date"); ok {
+		expirationDate, _ := time.Parse(time.RFC3339, v.(string)) // validated by schema
+		expirationUnixTime := date.UnixTime(expirationDate)
+		parameters.KeyAttributes.Expires = &expirationUnixTime
+	}
+
+	if resp, err := client.CreateKey(ctx, *managedHSMBaseUri, name, parameters); err != nil {
+		if meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedKeys && utils.ResponseWasConflict(resp.Response) {
+			recoveredKey, err := client.RecoverDeletedKey(ctx, *managedHSMBaseUri, name)
+			if err != nil {
+				return err
+			}
+			if recoveredKey.Key == nil || recoveredKey.Key.Kid == nil {
+				return fmt.Errorf("recovering Key %q (Managed HSM %q): `key.kid` was nil", name, *managedHSMBaseUri)
+			}
+			log.Printf("[DEBUG] Recovering Key %q with ID: %q", name, *recoveredKey.Key.Kid)
+			stateConf := &pluginsdk.StateChangeConf{
+				Pending:                   []string{"pending"},
+				Target:                    []string{"available"},
+				Refresh:                   keyVaultChildItemRefreshFunc(*recoveredKey.Key.Kid),
+				Delay:                     30 * time.Second,
+				PollInterval:              10 * time.Second,
+				ContinuousTargetOccurence: 10,
+				Timeout:                   d.Timeout(pluginsdk.TimeoutCreate),
+			}
+
+			if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+				return fmt.Errorf("waiting for Key %q (Managed HSM %q) to become available: %s", name, *managedHSMBaseUri, err)
+			}
+			log.Printf("[DEBUG] Key %q recovered with ID: %q", name, *recoveredKey.Key.Kid)
+		} else {
+			return fmt.Errorf("creating Key %q (Managed HSM %q): %+v", name, *managedHSMBaseUri, err)
+		}
+	}
+
+	if v, ok := d.GetOk("rotation_policy"); ok {
+		if _, err := client.UpdateKeyRotationPolicy(ctx, *managedHSMBaseUri, name, expandKeyVaultKeyRotationPolicy(v)); err != nil {
+			return fmt.Errorf("creating the Rotation Policy for Key %q (Managed HSM %q): %+v", name, *managedHSMBaseUri, err)
+		}
+	}
+
+	// "" indicates the latest version
+	read, err := client.GetKey(ctx, *managedHSMBaseUri, name, "")
+	if err != nil {
+		return fmt.Errorf("retrieving Key %q (Managed HSM %q): %+v", name, *managedHSMBaseUri, err)
+	}
+	if read.Key == nil || read.Key.Kid == nil {
+		return fmt.Errorf("retrieving Key %q (Managed HSM %q): `key.kid` was nil", name, *managedHSMBaseUri)
+	}
+
+	id, err := parse.ParseNestedItemID(*read.Key.Kid)
+	if err != nil {
+		return err
+	}
+	d.SetId(id.ID())
+
+	return resourceKeyVaultManagedHardwareSecurityModuleKeyRead(d, meta)
+}
+
+func resourceKeyVaultManagedHardwareSecurityModuleKeyUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	client, err := meta.(*clients.Client).KeyVault.ManagedHSMDataPlaneClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	parameters := keyvault.KeyUpdateParameters{
+		KeyOps: expandKeyVaultKeyOptions(d),
+		KeyAttributes: &keyvault.KeyAttributes{
+			Enabled: utils.Bool(true),
+		},
+		Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	if v, ok := d.GetOk("not_before_date"); ok {
+		notBeforeDate, _ := time.Parse(time.RFC3339, v.(string)) // validated by schema
+		notBeforeUnixTime := date.UnixTime(notBeforeDate)
+		parameters.KeyAttributes.NotBefore = &notBeforeUnixTime
+	}
+
+	if v, ok := d.GetOk("expiration_date"); ok {
+		expirationDate, _ := time.Parse(time.RFC3339, v.(string)) // validated by schema
+		expirationUnixTime := date.UnixTime(expirationDate)
+		parameters.KeyAttributes.Expires = &expirationUnixTime
+	}
+
+	if _, err = client.UpdateKey(ctx, id.KeyVaultBaseUrl, id.Name, "", parameters); err != nil {
+		return fmt.Errorf("updating Key %q (Managed HSM %q): %+v", id.Name, id.KeyVaultBaseUrl, err)
+	}
+
+	if d.HasChange("rotation_policy") {
+		if v, ok := d.GetOk("rotation_policy"); ok {
+			if _, err := client.UpdateKeyRotationPolicy(ctx, id.KeyVaultBaseUrl, id.Name, expandKeyVaultKeyRotationPolicy(v)); err != nil {
+				return fmt.Errorf("updating the Rotation Policy for Key %q (Managed HSM %q): %+v", id.Name, id.KeyVaultBaseUrl, err)
+			}
+		}
+	}
+
+	return resourceKeyVaultManagedHardwareSecurityModuleKeyRead(d, meta)
+}
+
+func resourceKeyVaultManagedHardwareSecurityModuleKeyRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	client, err := keyVaultsClient.ManagedHSMDataPlaneClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// the Resource ID of the Managed HSM is looked up when importing, so is always available
+	managedHSMId, err := parse.ManagedHSMID(d.Get("managed_hsm_id").(string))
+	if err != nil {
+		return err
+	}
+
+	ok, err := keyVaultsClient.ManagedHSMExists(ctx, *managedHSMId)
+	if err != nil {
+		return fmt.Errorf("checking if %s for Key %q exists: %+v", *managedHSMId, id.Name, err)
+	}
+	if !ok {
+		log.Printf("[DEBUG] %s for Key %q was not found - removing from state", *managedHSMId, id.Name)
+		d.SetId("")
+		return nil
+	}
+
+	resp, err := client.GetKey(ctx, id.KeyVaultBaseUrl, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Key %q was not found in Managed HSM at URI %q - removing from state", id.Name, id.KeyVaultBaseUrl)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving Key %q (Managed HSM %q): %+v", id.Name, id.KeyVaultBaseUrl, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("managed_hsm_id", managedHSMId.ID())
+
+	if key := resp.Key; key != nil {
+		d.Set("key_type", string(key.Kty))
+
+		if err := d.Set("key_opts", flattenKeyVaultKeyOptions(key.KeyOps)); err != nil {
+			return fmt.Errorf("setting `key_opts`: %+v", err)
+		}
+
+		d.Set("n", key.N)
+		d.Set("e", key.E)
+		d.Set("x", key.X)
+		d.Set("y", key.Y)
+		if key.N != nil {
+			nBytes, err := base64.RawURLEncoding.DecodeString(*key.N)
+			if err != nil {
+				return fmt.Errorf("decoding N: %+v", err)
+			}
+			d.Set("key_size", len(nBytes)*8)
+		}
+
+		d.Set("curve", key.Crv)
+
+		if err := flattenKeyVaultKeyPublicKey(d, key); err != nil {
+			return err
+		}
+	}
+
+	if attributes := resp.Attributes; attributes != nil {
+		if v := attributes.NotBefore; v != nil {
+			d.Set("not_before_date", time.Time(*v).Format(time.RFC3339))
+		}
+
+		if v := attributes.Expires; v != nil {
+			d.Set("expiration_date", time.Time(*v).Format(time.RFC3339))
+		}
+	}
+
+	// Computed
+	d.Set("version", id.Version)
+	d.Set("versionless_id", id.VersionlessID())
+
+	respPolicy, err := client.GetKeyRotationPolicy(ctx, id.KeyVaultBaseUrl, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(respPolicy.Response) {
+			return fmt.Errorf("retrieving the Rotation Policy for Key %q (Managed HSM %q): %+v", id.Name, id.KeyVaultBaseUrl, err)
+		}
+	} else {
+		if err := d.Set("rotation_policy", flattenKeyVaultKeyRotationPolicy(respPolicy)); err != nil {
+			return fmt.Errorf("setting `rotation_policy`: %+v", err)
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func resourceKeyVaultManagedHardwareSecurityModuleKeyDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	client, err := keyVaultsClient.ManagedHSMDataPlaneClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	managedHSMId, err := parse.ManagedHSMID(d.Get("managed_hsm_id").(string))
+	if err != nil {
+		return err
+	}
+
+	hsm, err := keyVaultsClient.ManagedHsmClient.Get(ctx, managedHSMId.ResourceGroup, managedHSMId.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(hsm.Response) {
+			log.Printf("[DEBUG] Key %q Managed HSM %q was not found - removing from state", id.Name, *managedHSMId)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *managedHSMId, err)
+	}
+
+	shouldPurge := meta.(*clients.Client).Features.KeyVault.PurgeSoftDeletedKeysOnDestroy
+	if shouldPurge && hsm.Properties != nil && utils.NormaliseNilableBool(hsm.Properties.EnablePurgeProtection) {
+		log.Printf("[DEBUG] cannot purge key %q because %s has purge protection enabled", id.Name, *managedHSMId)
+		shouldPurge = false
+	}
+
+	description := fmt.Sprintf("Key %q (Managed HSM %q)", id.Name, id.KeyVaultBaseUrl)
+	deleter := deleteAndPurgeKey{
+		client:      client,
+		keyVaultUri: id.KeyVaultBaseUrl,
+		name:        id.Name,
+	}
+	return deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter)
+}
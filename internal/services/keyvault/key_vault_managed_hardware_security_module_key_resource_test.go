@@ -0,0 +1,178 @@
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KeyVaultManagedHardwareSecurityModuleKeyResource struct{}
+
+// NOTE: these tests are run as a part of `TestAccKeyVaultManagedHardwareSecurityModule` since only a single
+// Managed HSM can be provisioned at a time
+
+func testAccKeyVaultManagedHardwareSecurityModuleKey_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_key", "test")
+	r := KeyVaultManagedHardwareSecurityModuleKeyResource{}
+	roleAssignmentName := uuid.New().String()
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, roleAssignmentName),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("public_key_pem").IsSet(),
+				check.That(data.ResourceName).Key("versionless_id").IsSet(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func testAccKeyVaultManagedHardwareSecurityModuleKey_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_key", "test")
+	r := KeyVaultManagedHardwareSecurityModuleKeyResource{}
+	roleAssignmentName := uuid.New().String()
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data, roleAssignmentName),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("key_size").HasValue("2048"),
+				check.That(data.ResourceName).Key("n").IsSet(),
+				check.That(data.ResourceName).Key("e").IsSet(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func testAccKeyVaultManagedHardwareSecurityModuleKey_rotationPolicy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module_key", "test")
+	r := KeyVaultManagedHardwareSecurityModuleKeyResource{}
+	roleAssignmentName := uuid.New().String()
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, roleAssignmentName),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.rotationPolicy(data, roleAssignmentName),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("rotation_policy.0.expire_after").HasValue("P90D"),
+				check.That(data.ResourceName).Key("rotation_policy.0.automatic.0.time_before_expiry").HasValue("P30D"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	client, err := clients.KeyVault.ManagedHSMDataPlaneClient()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := parse.ParseNestedItemID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetKey(ctx, id.KeyVaultBaseUrl, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Key %q (Managed HSM %q): %+v", id.Name, id.KeyVaultBaseUrl, err)
+	}
+
+	return utils.Bool(resp.Key != nil), nil
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) basic(data acceptance.TestData, roleAssignmentName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_key" "test" {
+  name           = "acctestkey-%s"
+  managed_hsm_id = azurerm_key_vault_managed_hardware_security_module.test.id
+  key_type       = "EC-HSM"
+  curve          = "P-256"
+  key_opts       = ["sign", "verify"]
+
+  depends_on = [azurerm_key_vault_managed_hardware_security_module_role_assignment.crypto_user]
+}
+`, r.template(data, roleAssignmentName), data.RandomString)
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) complete(data acceptance.TestData, roleAssignmentName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_key" "test" {
+  name            = "acctestkey-%s"
+  managed_hsm_id  = azurerm_key_vault_managed_hardware_security_module.test.id
+  key_type        = "RSA-HSM"
+  key_size        = 2048
+  key_opts        = ["decrypt", "encrypt", "sign", "unwrapKey", "verify", "wrapKey"]
+  not_before_date = "2021-01-01T01:02:03Z"
+  expiration_date = "2034-12-31T23:59:59Z"
+
+  tags = {
+    "hello" = "world"
+  }
+
+  depends_on = [azurerm_key_vault_managed_hardware_security_module_role_assignment.crypto_user]
+}
+`, r.template(data, roleAssignmentName), data.RandomString)
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) rotationPolicy(data acceptance.TestData, roleAssignmentName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module_key" "test" {
+  name           = "acctestkey-%s"
+  managed_hsm_id = azurerm_key_vault_managed_hardware_security_module.test.id
+  key_type       = "EC-HSM"
+  curve          = "P-256"
+  key_opts       = ["sign", "verify"]
+
+  rotation_policy {
+    expire_after         = "P90D"
+    notify_before_expiry = "P29D"
+    automatic {
+      time_before_expiry = "P30D"
+    }
+  }
+
+  depends_on = [azurerm_key_vault_managed_hardware_security_module_role_assignment.crypto_user]
+}
+`, r.template(data, roleAssignmentName), data.RandomString)
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleKeyResource) template(data acceptance.TestData, roleAssignmentName string) string {
+	return fmt.Sprintf(`
+%s
+
+# the administrators of a Managed HSM can't manage Keys without being assigned the "Managed HSM Crypto User" role
+resource "azurerm_key_vault_managed_hardware_security_module_role_assignment" "crypto_user" {
+  name               = "%s"
+  managed_hsm_id     = azurerm_key_vault_managed_hardware_security_module.test.id
+  scope              = "/keys"
+  role_definition_id = "/Microsoft.KeyVault/providers/Microsoft.Authorization/roleDefinitions/21dbd100-6940-42c2-9190-5d6cb909625b"
+  principal_id       = data.azurerm_client_config.current.object_id
+}
+`, KeyVaultManagedHardwareSecurityModuleResource{}.activated(data), roleAssignmentName)
+}
@@ -1,6 +1,7 @@
 package keyvault
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -26,6 +27,7 @@ func resourceKeyVaultManagedHardwareSecurityModule() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourceArmKeyVaultManagedHardwareSecurityModuleCreate,
 		Read:   resourceArmKeyVaultManagedHardwareSecurityModuleRead,
+		Update: resourceArmKeyVaultManagedHardwareSecurityModuleUpdate,
 		Delete: resourceArmKeyVaultManagedHardwareSecurityModuleDelete,
 
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
@@ -36,9 +38,12 @@ func resourceKeyVaultManagedHardwareSecurityModule() *pluginsdk.Resource {
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
 			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceArmKeyVaultManagedHardwareSecurityModuleCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -131,12 +136,67 @@ func resourceKeyVaultManagedHardwareSecurityModule() *pluginsdk.Resource {
 				},
 			},
 
+			"security_domain_certificate": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MinItems:     3,
+				MaxItems:     10,
+				RequiredWith: []string{"security_domain_quorum"},
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"security_domain_quorum": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"security_domain_certificate"},
+				ValidateFunc: validation.IntBetween(2, 10),
+			},
+
 			// https://github.com/Azure/azure-rest-api-specs/issues/13365
 			"tags": tags.ForceNewSchema(),
+
+			"security_domain_encrypted_data": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
 		},
 	}
 }
 
+func resourceArmKeyVaultManagedHardwareSecurityModuleCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	// the Security Domain can only be downloaded once, when the Managed HSM is activated
+	if d.Id() != "" && (d.HasChange("security_domain_certificate") || d.HasChange("security_domain_quorum")) {
+		if old, _ := d.GetChange("security_domain_certificate"); len(old.([]interface{})) > 0 {
+			return fmt.Errorf("`security_domain_certificate` and `security_domain_quorum` can't be changed once the Managed HSM has been activated")
+		}
+	}
+
+	if d.NewValueKnown("security_domain_certificate") {
+		certificates := d.Get("security_domain_certificate").([]interface{})
+		if quorum := d.Get("security_domain_quorum").(int); len(certificates) > 0 && quorum > len(certificates) {
+			return fmt.Errorf("`security_domain_quorum` (%d) can't be greater than the number of `security_domain_certificate` (%d)", quorum, len(certificates))
+		}
+
+		for _, v := range certificates {
+			// the values may not be known at plan time, in which case they're validated when the Managed HSM is activated
+			if v == nil || v.(string) == "" {
+				return nil
+			}
+		}
+		if len(certificates) > 0 {
+			if _, err := expandManagedHSMSecurityDomainCertificates(certificates); err != nil {
+				return fmt.Errorf("validating `security_domain_certificate`: %+v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceArmKeyVaultManagedHardwareSecurityModuleCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).KeyVault.ManagedHsmClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
@@ -191,9 +251,63 @@ func resourceArmKeyVaultManagedHardwareSecurityModuleCreate(d *pluginsdk.Resourc
 	}
 
 	d.SetId(id.ID())
+
+	if certificates := d.Get("security_domain_certificate").([]interface{}); len(certificates) > 0 {
+		// returning an error once the ID has been set would taint the Managed HSM, meaning it'd be destroyed and
+		// re-created (which isn't possible when purge protection is enabled) - since the certificates are removed
+		// from the state when the activation fails, it's retried by the Update during the next apply instead
+		if err := activateKeyVaultManagedHardwareSecurityModule(ctx, d, meta, id, certificates, d.Timeout(pluginsdk.TimeoutCreate)); err != nil {
+			log.Printf("[WARN] %+v - the activation will be retried during the next apply", err)
+		}
+	}
+
+	return resourceArmKeyVaultManagedHardwareSecurityModuleRead(d, meta)
+}
+
+func resourceArmKeyVaultManagedHardwareSecurityModuleUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedHSMID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// an existing Managed HSM which hasn't been activated can be activated by specifying the Security Domain certificates
+	if d.HasChange("security_domain_certificate") {
+		if certificates := d.Get("security_domain_certificate").([]interface{}); len(certificates) > 0 {
+			if err := activateKeyVaultManagedHardwareSecurityModule(ctx, d, meta, *id, certificates, d.Timeout(pluginsdk.TimeoutUpdate)); err != nil {
+				return err
+			}
+		}
+	}
+
 	return resourceArmKeyVaultManagedHardwareSecurityModuleRead(d, meta)
 }
 
+func activateKeyVaultManagedHardwareSecurityModule(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id parse.ManagedHSMId, certificates []interface{}, timeout time.Duration) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client, err := keyVaultsClient.ManagedHSMSecurityDomainClient()
+	if err != nil {
+		return err
+	}
+
+	managedHSMBaseUri, err := keyVaultsClient.BaseUriForManagedHSM(ctx, id)
+	if err != nil {
+		return fmt.Errorf("looking up the Data Plane URI for %s: %+v", id, err)
+	}
+
+	encryptedData, err := downloadManagedHSMSecurityDomain(ctx, client, *managedHSMBaseUri, certificates, d.Get("security_domain_quorum").(int), timeout)
+	if err != nil {
+		// the Managed HSM exists but hasn't been activated, so clear the certificates to ensure activation is retried
+		d.Set("security_domain_certificate", []interface{}{})
+		return fmt.Errorf("activating %s: %+v", id, err)
+	}
+	d.Set("security_domain_encrypted_data", encryptedData)
+
+	return nil
+}
+
 func resourceArmKeyVaultManagedHardwareSecurityModuleRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).KeyVault.ManagedHsmClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
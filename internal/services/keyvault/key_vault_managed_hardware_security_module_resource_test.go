@@ -26,6 +26,19 @@ func TestAccKeyVaultManagedHardwareSecurityModule(t *testing.T) {
 			"basic":    testAccKeyVaultManagedHardwareSecurityModule_basic,
 			"update":   testAccKeyVaultManagedHardwareSecurityModule_requiresImport,
 			"complete": testAccKeyVaultManagedHardwareSecurityModule_complete,
+			"activate": testAccKeyVaultManagedHardwareSecurityModule_activate,
+		},
+		"key": {
+			"basic":          testAccKeyVaultManagedHardwareSecurityModuleKey_basic,
+			"complete":       testAccKeyVaultManagedHardwareSecurityModuleKey_complete,
+			"rotationPolicy": testAccKeyVaultManagedHardwareSecurityModuleKey_rotationPolicy,
+		},
+		"role_assignment": {
+			"basic": testAccKeyVaultManagedHardwareSecurityModuleRoleAssignment_basic,
+		},
+		"role_definition": {
+			"basic":  testAccKeyVaultManagedHardwareSecurityModuleRoleDefinition_basic,
+			"update": testAccKeyVaultManagedHardwareSecurityModuleRoleDefinition_update,
 		},
 	})
 }
@@ -75,6 +88,29 @@ func testAccKeyVaultManagedHardwareSecurityModule_complete(t *testing.T) {
 	})
 }
 
+func testAccKeyVaultManagedHardwareSecurityModule_activate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module", "test")
+	r := KeyVaultManagedHardwareSecurityModuleResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.activated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("security_domain_encrypted_data").IsSet(),
+			),
+		},
+		data.ImportStep("security_domain_certificate", "security_domain_quorum", "security_domain_encrypted_data"),
+	})
+}
+
 func (KeyVaultManagedHardwareSecurityModuleResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.ManagedHSMID(state.ID)
 	if err != nil {
@@ -174,6 +210,34 @@ resource "azurerm_key_vault_managed_hardware_security_module" "test" {
 `, template, data.RandomInteger)
 }
 
+func (r KeyVaultManagedHardwareSecurityModuleResource) activated(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_managed_hardware_security_module" "test" {
+  name                     = "kvHsm%d"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  sku_name                 = "Standard_B1"
+  tenant_id                = data.azurerm_client_config.current.tenant_id
+  admin_object_ids         = [data.azurerm_client_config.current.object_id]
+  purge_protection_enabled = false
+
+  security_domain_certificate = [
+    file("testdata/hsm_security_domain_1.pem"),
+    file("testdata/hsm_security_domain_2.pem"),
+    file("testdata/hsm_security_domain_3.pem"),
+  ]
+  security_domain_quorum = 2
+}
+`, template, data.RandomInteger)
+}
+
 func (KeyVaultManagedHardwareSecurityModuleResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 data "azurerm_client_config" "current" {
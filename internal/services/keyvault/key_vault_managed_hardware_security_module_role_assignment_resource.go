@@ -0,0 +1,240 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource struct{}
+
+var _ sdk.Resource = KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource{}
+
+var _ sdk.ResourceWithCustomImporter = KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource{}
+
+type KeyVaultManagedHardwareSecurityModuleRoleAssignmentModel struct {
+	Name             string `tfschema:"name"`
+	ManagedHSMId     string `tfschema:"managed_hsm_id"`
+	Scope            string `tfschema:"scope"`
+	RoleDefinitionId string `tfschema:"role_definition_id"`
+	PrincipalId      string `tfschema:"principal_id"`
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"managed_hsm_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ManagedHSMID,
+		},
+
+		"scope": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ManagedHSMRoleScope,
+		},
+
+		"role_definition_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			DiffSuppressFunc: func(_, old, new string, _ *pluginsdk.ResourceData) bool {
+				// the API returns the Role Definition ID without a leading slash
+				return strings.EqualFold(strings.TrimPrefix(old, "/"), strings.TrimPrefix(new, "/"))
+			},
+		},
+
+		"principal_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) ResourceType() string {
+	return "azurerm_key_vault_managed_hardware_security_module_role_assignment"
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) ModelObject() interface{} {
+	return &KeyVaultManagedHardwareSecurityModuleRoleAssignmentModel{}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ManagedHSMRoleAssignmentID
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client, err := keyVaultsClient.ManagedHSMRoleAssignmentsClient()
+			if err != nil {
+				return err
+			}
+
+			var config KeyVaultManagedHardwareSecurityModuleRoleAssignmentModel
+			if err := metadata.Decode(&config); err != nil {
+				return err
+			}
+
+			managedHSMId, err := parse.ManagedHSMID(config.ManagedHSMId)
+			if err != nil {
+				return err
+			}
+
+			managedHSMBaseUri, err := keyVaultsClient.BaseUriForManagedHSM(ctx, *managedHSMId)
+			if err != nil {
+				return fmt.Errorf("looking up the Data Plane URI for %s: %+v", *managedHSMId, err)
+			}
+
+			id, err := parse.NewManagedHSMRoleAssignmentID(*managedHSMBaseUri, config.Scope, config.Name)
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return tf.ImportAsExistsError(r.ResourceType(), id.ID())
+			}
+
+			parameters := keyvault.RoleAssignmentCreateParameters{
+				Properties: &keyvault.RoleAssignmentProperties{
+					RoleDefinitionID: utils.String(config.RoleDefinitionId),
+					PrincipalID:      utils.String(config.PrincipalId),
+				},
+			}
+			if _, err := client.Create(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client, err := keyVaultsClient.ManagedHSMRoleAssignmentsClient()
+			if err != nil {
+				return err
+			}
+
+			id, err := parse.ManagedHSMRoleAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// the Resource ID of the Managed HSM is looked up when importing, so is always available
+			managedHSMId, err := parse.ManagedHSMID(metadata.ResourceData.Get("managed_hsm_id").(string))
+			if err != nil {
+				return err
+			}
+
+			ok, err := keyVaultsClient.ManagedHSMExists(ctx, *managedHSMId)
+			if err != nil {
+				return fmt.Errorf("checking if %s for %s exists: %+v", *managedHSMId, id, err)
+			}
+			if !ok {
+				return metadata.MarkAsGone(id)
+			}
+
+			resp, err := client.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := KeyVaultManagedHardwareSecurityModuleRoleAssignmentModel{
+				Name:         id.Name,
+				ManagedHSMId: managedHSMId.ID(),
+				Scope:        id.Scope,
+			}
+
+			if props := resp.Properties; props != nil {
+				state.RoleDefinitionId = utils.NormalizeNilableString(props.RoleDefinitionID)
+				state.PrincipalId = utils.NormalizeNilableString(props.PrincipalID)
+			}
+
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) CustomImporter() sdk.ResourceRunFunc {
+	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+		id, err := parse.ManagedHSMRoleAssignmentID(metadata.ResourceData.Id())
+		if err != nil {
+			return err
+		}
+
+		managedHSMId, err := metadata.Client.KeyVault.ManagedHSMIDFromBaseUrl(ctx, metadata.Client.Resource, id.ManagedHSMBaseUrl)
+		if err != nil {
+			return fmt.Errorf("retrieving the Resource ID of the Managed HSM at URL %q: %+v", id.ManagedHSMBaseUrl, err)
+		}
+
+		return metadata.ResourceData.Set("managed_hsm_id", managedHSMId.ID())
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client, err := metadata.Client.KeyVault.ManagedHSMRoleAssignmentsClient()
+			if err != nil {
+				return err
+			}
+
+			id, err := parse.ManagedHSMRoleAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if resp, err := client.Delete(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name); err != nil {
+				if !utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("deleting %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
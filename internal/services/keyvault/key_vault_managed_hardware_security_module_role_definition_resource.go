@@ -0,0 +1,413 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+// custom Role Definitions can only be created at the global scope of a Managed HSM
+const managedHSMRoleDefinitionScope = "/"
+
+type KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource struct{}
+
+var _ sdk.ResourceWithUpdate = KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource{}
+
+var _ sdk.ResourceWithCustomImporter = KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource{}
+
+type KeyVaultManagedHardwareSecurityModuleRoleDefinitionModel struct {
+	Name             string                                                `tfschema:"name"`
+	ManagedHSMId     string                                                `tfschema:"managed_hsm_id"`
+	RoleName         string                                                `tfschema:"role_name"`
+	Description      string                                                `tfschema:"description"`
+	Permission       []KeyVaultManagedHardwareSecurityModuleRolePermission `tfschema:"permission"`
+	RoleDefinitionId string                                                `tfschema:"role_definition_id"`
+	RoleType         string                                                `tfschema:"role_type"`
+}
+
+type KeyVaultManagedHardwareSecurityModuleRolePermission struct {
+	Actions        []string `tfschema:"actions"`
+	NotActions     []string `tfschema:"not_actions"`
+	DataActions    []string `tfschema:"data_actions"`
+	NotDataActions []string `tfschema:"not_data_actions"`
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Arguments() map[string]*pluginsdk.Schema {
+	dataActions := make([]string, 0)
+	for _, v := range keyvault.PossibleDataActionValues() {
+		dataActions = append(dataActions, string(v))
+	}
+
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"managed_hsm_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ManagedHSMID,
+		},
+
+		"role_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"description": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"permission": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"actions": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"not_actions": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"data_actions": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringInSlice(dataActions, false),
+						},
+					},
+
+					"not_data_actions": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringInSlice(dataActions, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"role_definition_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"role_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) ResourceType() string {
+	return "azurerm_key_vault_managed_hardware_security_module_role_definition"
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) ModelObject() interface{} {
+	return &KeyVaultManagedHardwareSecurityModuleRoleDefinitionModel{}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ManagedHSMRoleDefinitionID
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client, err := keyVaultsClient.ManagedHSMRoleDefinitionsClient()
+			if err != nil {
+				return err
+			}
+
+			var config KeyVaultManagedHardwareSecurityModuleRoleDefinitionModel
+			if err := metadata.Decode(&config); err != nil {
+				return err
+			}
+
+			managedHSMId, err := parse.ManagedHSMID(config.ManagedHSMId)
+			if err != nil {
+				return err
+			}
+
+			managedHSMBaseUri, err := keyVaultsClient.BaseUriForManagedHSM(ctx, *managedHSMId)
+			if err != nil {
+				return fmt.Errorf("looking up the Data Plane URI for %s: %+v", *managedHSMId, err)
+			}
+
+			id, err := parse.NewManagedHSMRoleDefinitionID(*managedHSMBaseUri, managedHSMRoleDefinitionScope, config.Name)
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return tf.ImportAsExistsError(r.ResourceType(), id.ID())
+			}
+
+			parameters := keyvault.RoleDefinitionCreateParameters{
+				Properties: &keyvault.RoleDefinitionProperties{
+					RoleName:         utils.String(config.RoleName),
+					Description:      utils.String(config.Description),
+					RoleType:         keyvault.RoleTypeCustomRole,
+					Permissions:      expandKeyVaultManagedHardwareSecurityModuleRolePermissions(config.Permission),
+					AssignableScopes: &[]keyvault.RoleScope{keyvault.RoleScopeGlobal},
+				},
+			}
+			if _, err := client.CreateOrUpdate(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client, err := keyVaultsClient.ManagedHSMRoleDefinitionsClient()
+			if err != nil {
+				return err
+			}
+
+			id, err := parse.ManagedHSMRoleDefinitionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// the Resource ID of the Managed HSM is looked up when importing, so is always available
+			managedHSMId, err := parse.ManagedHSMID(metadata.ResourceData.Get("managed_hsm_id").(string))
+			if err != nil {
+				return err
+			}
+
+			ok, err := keyVaultsClient.ManagedHSMExists(ctx, *managedHSMId)
+			if err != nil {
+				return fmt.Errorf("checking if %s for %s exists: %+v", *managedHSMId, id, err)
+			}
+			if !ok {
+				return metadata.MarkAsGone(id)
+			}
+
+			resp, err := client.Get(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := KeyVaultManagedHardwareSecurityModuleRoleDefinitionModel{
+				Name:             id.Name,
+				ManagedHSMId:     managedHSMId.ID(),
+				RoleDefinitionId: utils.NormalizeNilableString(resp.ID),
+			}
+
+			if props := resp.RoleDefinitionProperties; props != nil {
+				state.RoleName = utils.NormalizeNilableString(props.RoleName)
+				state.Description = utils.NormalizeNilableString(props.Description)
+				state.RoleType = string(props.RoleType)
+				state.Permission = flattenKeyVaultManagedHardwareSecurityModuleRolePermissions(props.Permissions)
+			}
+
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client, err := metadata.Client.KeyVault.ManagedHSMRoleDefinitionsClient()
+			if err != nil {
+				return err
+			}
+
+			id, err := parse.ManagedHSMRoleDefinitionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config KeyVaultManagedHardwareSecurityModuleRoleDefinitionModel
+			if err := metadata.Decode(&config); err != nil {
+				return err
+			}
+
+			// the API replaces the whole Role Definition, so all of the properties are sent
+			parameters := keyvault.RoleDefinitionCreateParameters{
+				Properties: &keyvault.RoleDefinitionProperties{
+					RoleName:         utils.String(config.RoleName),
+					Description:      utils.String(config.Description),
+					RoleType:         keyvault.RoleTypeCustomRole,
+					Permissions:      expandKeyVaultManagedHardwareSecurityModuleRolePermissions(config.Permission),
+					AssignableScopes: &[]keyvault.RoleScope{keyvault.RoleScopeGlobal},
+				},
+			}
+			if _, err := client.CreateOrUpdate(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name, parameters); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) CustomImporter() sdk.ResourceRunFunc {
+	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+		id, err := parse.ManagedHSMRoleDefinitionID(metadata.ResourceData.Id())
+		if err != nil {
+			return err
+		}
+
+		managedHSMId, err := metadata.Client.KeyVault.ManagedHSMIDFromBaseUrl(ctx, metadata.Client.Resource, id.ManagedHSMBaseUrl)
+		if err != nil {
+			return fmt.Errorf("retrieving the Resource ID of the Managed HSM at URL %q: %+v", id.ManagedHSMBaseUrl, err)
+		}
+
+		return metadata.ResourceData.Set("managed_hsm_id", managedHSMId.ID())
+	}
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client, err := metadata.Client.KeyVault.ManagedHSMRoleDefinitionsClient()
+			if err != nil {
+				return err
+			}
+
+			id, err := parse.ManagedHSMRoleDefinitionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if resp, err := client.Delete(ctx, id.ManagedHSMBaseUrl, id.Scope, id.Name); err != nil {
+				if !utils.ResponseWasNotFound(resp.Response) {
+					return fmt.Errorf("deleting %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+		Timeout: 30 * time.Minute,
+	}
+}
+
+func expandKeyVaultManagedHardwareSecurityModuleRolePermissions(input []KeyVaultManagedHardwareSecurityModuleRolePermission) *[]keyvault.Permission {
+	results := make([]keyvault.Permission, 0)
+
+	for _, item := range input {
+		dataActions := make([]keyvault.DataAction, 0)
+		for _, v := range item.DataActions {
+			dataActions = append(dataActions, keyvault.DataAction(v))
+		}
+
+		notDataActions := make([]keyvault.DataAction, 0)
+		for _, v := range item.NotDataActions {
+			notDataActions = append(notDataActions, keyvault.DataAction(v))
+		}
+
+		actions := item.Actions
+		if actions == nil {
+			actions = make([]string, 0)
+		}
+
+		notActions := item.NotActions
+		if notActions == nil {
+			notActions = make([]string, 0)
+		}
+
+		results = append(results, keyvault.Permission{
+			Actions:        &actions,
+			NotActions:     &notActions,
+			DataActions:    &dataActions,
+			NotDataActions: &notDataActions,
+		})
+	}
+
+	return &results
+}
+
+func flattenKeyVaultManagedHardwareSecurityModuleRolePermissions(input *[]keyvault.Permission) []KeyVaultManagedHardwareSecurityModuleRolePermission {
+	results := make([]KeyVaultManagedHardwareSecurityModuleRolePermission, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		permission := KeyVaultManagedHardwareSecurityModuleRolePermission{
+			Actions:        make([]string, 0),
+			NotActions:     make([]string, 0),
+			DataActions:    make([]string, 0),
+			NotDataActions: make([]string, 0),
+		}
+
+		if item.Actions != nil {
+			permission.Actions = *item.Actions
+		}
+		if item.NotActions != nil {
+			permission.NotActions = *item.NotActions
+		}
+		if item.DataActions != nil {
+			for _, v := range *item.DataActions {
+				permission.DataActions = append(permission.DataActions, string(v))
+			}
+		}
+		if item.NotDataActions != nil {
+			for _, v := range *item.NotDataActions {
+				permission.NotDataActions = append(permission.NotDataActions, string(v))
+			}
+		}
+
+		results = append(results, permission)
+	}
+
+	return results
+}
@@ -0,0 +1,143 @@
+package keyvault
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+// expandManagedHSMSecurityDomainCertificates converts the PEM encoded certificates used to encrypt the Security
+// Domain into the JSON Web Key format expected by the API - only the public keys are required, the private keys
+// are needed to restore the Security Domain and so never leave the user
+func expandManagedHSMSecurityDomainCertificates(input []interface{}) (*[]keyvault.SecurityDomainJSONWebKey, error) {
+	results := make([]keyvault.SecurityDomainJSONWebKey, 0)
+
+	for i, raw := range input {
+		certificate, err := parseManagedHSMSecurityDomainCertificate(raw.(string))
+		if err != nil {
+			return nil, fmt.Errorf("parsing Security Domain certificate %d: %+v", i+1, err)
+		}
+
+		publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("Security Domain certificate %d (%q) must contain an RSA public key", i+1, certificate.Subject.String())
+		}
+		if publicKey.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("Security Domain certificate %d (%q) must contain an RSA public key of at least 2048 bits but got %d bits", i+1, certificate.Subject.String(), publicKey.N.BitLen())
+		}
+
+		sha1Thumbprint := sha1.Sum(certificate.Raw)
+		sha256Thumbprint := sha256.Sum256(certificate.Raw)
+		thumbprint := base64.RawURLEncoding.EncodeToString(sha256Thumbprint[:])
+
+		results = append(results, keyvault.SecurityDomainJSONWebKey{
+			Kid:     utils.String(thumbprint),
+			Kty:     utils.String("RSA"),
+			KeyOps:  &[]string{"verify"},
+			Alg:     utils.String("RS256"),
+			N:       utils.String(base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())),
+			E:       utils.String(base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())),
+			X5c:     &[]string{base64.StdEncoding.EncodeToString(certificate.Raw)},
+			X5t:     utils.String(base64.RawURLEncoding.EncodeToString(sha1Thumbprint[:])),
+			X5tS256: utils.String(thumbprint),
+		})
+	}
+
+	return &results, nil
+}
+
+func parseManagedHSMSecurityDomainCertificate(input string) (*x509.Certificate, error) {
+	block, rest := pem.Decode([]byte(input))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded certificate was found")
+	}
+	if block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("expected a `CERTIFICATE` PEM block but got %q", block.Type)
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, fmt.Errorf("expected a single PEM encoded certificate")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// downloadManagedHSMSecurityDomain activates the Managed HSM by downloading its Security Domain, which is encrypted
+// using the specified certificates - returning the encrypted Security Domain
+func downloadManagedHSMSecurityDomain(ctx context.Context, client *keyvault.HSMSecurityDomainClient, managedHSMBaseUri string, certificates []interface{}, quorum int, timeout time.Duration) (*string, error) {
+	keys, err := expandManagedHSMSecurityDomainCertificates(certificates)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := keyvault.CertificateInfoObject{
+		Certificates: keys,
+		Required:     utils.Int32(int32(quorum)),
+	}
+	log.Printf("[DEBUG] Downloading the Security Domain for the Managed HSM at %q", managedHSMBaseUri)
+	future, err := client.Download(ctx, managedHSMBaseUri, parameters)
+	if err != nil {
+		return nil, fmt.Errorf("downloading the Security Domain for the Managed HSM at %q: %+v", managedHSMBaseUri, err)
+	}
+
+	// the encrypted Security Domain is only returned in the initial response, the subsequent polling
+	// only returns the status of the activation
+	resp := future.Response()
+	if resp == nil || resp.Body == nil {
+		return nil, fmt.Errorf("downloading the Security Domain for the Managed HSM at %q: the response was empty", managedHSMBaseUri)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading the Security Domain for the Managed HSM at %q: %+v", managedHSMBaseUri, err)
+	}
+	var securityDomain keyvault.SecurityDomainObject
+	if err := json.Unmarshal(body, &securityDomain); err != nil {
+		return nil, fmt.Errorf("parsing the Security Domain for the Managed HSM at %q: %+v", managedHSMBaseUri, err)
+	}
+	if securityDomain.Value == nil || *securityDomain.Value == "" {
+		return nil, fmt.Errorf("downloading the Security Domain for the Managed HSM at %q: `value` was empty", managedHSMBaseUri)
+	}
+
+	log.Printf("[DEBUG] Waiting for the Managed HSM at %q to be activated", managedHSMBaseUri)
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending:    []string{string(keyvault.OperationStatusInProgress)},
+		Target:     []string{string(keyvault.OperationStatusSuccess)},
+		Refresh:    managedHSMSecurityDomainDownloadRefreshFunc(ctx, client, managedHSMBaseUri),
+		MinTimeout: 15 * time.Second,
+		Timeout:    timeout,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return nil, fmt.Errorf("waiting for the Managed HSM at %q to be activated: %+v", managedHSMBaseUri, err)
+	}
+
+	return securityDomain.Value, nil
+}
+
+func managedHSMSecurityDomainDownloadRefreshFunc(ctx context.Context, client *keyvault.HSMSecurityDomainClient, managedHSMBaseUri string) pluginsdk.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := client.DownloadPending(ctx, managedHSMBaseUri)
+		if err != nil {
+			return nil, "", fmt.Errorf("retrieving the status of the Security Domain download for the Managed HSM at %q: %+v", managedHSMBaseUri, err)
+		}
+
+		if resp.Status == keyvault.OperationStatusFailed {
+			return nil, "", fmt.Errorf("downloading the Security Domain for the Managed HSM at %q failed: %s", managedHSMBaseUri, utils.NormalizeNilableString(resp.StatusDetails))
+		}
+
+		return resp, string(resp.Status), nil
+	}
+}
@@ -0,0 +1,138 @@
+package keyvault
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestManagedHSMSecurityDomainCertificate(t *testing.T, key crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "security domain"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("creating certificate: %+v", err)
+	}
+	certificate, err := x509.ParseCertificate(raw)
+	if err != nil {
+		t.Fatalf("parsing certificate: %+v", err)
+	}
+	return certificate
+}
+
+func TestExpandManagedHSMSecurityDomainCertificates(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %+v", err)
+	}
+	rsaCertificate := newTestManagedHSMSecurityDomainCertificate(t, rsaKey)
+	rsaCertificatePem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rsaCertificate.Raw}))
+
+	smallRsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generating key: %+v", err)
+	}
+	smallRsaCertificate := newTestManagedHSMSecurityDomainCertificate(t, smallRsaKey)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %+v", err)
+	}
+	ecCertificate := newTestManagedHSMSecurityDomainCertificate(t, ecKey)
+
+	testData := []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{
+			name:  "rsa certificate",
+			input: rsaCertificatePem,
+		},
+		{
+			name:          "rsa certificate with a small key",
+			input:         string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: smallRsaCertificate.Raw})),
+			expectedError: "at least 2048 bits",
+		},
+		{
+			name:          "ec certificate",
+			input:         string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ecCertificate.Raw})),
+			expectedError: "must contain an RSA public key",
+		},
+		{
+			name:          "private key",
+			input:         string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})),
+			expectedError: "expected a `CERTIFICATE` PEM block",
+		},
+		{
+			name:          "multiple certificates",
+			input:         rsaCertificatePem + rsaCertificatePem,
+			expectedError: "expected a single PEM encoded certificate",
+		},
+		{
+			name:          "not pem encoded",
+			input:         "hello world",
+			expectedError: "no PEM encoded certificate",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := expandManagedHSMSecurityDomainCertificates([]interface{}{v.input})
+		if v.expectedError != "" {
+			if err == nil {
+				t.Fatalf("expected an error containing %q but got none", v.expectedError)
+			}
+			if !strings.Contains(err.Error(), v.expectedError) {
+				t.Fatalf("expected an error containing %q but got %q", v.expectedError, err.Error())
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		if len(*actual) != 1 {
+			t.Fatalf("expected 1 key but got %d", len(*actual))
+		}
+		key := (*actual)[0]
+
+		if *key.Kty != "RSA" {
+			t.Fatalf("expected the key type to be `RSA` but got %q", *key.Kty)
+		}
+		if *key.E != "AQAB" {
+			t.Fatalf("expected the exponent to be `AQAB` but got %q", *key.E)
+		}
+		n, err := base64.RawURLEncoding.DecodeString(*key.N)
+		if err != nil {
+			t.Fatalf("decoding the modulus: %+v", err)
+		}
+		if new(big.Int).SetBytes(n).Cmp(rsaKey.N) != 0 {
+			t.Fatalf("expected the modulus to match the public key")
+		}
+		if len(*key.X5c) != 1 || (*key.X5c)[0] != base64.StdEncoding.EncodeToString(rsaCertificate.Raw) {
+			t.Fatalf("expected `x5c` to contain the DER encoded certificate")
+		}
+		thumbprint := sha256.Sum256(rsaCertificate.Raw)
+		if *key.X5tS256 != base64.RawURLEncoding.EncodeToString(thumbprint[:]) {
+			t.Fatalf("expected `x5t#S256` to be the SHA256 thumbprint of the certificate")
+		}
+	}
+}
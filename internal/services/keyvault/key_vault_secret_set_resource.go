@@ -0,0 +1,745 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/workerpool"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type keyVaultSecretSetItem struct {
+	Name           string
+	Value          string
+	ContentType    string
+	NotBeforeDate  string
+	ExpirationDate string
+	Tags           map[string]interface{}
+}
+
+func resourceKeyVaultSecretSet() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceKeyVaultSecretSetCreate,
+		Read:   resourceKeyVaultSecretSetRead,
+		Update: resourceKeyVaultSecretSetUpdate,
+		Delete: resourceKeyVaultSecretSetDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
+			_, err := parse.SecretSetSecretsID(id)
+			return err
+		}, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+			// the names of the Secrets are only required to import the Secret Set, after which they're tracked in `secret_names`
+			id, err := parse.SecretSetSecretsID(d.Id())
+			if err != nil {
+				return nil, err
+			}
+
+			d.Set("secret_names", id.SecretNames)
+			d.SetId(id.SecretSet.ID())
+			return []*pluginsdk.ResourceData{d}, nil
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: keyVaultValidate.NestedItemName,
+			},
+
+			"key_vault_id": commonschema.ResourceIDReferenceRequiredForceNew(commonids.KeyVaultId{}),
+
+			"secret": {
+				Type:     pluginsdk.TypeSet,
+				Required: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: keyVaultValidate.NestedItemName,
+						},
+
+						"value": {
+							Type:      pluginsdk.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+
+						"content_type": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+						},
+
+						"not_before_date": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+
+						"expiration_date": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+
+						"tags": tags.SchemaWithMax(15),
+					},
+				},
+			},
+
+			"parallelism": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 50),
+			},
+
+			"rotation": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"interval": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validateKeyVaultSecretSetRotationInterval,
+							AtLeastOneOf: []string{"rotation.0.interval", "rotation.0.trigger"},
+						},
+
+						"trigger": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							AtLeastOneOf: []string{"rotation.0.interval", "rotation.0.trigger"},
+						},
+					},
+				},
+			},
+
+			"secret_names": {
+				Type:     pluginsdk.TypeSet,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"secret_ids": {
+				Type:     pluginsdk.TypeMap,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"previous_secret_ids": {
+				Type:     pluginsdk.TypeMap,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"last_rotation_time": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceKeyVaultSecretSetCustomizeDiff),
+	}
+}
+
+func resourceKeyVaultSecretSetCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	oldLastRotation, _ := d.GetChange("last_rotation_time")
+	if keyVaultSecretSetRotationIsDue(d.HasChange("rotation.0.trigger"), d.Get("rotation").([]interface{}), oldLastRotation.(string)) {
+		for _, key := range []string{"secret_ids", "previous_secret_ids", "last_rotation_time"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return fmt.Errorf("setting `%s` to known after apply: %+v", key, err)
+			}
+		}
+		return nil
+	}
+
+	if d.HasChange("secret") {
+		if d.NewValueKnown("secret") {
+			names := keyVaultSecretSetItemNames(expandKeyVaultSecretSetItems(d.Get("secret").(*pluginsdk.Set).List()))
+			if err := d.SetNew("secret_names", names); err != nil {
+				return fmt.Errorf("setting `secret_names`: %+v", err)
+			}
+		} else if err := d.SetNewComputed("secret_names"); err != nil {
+			return fmt.Errorf("setting `secret_names` to known after apply: %+v", err)
+		}
+
+		// new versions are created for any Secrets whose values have changed
+		for _, key := range []string{"secret_ids", "previous_secret_ids"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return fmt.Errorf("setting `%s` to known after apply: %+v", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceKeyVaultSecretSetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	keyVaultId, err := commonids.ParseKeyVaultID(d.Get("key_vault_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewSecretSetID(keyVaultId.SubscriptionId, keyVaultId.ResourceGroupName, keyVaultId.VaultName, d.Get("name").(string))
+
+	keyVaultBaseUrl, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("looking up the vault url for %s: %+v", id, err)
+	}
+
+	items := expandKeyVaultSecretSetItems(d.Get("secret").(*pluginsdk.Set).List())
+	names := keyVaultSecretSetItemNames(items)
+	parallelism := d.Get("parallelism").(int)
+
+	existingNames := make([]string, 0)
+	existingNamesLock := sync.Mutex{}
+	err = runKeyVaultSecretSetOperations(names, parallelism, func(name string) error {
+		existing, err := client.GetSecret(ctx, *keyVaultBaseUrl, name, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(existing.Response) {
+				return nil
+			}
+			return fmt.Errorf("checking for presence of existing Secret: %+v", err)
+		}
+
+		existingNamesLock.Lock()
+		existingNames = append(existingNames, name)
+		existingNamesLock.Unlock()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("checking for presence of existing Secrets for %s: %+v", id, err)
+	}
+	if len(existingNames) > 0 {
+		sort.Strings(existingNames)
+		log.Printf("[DEBUG] the Secrets %q already exist within %s", existingNames, *keyVaultId)
+		return tf.ImportAsExistsError("azurerm_key_vault_secret_set", parse.NewSecretSetSecretsID(id, names).ID())
+	}
+
+	secretIds := map[string]string{}
+	secretIdsLock := sync.Mutex{}
+	err = runKeyVaultSecretSetOperations(names, parallelism, func(name string) error {
+		secretId, err := setKeyVaultSecretSetItem(ctx, d, meta, *keyVaultBaseUrl, items[name])
+		if err != nil {
+			return err
+		}
+
+		secretIdsLock.Lock()
+		secretIds[name] = secretId
+		secretIdsLock.Unlock()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("creating the Secrets for %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+	d.Set("secret_names", names)
+	d.Set("secret_ids", secretIds)
+	d.Set("previous_secret_ids", map[string]string{})
+	d.Set("last_rotation_time", time.Now().UTC().Format(time.RFC3339))
+
+	return resourceKeyVaultSecretSetRead(d, meta)
+}
+
+func resourceKeyVaultSecretSetUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SecretSetID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keyVaultId := commonids.NewKeyVaultID(id.SubscriptionId, id.ResourceGroup, id.VaultName)
+	keyVaultBaseUrl, err := keyVaultsClient.BaseUriForKeyVault(ctx, keyVaultId)
+	if err != nil {
+		return fmt.Errorf("looking up the vault url for %s: %+v", id, err)
+	}
+
+	oldRaw, newRaw := d.GetChange("secret")
+	oldItems := expandKeyVaultSecretSetItems(oldRaw.(*pluginsdk.Set).List())
+	newItems := expandKeyVaultSecretSetItems(newRaw.(*pluginsdk.Set).List())
+
+	oldLastRotation, _ := d.GetChange("last_rotation_time")
+	rotate := keyVaultSecretSetRotationIsDue(d.HasChange("rotation.0.trigger"), d.Get("rotation").([]interface{}), oldLastRotation.(string))
+
+	oldSecretIds, _ := d.GetChange("secret_ids")
+	oldPreviousSecretIds, _ := d.GetChange("previous_secret_ids")
+	secretIds := map[string]string{}
+	previousSecretIds := map[string]string{}
+	for name := range newItems {
+		if v, ok := oldSecretIds.(map[string]interface{})[name]; ok {
+			secretIds[name] = v.(string)
+		}
+		if v, ok := oldPreviousSecretIds.(map[string]interface{})[name]; ok {
+			previousSecretIds[name] = v.(string)
+		}
+	}
+
+	// Secrets which are new, have a new value or are being rotated require a new version, whereas
+	// Secrets where only the attributes have changed can be updated in-place
+	toSet := make([]string, 0)
+	toUpdate := make([]string, 0)
+	for name, item := range newItems {
+		existing, ok := oldItems[name]
+		switch {
+		case !ok || rotate || existing.Value != item.Value:
+			toSet = append(toSet, name)
+		case !reflect.DeepEqual(existing, item):
+			toUpdate = append(toUpdate, name)
+		}
+	}
+	toDelete := make([]string, 0)
+	for name := range oldItems {
+		if _, ok := newItems[name]; !ok {
+			toDelete = append(toDelete, name)
+		}
+	}
+
+	// until the removed Secrets have been deleted `secret_names` tracks both the existing and the new Secrets, so
+	// that none of them are lost from the state should any of the operations fail
+	allItems := make(map[string]keyVaultSecretSetItem)
+	for name, item := range oldItems {
+		allItems[name] = item
+	}
+	for name, item := range newItems {
+		allItems[name] = item
+	}
+	d.Set("secret_names", keyVaultSecretSetItemNames(allItems))
+
+	parallelism := d.Get("parallelism").(int)
+	secretIdsLock := sync.Mutex{}
+
+	err = runKeyVaultSecretSetOperations(toSet, parallelism, func(name string) error {
+		secretId, err := setKeyVaultSecretSetItem(ctx, d, meta, *keyVaultBaseUrl, newItems[name])
+		if err != nil {
+			return err
+		}
+
+		secretIdsLock.Lock()
+		if existing, ok := secretIds[name]; ok && existing != secretId {
+			previousSecretIds[name] = existing
+		}
+		secretIds[name] = secretId
+		secretIdsLock.Unlock()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("setting the Secrets for %s: %+v", id, err)
+	}
+
+	err = runKeyVaultSecretSetOperations(toUpdate, parallelism, func(name string) error {
+		item := newItems[name]
+		parameters := keyvault.SecretUpdateParameters{
+			ContentType:      utils.String(item.ContentType),
+			Tags:             tags.Expand(item.Tags),
+			SecretAttributes: expandKeyVaultSecretSetItemAttributes(item),
+		}
+		if _, err := client.UpdateSecret(ctx, *keyVaultBaseUrl, name, "", parameters); err != nil {
+			return fmt.Errorf("updating Secret: %+v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("updating the Secrets for %s: %+v", id, err)
+	}
+
+	if err := deleteKeyVaultSecretSetItems(ctx, meta, keyVaultId, *keyVaultBaseUrl, toDelete, parallelism); err != nil {
+		return fmt.Errorf("deleting the Secrets removed from %s: %+v", id, err)
+	}
+
+	d.Set("secret_names", keyVaultSecretSetItemNames(newItems))
+	d.Set("secret_ids", secretIds)
+	d.Set("previous_secret_ids", previousSecretIds)
+	if rotate {
+		d.Set("last_rotation_time", time.Now().UTC().Format(time.RFC3339))
+	} else {
+		d.Set("last_rotation_time", oldLastRotation)
+	}
+
+	return resourceKeyVaultSecretSetRead(d, meta)
+}
+
+func resourceKeyVaultSecretSetRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SecretSetID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keyVaultId := commonids.NewKeyVaultID(id.SubscriptionId, id.ResourceGroup, id.VaultName)
+	ok, err := keyVaultsClient.Exists(ctx, keyVaultId)
+	if err != nil {
+		return fmt.Errorf("checking if %s exists: %+v", keyVaultId, err)
+	}
+	if !ok {
+		log.Printf("[DEBUG] %s was not found - removing %s from state", keyVaultId, id)
+		d.SetId("")
+		return nil
+	}
+
+	keyVaultBaseUrl, err := keyVaultsClient.BaseUriForKeyVault(ctx, keyVaultId)
+	if err != nil {
+		return fmt.Errorf("looking up the vault url for %s: %+v", id, err)
+	}
+
+	// the names of the Secrets are taken from `secret_names` rather than the configuration, so that they're available when importing
+	secrets := make([]interface{}, 0)
+	secretIds := map[string]string{}
+	resultsLock := sync.Mutex{}
+	err = runKeyVaultSecretSetOperations(keyVaultSecretSetNames(d), d.Get("parallelism").(int), func(name string) error {
+		// we always want to get the latest version
+		resp, err := client.GetSecret(ctx, *keyVaultBaseUrl, name, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				log.Printf("[DEBUG] Secret %q was not found within %s - removing from state", name, keyVaultId)
+				return nil
+			}
+			return fmt.Errorf("retrieving Secret: %+v", err)
+		}
+
+		secret := map[string]interface{}{
+			"name":            name,
+			"value":           utils.NormalizeNilableString(resp.Value),
+			"content_type":    utils.NormalizeNilableString(resp.ContentType),
+			"not_before_date": "",
+			"expiration_date": "",
+			"tags":            tags.Flatten(resp.Tags),
+		}
+		if attributes := resp.Attributes; attributes != nil {
+			if v := attributes.NotBefore; v != nil {
+				secret["not_before_date"] = time.Time(*v).Format(time.RFC3339)
+			}
+			if v := attributes.Expires; v != nil {
+				secret["expiration_date"] = time.Time(*v).Format(time.RFC3339)
+			}
+		}
+
+		resultsLock.Lock()
+		secrets = append(secrets, secret)
+		if resp.ID != nil {
+			secretIds[name] = *resp.ID
+		}
+		resultsLock.Unlock()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("retrieving the Secrets for %s: %+v", id, err)
+	}
+	if len(secrets) == 0 {
+		log.Printf("[DEBUG] none of the Secrets within %s were found - removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	previousSecretIds := map[string]interface{}{}
+	for name, v := range d.Get("previous_secret_ids").(map[string]interface{}) {
+		if _, ok := secretIds[name]; ok {
+			previousSecretIds[name] = v
+		}
+	}
+
+	names := make([]string, 0, len(secretIds))
+	for name := range secretIds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	d.Set("name", id.Name)
+	d.Set("key_vault_id", keyVaultId.ID())
+	if err := d.Set("secret", secrets); err != nil {
+		return fmt.Errorf("setting `secret`: %+v", err)
+	}
+	d.Set("secret_names", names)
+	d.Set("secret_ids", secretIds)
+	d.Set("previous_secret_ids", previousSecretIds)
+
+	if _, ok := d.GetOk("parallelism"); !ok {
+		d.Set("parallelism", 10)
+	}
+
+	return nil
+}
+
+func resourceKeyVaultSecretSetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SecretSetID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keyVaultId := commonids.NewKeyVaultID(id.SubscriptionId, id.ResourceGroup, id.VaultName)
+	ok, err := keyVaultsClient.Exists(ctx, keyVaultId)
+	if err != nil {
+		return fmt.Errorf("checking if %s exists: %+v", keyVaultId, err)
+	}
+	if !ok {
+		log.Printf("[DEBUG] %s was not found - removing %s from state", keyVaultId, id)
+		return nil
+	}
+
+	keyVaultBaseUrl, err := keyVaultsClient.BaseUriForKeyVault(ctx, keyVaultId)
+	if err != nil {
+		return fmt.Errorf("looking up the vault url for %s: %+v", id, err)
+	}
+
+	if err := deleteKeyVaultSecretSetItems(ctx, meta, keyVaultId, *keyVaultBaseUrl, keyVaultSecretSetNames(d), d.Get("parallelism").(int)); err != nil {
+		return fmt.Errorf("deleting the Secrets for %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+// setKeyVaultSecretSetItem creates a new version of the specified Secret, returning the ID of this version
+func setKeyVaultSecretSetItem(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, keyVaultBaseUrl string, item keyVaultSecretSetItem) (string, error) {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+
+	parameters := keyvault.SecretSetParameters{
+		Value:            utils.String(item.Value),
+		ContentType:      utils.String(item.ContentType),
+		Tags:             tags.Expand(item.Tags),
+		SecretAttributes: expandKeyVaultSecretSetItemAttributes(item),
+	}
+
+	resp, err := client.SetSecret(ctx, keyVaultBaseUrl, item.Name, parameters)
+	if err != nil {
+		// the Secret may exist in a Soft Deleted state, in which case it can be recovered when opted-in
+		if !meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedSecrets || !utils.ResponseWasConflict(resp.Response) {
+			return "", fmt.Errorf("setting Secret: %+v", err)
+		}
+
+		recovered, err := client.RecoverDeletedSecret(ctx, keyVaultBaseUrl, item.Name)
+		if err != nil {
+			return "", fmt.Errorf("recovering Soft Deleted Secret: %+v", err)
+		}
+		if recovered.ID == nil {
+			return "", fmt.Errorf("recovering Soft Deleted Secret: `id` was nil")
+		}
+
+		// recovered Key Vault Child items aren't as readily available as newly created ones
+		stateConf := &pluginsdk.StateChangeConf{
+			Pending:                   []string{"pending"},
+			Target:                    []string{"available"},
+			Refresh:                   keyVaultChildItemRefreshFunc(*recovered.ID),
+			Delay:                     30 * time.Second,
+			PollInterval:              10 * time.Second,
+			ContinuousTargetOccurence: 10,
+			Timeout:                   time.Until(keyVaultSecretSetDeadline(ctx, d)),
+		}
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return "", fmt.Errorf("waiting for the recovered Secret to become available: %+v", err)
+		}
+
+		if resp, err = client.SetSecret(ctx, keyVaultBaseUrl, item.Name, parameters); err != nil {
+			return "", fmt.Errorf("setting recovered Secret: %+v", err)
+		}
+	}
+
+	if resp.ID == nil {
+		return "", fmt.Errorf("setting Secret: `id` was nil")
+	}
+
+	return *resp.ID, nil
+}
+
+func deleteKeyVaultSecretSetItems(ctx context.Context, meta interface{}, keyVaultId commonids.KeyVaultId, keyVaultBaseUrl string, names []string, parallelism int) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	kv, err := keyVaultsClient.VaultsClient.Get(ctx, keyVaultId.ResourceGroupName, keyVaultId.VaultName)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", keyVaultId, err)
+	}
+
+	shouldPurge := meta.(*clients.Client).Features.KeyVault.PurgeSoftDeletedSecretsOnDestroy
+	if shouldPurge && kv.Properties != nil && utils.NormaliseNilableBool(kv.Properties.EnablePurgeProtection) {
+		log.Printf("[DEBUG] cannot purge secrets because %s has purge protection enabled", keyVaultId)
+		shouldPurge = false
+	}
+
+	return runKeyVaultSecretSetOperations(names, parallelism, func(name string) error {
+		description := fmt.Sprintf("Secret %q (Key Vault %q)", name, keyVaultBaseUrl)
+		deleter := deleteAndPurgeSecret{
+			client:      keyVaultsClient.ManagementClient,
+			keyVaultUri: keyVaultBaseUrl,
+			name:        name,
+		}
+		return deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter)
+	})
+}
+
+// runKeyVaultSecretSetOperations runs the operation for each of the specified Secrets, with at most `parallelism`
+// operations running at once, returning the errors from all of the operations which failed
+func runKeyVaultSecretSetOperations(names []string, parallelism int, operation func(name string) error) error {
+	return workerpool.Run(names, parallelism, func(name string) string {
+		return fmt.Sprintf("Secret %q", name)
+	}, operation)
+}
+
+// keyVaultSecretSetRotationIsDue returns whether new versions of all of the Secrets should be created, either
+// because the rotation trigger has changed or because the rotation interval has passed since the last rotation
+func keyVaultSecretSetRotationIsDue(triggerChanged bool, input []interface{}, lastRotationTime string) bool {
+	if len(input) == 0 || input[0] == nil {
+		return false
+	}
+	if triggerChanged {
+		return true
+	}
+
+	raw := input[0].(map[string]interface{})
+	interval, err := time.ParseDuration(raw["interval"].(string))
+	if err != nil || interval <= 0 {
+		return false
+	}
+
+	lastRotation, err := time.Parse(time.RFC3339, lastRotationTime)
+	if err != nil {
+		return false
+	}
+
+	return !time.Now().Before(lastRotation.Add(interval))
+}
+
+func keyVaultSecretSetDeadline(ctx context.Context, d *pluginsdk.ResourceData) time.Time {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline
+	}
+	return time.Now().Add(d.Timeout(pluginsdk.TimeoutCreate))
+}
+
+func validateKeyVaultSecretSetRotationInterval(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("expected %q to be a duration such as `720h`: %+v", k, err))
+		return
+	}
+
+	if duration < time.Hour {
+		errors = append(errors, fmt.Errorf("expected %q to be at least `1h` but got %q", k, v))
+	}
+
+	return
+}
+
+func expandKeyVaultSecretSetItems(input []interface{}) map[string]keyVaultSecretSetItem {
+	output := make(map[string]keyVaultSecretSetItem)
+	for _, raw := range input {
+		if raw == nil {
+			continue
+		}
+		v := raw.(map[string]interface{})
+
+		item := keyVaultSecretSetItem{
+			Name:           v["name"].(string),
+			Value:          v["value"].(string),
+			ContentType:    v["content_type"].(string),
+			NotBeforeDate:  v["not_before_date"].(string),
+			ExpirationDate: v["expiration_date"].(string),
+			Tags:           map[string]interface{}{},
+		}
+		if t, ok := v["tags"].(map[string]interface{}); ok {
+			item.Tags = t
+		}
+		output[item.Name] = item
+	}
+
+	return output
+}
+
+func expandKeyVaultSecretSetItemAttributes(item keyVaultSecretSetItem) *keyvault.SecretAttributes {
+	attributes := &keyvault.SecretAttributes{}
+
+	if item.NotBeforeDate != "" {
+		notBeforeDate, _ := time.Parse(time.RFC3339, item.NotBeforeDate) // validated by schema
+		notBeforeUnixTime := date.UnixTime(notBeforeDate)
+		attributes.NotBefore = &notBeforeUnixTime
+	}
+
+	if item.ExpirationDate != "" {
+		expirationDate, _ := time.Parse(time.RFC3339, item.ExpirationDate) // validated by schema
+		expirationUnixTime := date.UnixTime(expirationDate)
+		attributes.Expires = &expirationUnixTime
+	}
+
+	return attributes
+}
+
+func keyVaultSecretSetItemNames(input map[string]keyVaultSecretSetItem) []string {
+	names := make([]string, 0, len(input))
+	for name := range input {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keyVaultSecretSetNames returns the names of the Secrets managed by the Secret Set
+func keyVaultSecretSetNames(d *pluginsdk.ResourceData) []string {
+	names := make([]string, 0)
+	for _, v := range d.Get("secret_names").(*pluginsdk.Set).List() {
+		names = append(names, v.(string))
+	}
+	sort.Strings(names)
+	return names
+}
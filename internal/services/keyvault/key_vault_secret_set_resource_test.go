@@ -0,0 +1,276 @@
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type KeyVaultSecretSetResource struct{}
+
+func TestAccKeyVaultSecretSet_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_set", "test")
+	r := KeyVaultSecretSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("secret.#").HasValue("2"),
+				check.That(data.ResourceName).Key("secret_names.#").HasValue("2"),
+				check.That(data.ResourceName).Key("secret_ids.%").HasValue("2"),
+				check.That(data.ResourceName).Key("previous_secret_ids.%").HasValue("0"),
+			),
+		},
+		r.importStep(data, "last_rotation_time", "previous_secret_ids"),
+	})
+}
+
+func TestAccKeyVaultSecretSet_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_set", "test")
+	r := KeyVaultSecretSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(data),
+			ExpectError: acceptance.RequiresImportError("azurerm_key_vault_secret_set"),
+		},
+	})
+}
+
+func TestAccKeyVaultSecretSet_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_set", "test")
+	r := KeyVaultSecretSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config: r.updated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("secret.#").HasValue("2"),
+				check.That(data.ResourceName).Key("secret_ids.%").HasValue("2"),
+				// only the Secret whose value changed has a new version
+				check.That(data.ResourceName).Key("previous_secret_ids.%").HasValue("1"),
+				check.That(data.ResourceName).Key("previous_secret_ids.first").Exists(),
+			),
+		},
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("secret.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAccKeyVaultSecretSet_rotation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_set", "test")
+	r := KeyVaultSecretSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.rotation(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("previous_secret_ids.%").HasValue("0"),
+			),
+		},
+		{
+			Config: r.rotation(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("previous_secret_ids.%").HasValue("2"),
+				check.That(data.ResourceName).Key("last_rotation_time").Exists(),
+			),
+		},
+	})
+}
+
+// importStep imports the Secret Set using the names of the Secrets within it, which are required to import it
+func (KeyVaultSecretSetResource) importStep(data acceptance.TestData, ignore ...string) acceptance.TestStep {
+	step := data.ImportStep(ignore...)
+	step.ImportStateIdFunc = func(state *acceptance.State) (string, error) {
+		rs, ok := state.RootModule().Resources[data.ResourceName]
+		if !ok {
+			return "", fmt.Errorf("%q was not found in the state", data.ResourceName)
+		}
+
+		id, err := parse.SecretSetID(rs.Primary.ID)
+		if err != nil {
+			return "", err
+		}
+
+		names := make([]string, 0)
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "secret_names.") && k != "secret_names.#" {
+				names = append(names, v)
+			}
+		}
+		return parse.NewSecretSetSecretsID(*id, names).ID(), nil
+	}
+	return step
+}
+
+func (KeyVaultSecretSetResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.SecretSetID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	keyVaultId := commonids.NewKeyVaultID(id.SubscriptionId, id.ResourceGroup, id.VaultName)
+	keyVaultBaseUrl, err := clients.KeyVault.BaseUriForKeyVault(ctx, keyVaultId)
+	if err != nil {
+		return nil, fmt.Errorf("looking up the vault url for %s: %+v", keyVaultId, err)
+	}
+
+	for k, v := range state.Attributes {
+		if !strings.HasPrefix(k, "secret_ids.") || k == "secret_ids.%" {
+			continue
+		}
+
+		name := strings.TrimPrefix(k, "secret_ids.")
+		resp, err := clients.KeyVault.ManagementClient.GetSecret(ctx, *keyVaultBaseUrl, name, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return utils.Bool(false), nil
+			}
+			return nil, fmt.Errorf("retrieving Secret %q: %+v", name, err)
+		}
+		if resp.ID == nil || *resp.ID != v {
+			return nil, fmt.Errorf("expected the latest version of Secret %q to be %q but got %v", name, v, resp.ID)
+		}
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r KeyVaultSecretSetResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_secret_set" "test" {
+  name         = "set-%s"
+  key_vault_id = azurerm_key_vault.test.id
+
+  secret {
+    name  = "first"
+    value = "rick-and-morty"
+  }
+
+  secret {
+    name         = "second"
+    value        = "<rick><morty /></rick>"
+    content_type = "application/xml"
+
+    tags = {
+      hello = "world"
+    }
+  }
+}
+`, KeyVaultSecretResource{}.template(data), data.RandomString)
+}
+
+func (r KeyVaultSecretSetResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_secret_set" "import" {
+  name         = azurerm_key_vault_secret_set.test.name
+  key_vault_id = azurerm_key_vault_secret_set.test.key_vault_id
+
+  secret {
+    name  = "first"
+    value = "rick-and-morty"
+  }
+}
+`, r.basic(data))
+}
+
+func (r KeyVaultSecretSetResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_secret_set" "test" {
+  name         = "set-%s"
+  key_vault_id = azurerm_key_vault.test.id
+  parallelism  = 2
+
+  secret {
+    name            = "first"
+    value           = "mad-scientist"
+    expiration_date = "2030-01-01T01:02:03Z"
+  }
+
+  secret {
+    name         = "second"
+    value        = "<rick><morty /></rick>"
+    content_type = "application/xml"
+
+    tags = {
+      hello = "universe"
+    }
+  }
+}
+`, KeyVaultSecretResource{}.template(data), data.RandomString)
+}
+
+func (r KeyVaultSecretSetResource) rotation(data acceptance.TestData, trigger string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_secret_set" "test" {
+  name         = "set-%s"
+  key_vault_id = azurerm_key_vault.test.id
+
+  secret {
+    name  = "first"
+    value = "rick-and-morty"
+  }
+
+  secret {
+    name  = "second"
+    value = "mad-scientist"
+  }
+
+  rotation {
+    interval = "720h"
+    trigger  = %q
+  }
+}
+`, KeyVaultSecretResource{}.template(data), data.RandomString, trigger)
+}
@@ -0,0 +1,163 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	managedHSMRoleAssignmentsSegment = "roleAssignments"
+	managedHSMRoleDefinitionsSegment = "roleDefinitions"
+)
+
+type ManagedHSMRoleAssignmentId struct {
+	ManagedHSMBaseUrl string
+	Scope             string
+	Name              string
+}
+
+func NewManagedHSMRoleAssignmentID(managedHSMBaseUrl, scope, name string) (*ManagedHSMRoleAssignmentId, error) {
+	baseUrl, err := normalizeManagedHSMBaseUrl(managedHSMBaseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManagedHSMRoleAssignmentId{
+		ManagedHSMBaseUrl: *baseUrl,
+		Scope:             scope,
+		Name:              name,
+	}, nil
+}
+
+func (id ManagedHSMRoleAssignmentId) String() string {
+	components := []string{
+		fmt.Sprintf("Base Url %q", id.ManagedHSMBaseUrl),
+		fmt.Sprintf("Scope %q", id.Scope),
+		fmt.Sprintf("Name %q", id.Name),
+	}
+	return fmt.Sprintf("Managed HSM Role Assignment: (%s)", strings.Join(components, " / "))
+}
+
+func (id ManagedHSMRoleAssignmentId) ID() string {
+	// example: https://example-hsm.managedhsm.azure.net/keys/providers/Microsoft.Authorization/roleAssignments/00000000-0000-0000-0000-000000000000
+	return managedHSMRoleID(id.ManagedHSMBaseUrl, id.Scope, managedHSMRoleAssignmentsSegment, id.Name)
+}
+
+// ManagedHSMRoleAssignmentID parses a Managed HSM Role Assignment ID into a ManagedHSMRoleAssignmentId struct
+func ManagedHSMRoleAssignmentID(input string) (*ManagedHSMRoleAssignmentId, error) {
+	baseUrl, scope, name, err := parseManagedHSMRoleID(input, managedHSMRoleAssignmentsSegment)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Managed HSM Role Assignment ID %q: %+v", input, err)
+	}
+
+	return &ManagedHSMRoleAssignmentId{
+		ManagedHSMBaseUrl: *baseUrl,
+		Scope:             *scope,
+		Name:              *name,
+	}, nil
+}
+
+type ManagedHSMRoleDefinitionId struct {
+	ManagedHSMBaseUrl string
+	Scope             string
+	Name              string
+}
+
+func NewManagedHSMRoleDefinitionID(managedHSMBaseUrl, scope, name string) (*ManagedHSMRoleDefinitionId, error) {
+	baseUrl, err := normalizeManagedHSMBaseUrl(managedHSMBaseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManagedHSMRoleDefinitionId{
+		ManagedHSMBaseUrl: *baseUrl,
+		Scope:             scope,
+		Name:              name,
+	}, nil
+}
+
+func (id ManagedHSMRoleDefinitionId) String() string {
+	components := []string{
+		fmt.Sprintf("Base Url %q", id.ManagedHSMBaseUrl),
+		fmt.Sprintf("Scope %q", id.Scope),
+		fmt.Sprintf("Name %q", id.Name),
+	}
+	return fmt.Sprintf("Managed HSM Role Definition: (%s)", strings.Join(components, " / "))
+}
+
+func (id ManagedHSMRoleDefinitionId) ID() string {
+	// example: https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleDefinitions/00000000-0000-0000-0000-000000000000
+	return managedHSMRoleID(id.ManagedHSMBaseUrl, id.Scope, managedHSMRoleDefinitionsSegment, id.Name)
+}
+
+// ManagedHSMRoleDefinitionID parses a Managed HSM Role Definition ID into a ManagedHSMRoleDefinitionId struct
+func ManagedHSMRoleDefinitionID(input string) (*ManagedHSMRoleDefinitionId, error) {
+	baseUrl, scope, name, err := parseManagedHSMRoleID(input, managedHSMRoleDefinitionsSegment)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Managed HSM Role Definition ID %q: %+v", input, err)
+	}
+
+	return &ManagedHSMRoleDefinitionId{
+		ManagedHSMBaseUrl: *baseUrl,
+		Scope:             *scope,
+		Name:              *name,
+	}, nil
+}
+
+func normalizeManagedHSMBaseUrl(input string) (*string, error) {
+	baseUrl, err := url.Parse(input)
+	if err != nil || input == "" {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+	if hostParts := strings.Split(baseUrl.Host, ":"); len(hostParts) > 1 {
+		baseUrl.Host = hostParts[0]
+	}
+
+	result := fmt.Sprintf("%s://%s/", baseUrl.Scheme, baseUrl.Host)
+	return &result, nil
+}
+
+func managedHSMRoleID(baseUrl, scope, resourceType, name string) string {
+	scope = strings.Trim(scope, "/")
+	segments := []string{
+		strings.TrimSuffix(baseUrl, "/"),
+	}
+	if scope != "" {
+		segments = append(segments, scope)
+	}
+	segments = append(segments, "providers", "Microsoft.Authorization", resourceType, name)
+	return strings.Join(segments, "/")
+}
+
+// parseManagedHSMRoleID parses an ID in the format `{baseUrl}{scope}/providers/Microsoft.Authorization/{resourceType}/{name}`
+// where the scope is either `/` or a path within the Managed HSM (for example `/keys` or `/keys/example`)
+func parseManagedHSMRoleID(input, resourceType string) (baseUrl, scope, name *string, err error) {
+	idURL, err := url.ParseRequestURI(input)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if idURL.Host == "" {
+		return nil, nil, nil, fmt.Errorf("expected the ID to contain the Managed HSM Base Url")
+	}
+
+	path := strings.TrimSuffix(idURL.Path, "/")
+	separator := fmt.Sprintf("/providers/Microsoft.Authorization/%s/", resourceType)
+	index := strings.LastIndex(strings.ToLower(path), strings.ToLower(separator))
+	if index == -1 {
+		return nil, nil, nil, fmt.Errorf("expected the path to contain %q", separator)
+	}
+
+	scopeValue := path[:index]
+	if scopeValue == "" {
+		scopeValue = "/"
+	}
+
+	nameValue := path[index+len(separator):]
+	if nameValue == "" || strings.Contains(nameValue, "/") {
+		return nil, nil, nil, fmt.Errorf("expected a single segment for the name but got %q", nameValue)
+	}
+
+	baseUrlValue := fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host)
+	return &baseUrlValue, &scopeValue, &nameValue, nil
+}
@@ -0,0 +1,210 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+var _ resourceids.Id = ManagedHSMRoleAssignmentId{}
+var _ resourceids.Id = ManagedHSMRoleDefinitionId{}
+
+func TestManagedHSMRoleAssignmentIDFormatter(t *testing.T) {
+	testData := []struct {
+		BaseUrl  string
+		Scope    string
+		Expected string
+	}{
+		{
+			BaseUrl:  "https://example-hsm.managedhsm.azure.net",
+			Scope:    "/",
+			Expected: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleAssignments/assignment1",
+		},
+		{
+			BaseUrl:  "https://example-hsm.managedhsm.azure.net:443/",
+			Scope:    "/keys",
+			Expected: "https://example-hsm.managedhsm.azure.net/keys/providers/Microsoft.Authorization/roleAssignments/assignment1",
+		},
+		{
+			BaseUrl:  "https://example-hsm.managedhsm.azure.net/",
+			Scope:    "/keys/key1",
+			Expected: "https://example-hsm.managedhsm.azure.net/keys/key1/providers/Microsoft.Authorization/roleAssignments/assignment1",
+		},
+	}
+
+	for _, v := range testData {
+		actual, err := NewManagedHSMRoleAssignmentID(v.BaseUrl, v.Scope, "assignment1")
+		if err != nil {
+			t.Fatalf("Error occurred when creating ID: %+v", err)
+		}
+		if actual.ID() != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual.ID())
+		}
+	}
+}
+
+func TestManagedHSMRoleAssignmentID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *ManagedHSMRoleAssignmentId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+		{
+			// missing path
+			Input: "https://example-hsm.managedhsm.azure.net",
+			Error: true,
+		},
+		{
+			// missing name
+			Input: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleAssignments/",
+			Error: true,
+		},
+		{
+			// role definition
+			Input: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleDefinitions/definition1",
+			Error: true,
+		},
+		{
+			// additional segment after the name
+			Input: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleAssignments/assignment1/extra",
+			Error: true,
+		},
+		{
+			// global scope
+			Input: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleAssignments/assignment1",
+			Expected: &ManagedHSMRoleAssignmentId{
+				ManagedHSMBaseUrl: "https://example-hsm.managedhsm.azure.net/",
+				Scope:             "/",
+				Name:              "assignment1",
+			},
+		},
+		{
+			// keys scope
+			Input: "https://example-hsm.managedhsm.azure.net/keys/providers/Microsoft.Authorization/roleAssignments/assignment1",
+			Expected: &ManagedHSMRoleAssignmentId{
+				ManagedHSMBaseUrl: "https://example-hsm.managedhsm.azure.net/",
+				Scope:             "/keys",
+				Name:              "assignment1",
+			},
+		},
+		{
+			// key scope
+			Input: "https://example-hsm.managedhsm.azure.net/keys/key1/providers/Microsoft.Authorization/roleAssignments/assignment1",
+			Expected: &ManagedHSMRoleAssignmentId{
+				ManagedHSMBaseUrl: "https://example-hsm.managedhsm.azure.net/",
+				Scope:             "/keys/key1",
+				Name:              "assignment1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := ManagedHSMRoleAssignmentID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.ManagedHSMBaseUrl != v.Expected.ManagedHSMBaseUrl {
+			t.Fatalf("Expected %q but got %q for ManagedHSMBaseUrl", v.Expected.ManagedHSMBaseUrl, actual.ManagedHSMBaseUrl)
+		}
+		if actual.Scope != v.Expected.Scope {
+			t.Fatalf("Expected %q but got %q for Scope", v.Expected.Scope, actual.Scope)
+		}
+		if actual.Name != v.Expected.Name {
+			t.Fatalf("Expected %q but got %q for Name", v.Expected.Name, actual.Name)
+		}
+	}
+}
+
+func TestManagedHSMRoleDefinitionIDFormatter(t *testing.T) {
+	actual, err := NewManagedHSMRoleDefinitionID("https://example-hsm.managedhsm.azure.net", "/", "definition1")
+	if err != nil {
+		t.Fatalf("Error occurred when creating ID: %+v", err)
+	}
+	expected := "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleDefinitions/definition1"
+	if actual.ID() != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual.ID())
+	}
+}
+
+func TestManagedHSMRoleDefinitionID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *ManagedHSMRoleDefinitionId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+		{
+			// relative path
+			Input: "/providers/Microsoft.Authorization/roleDefinitions/definition1",
+			Error: true,
+		},
+		{
+			// role assignment
+			Input: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleAssignments/assignment1",
+			Error: true,
+		},
+		{
+			// valid
+			Input: "https://example-hsm.managedhsm.azure.net/providers/Microsoft.Authorization/roleDefinitions/definition1",
+			Expected: &ManagedHSMRoleDefinitionId{
+				ManagedHSMBaseUrl: "https://example-hsm.managedhsm.azure.net/",
+				Scope:             "/",
+				Name:              "definition1",
+			},
+		},
+		{
+			// mixed casing
+			Input: "https://example-hsm.managedhsm.azure.net/PROVIDERS/microsoft.authorization/ROLEDEFINITIONS/definition1",
+			Expected: &ManagedHSMRoleDefinitionId{
+				ManagedHSMBaseUrl: "https://example-hsm.managedhsm.azure.net/",
+				Scope:             "/",
+				Name:              "definition1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := ManagedHSMRoleDefinitionID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.ManagedHSMBaseUrl != v.Expected.ManagedHSMBaseUrl {
+			t.Fatalf("Expected %q but got %q for ManagedHSMBaseUrl", v.Expected.ManagedHSMBaseUrl, actual.ManagedHSMBaseUrl)
+		}
+		if actual.Scope != v.Expected.Scope {
+			t.Fatalf("Expected %q but got %q for Scope", v.Expected.Scope, actual.Scope)
+		}
+		if actual.Name != v.Expected.Name {
+			t.Fatalf("Expected %q but got %q for Name", v.Expected.Name, actual.Name)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package parse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SecretSetSecretsId is the ID used to import a Key Vault Secret Set, which includes the names of the Secrets within
+// it - since the Secret Set only exists within Terraform, the names of the Secrets are required to be able to import it
+type SecretSetSecretsId struct {
+	SecretSet   SecretSetId
+	SecretNames []string
+}
+
+func NewSecretSetSecretsID(secretSet SecretSetId, secretNames []string) SecretSetSecretsId {
+	names := make([]string, len(secretNames))
+	copy(names, secretNames)
+	sort.Strings(names)
+
+	return SecretSetSecretsId{
+		SecretSet:   secretSet,
+		SecretNames: names,
+	}
+}
+
+func (id SecretSetSecretsId) String() string {
+	return fmt.Sprintf("%s / Secrets %q", id.SecretSet.String(), id.SecretNames)
+}
+
+func (id SecretSetSecretsId) ID() string {
+	return fmt.Sprintf("%s|%s", id.SecretSet.ID(), strings.Join(id.SecretNames, ","))
+}
+
+// SecretSetSecretsID parses a SecretSetSecrets ID in the format `{secretSetId}|{secretName1},{secretName2}`
+func SecretSetSecretsID(input string) (*SecretSetSecretsId, error) {
+	segments := strings.Split(input, "|")
+	if len(segments) != 2 {
+		return nil, fmt.Errorf("expected an ID in the format `{secretSetId}|{secretName1},{secretName2}` but got %q", input)
+	}
+
+	secretSetId, err := SecretSetID(segments[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing Secret Set ID %q: %+v", segments[0], err)
+	}
+
+	names := strings.Split(segments[1], ",")
+	for _, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("expected the Secret names %q to be a comma-separated list of non-empty names", segments[1])
+		}
+	}
+
+	id := NewSecretSetSecretsID(*secretSetId, names)
+	return &id, nil
+}
@@ -0,0 +1,91 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSecretSetSecretsID(t *testing.T) {
+	testData := []struct {
+		Name   string
+		Input  string
+		Error  bool
+		Expect *SecretSetSecretsId
+	}{
+		{
+			Name:  "Empty",
+			Input: "",
+			Error: true,
+		},
+		{
+			Name:  "Secret Set ID",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1/secretSets/set1",
+			Error: true,
+		},
+		{
+			Name:  "Invalid Secret Set ID",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1|secret1",
+			Error: true,
+		},
+		{
+			Name:  "Missing Secret Names",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1/secretSets/set1|",
+			Error: true,
+		},
+		{
+			Name:  "Empty Secret Name",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1/secretSets/set1|secret1,,secret2",
+			Error: true,
+		},
+		{
+			Name:  "Too Many Segments",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1/secretSets/set1|secret1|secret2",
+			Error: true,
+		},
+		{
+			Name:  "Single Secret",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1/secretSets/set1|secret1",
+			Expect: &SecretSetSecretsId{
+				SecretSet:   NewSecretSetID("12345678-1234-9876-4563-123456789012", "resGroup1", "vault1", "set1"),
+				SecretNames: []string{"secret1"},
+			},
+		},
+		{
+			Name:  "Multiple Secrets",
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1/secretSets/set1|second,first",
+			Expect: &SecretSetSecretsId{
+				SecretSet:   NewSecretSetID("12345678-1234-9876-4563-123456789012", "resGroup1", "vault1", "set1"),
+				SecretNames: []string{"first", "second"},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := SecretSetSecretsID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(*actual, *v.Expect) {
+			t.Fatalf("Expected %+v but got %+v", *v.Expect, *actual)
+		}
+	}
+}
+
+func TestSecretSetSecretsIDFormatter(t *testing.T) {
+	secretSetId := NewSecretSetID("12345678-1234-9876-4563-123456789012", "resGroup1", "vault1", "set1")
+	actual := NewSecretSetSecretsID(secretSetId, []string{"second", "first"}).ID()
+	expected := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.KeyVault/vaults/vault1/secretSets/set1|first,second"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
@@ -50,7 +50,9 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_key_vault_certificate_issuer":                           resourceKeyVaultCertificateIssuer(),
 		"azurerm_key_vault_key":                                          resourceKeyVaultKey(),
 		"azurerm_key_vault_managed_hardware_security_module":             resourceKeyVaultManagedHardwareSecurityModule(),
+		"azurerm_key_vault_managed_hardware_security_module_key":         resourceKeyVaultManagedHardwareSecurityModuleKey(),
 		"azurerm_key_vault_secret":                                       resourceKeyVaultSecret(),
+		"azurerm_key_vault_secret_set":                                   resourceKeyVaultSecretSet(),
 		"azurerm_key_vault":                                              resourceKeyVault(),
 		"azurerm_key_vault_managed_storage_account":                      resourceKeyVaultManagedStorageAccount(),
 		"azurerm_key_vault_managed_storage_account_sas_token_definition": resourceKeyVaultManagedStorageAccountSasTokenDefinition(),
@@ -66,5 +68,7 @@ func (r Registration) DataSources() []sdk.DataSource {
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		KeyVaultCertificateContactsResource{},
+		KeyVaultManagedHardwareSecurityModuleRoleAssignmentResource{},
+		KeyVaultManagedHardwareSecurityModuleRoleDefinitionResource{},
 	}
 }
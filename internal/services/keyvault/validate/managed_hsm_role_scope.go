@@ -0,0 +1,21 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ManagedHSMRoleScope validates the scope of a Managed HSM Role Assignment, which is either the whole
+// Managed HSM (`/`), all Keys within the Managed HSM (`/keys`) or a single Key (`/keys/{name}`)
+func ManagedHSMRoleScope(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return warnings, append(errors, fmt.Errorf("expected type of %s to be string", k))
+	}
+
+	if !regexp.MustCompile(`^/(keys(/[a-zA-Z\d-]{1,127})?)?$`).MatchString(v) {
+		errors = append(errors, fmt.Errorf("%s must be either `/`, `/keys` or `/keys/{name}` but got %q", k, v))
+	}
+
+	return warnings, errors
+}
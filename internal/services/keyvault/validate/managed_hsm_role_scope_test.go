@@ -0,0 +1,52 @@
+package validate
+
+import "testing"
+
+func TestManagedHSMRoleScope(t *testing.T) {
+	cases := []struct {
+		Input       string
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			Input:       "/",
+			ExpectError: false,
+		},
+		{
+			Input:       "/keys",
+			ExpectError: false,
+		},
+		{
+			Input:       "/keys/",
+			ExpectError: true,
+		},
+		{
+			Input:       "/keys/example-key1",
+			ExpectError: false,
+		},
+		{
+			Input:       "/keys/example-key1/version",
+			ExpectError: true,
+		},
+		{
+			Input:       "/secrets",
+			ExpectError: true,
+		},
+		{
+			Input:       "keys",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := ManagedHSMRoleScope(tc.Input, "scope")
+
+		hasError := len(errors) > 0
+		if tc.ExpectError != hasError {
+			t.Fatalf("expected an error to be %t for %q but got %t", tc.ExpectError, tc.Input, hasError)
+		}
+	}
+}
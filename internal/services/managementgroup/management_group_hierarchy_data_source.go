@@ -0,0 +1,305 @@
+package managementgroup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-05-01/managementgroups" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managementgroup/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managementgroup/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceManagementGroupHierarchy() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceManagementGroupHierarchyRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "display_name", "display_name_path"},
+				ValidateFunc: validate.ManagementGroupName,
+			},
+
+			"display_name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "display_name", "display_name_path"},
+			},
+
+			"display_name_path": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "display_name", "display_name_path"},
+				MinItems:     1,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"parent_management_group_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"management_groups": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"display_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"display_name_path": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						},
+
+						"parent_management_group_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"depth": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"subscriptions": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"subscription_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"display_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"parent_management_group_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"depth": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceManagementGroupHierarchyRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).ManagementGroups.GroupsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	groupName := d.Get("name").(string)
+	displayName := d.Get("display_name").(string)
+	displayNamePath := make([]string, 0)
+	for _, v := range d.Get("display_name_path").([]interface{}) {
+		displayNamePath = append(displayNamePath, v.(string))
+	}
+
+	// one of groupName, displayName and displayNamePath must be non-empty, this is guaranteed by schema
+	// when looking up by display name (or a path of display names) use the list api to get the group name first
+	var err error
+	if displayName != "" {
+		groupName, err = getManagementGroupNameByDisplayName(ctx, client, displayName)
+		if err != nil {
+			return fmt.Errorf("reading Management Group (Display Name %q): %+v", displayName, err)
+		}
+	}
+	if len(displayNamePath) > 0 {
+		groupName, err = getManagementGroupNameByDisplayName(ctx, client, displayNamePath[0])
+		if err != nil {
+			return fmt.Errorf("reading Management Group (Display Name %q): %+v", displayNamePath[0], err)
+		}
+	}
+
+	recurse := true
+	resp, err := client.Get(ctx, groupName, "children", &recurse, "", managementGroupCacheControl)
+	if err != nil {
+		if utils.ResponseWasForbidden(resp.Response) {
+			return fmt.Errorf("Management Group %q was not found", groupName)
+		}
+
+		return fmt.Errorf("reading Management Group %q: %+v", groupName, err)
+	}
+
+	props := resp.Properties
+	if props == nil {
+		return fmt.Errorf("retrieving Management Group %q: `properties` was nil", groupName)
+	}
+
+	parentId := ""
+	if details := props.Details; details != nil {
+		if parent := details.Parent; parent != nil && parent.ID != nil {
+			parentId = *parent.ID
+		}
+	}
+
+	root := managementgroups.ChildInfo{
+		Type:        managementgroups.Type1MicrosoftManagementmanagementGroups,
+		ID:          resp.ID,
+		Name:        resp.Name,
+		DisplayName: props.DisplayName,
+		Children:    props.Children,
+	}
+	rootPath := []string{utils.NormalizeNilableString(props.DisplayName)}
+
+	// walk the remainder of the display name path down through the (already recursively expanded) children
+	if len(displayNamePath) > 1 {
+		for i, segment := range displayNamePath[1:] {
+			child, err := findManagementGroupChildByDisplayName(root.Children, segment)
+			if err != nil {
+				return fmt.Errorf("resolving Display Name Path %q: %+v", strings.Join(displayNamePath[:i+2], "/"), err)
+			}
+
+			parentId = utils.NormalizeNilableString(root.ID)
+			root = *child
+			rootPath = append(rootPath, segment)
+		}
+	}
+
+	if root.Name == nil || *root.Name == "" {
+		return fmt.Errorf("retrieving Management Group %q: `name` was nil", groupName)
+	}
+
+	id := parse.NewManagementGroupId(*root.Name)
+	d.SetId(id.ID())
+	d.Set("name", id.Name)
+	d.Set("display_name", root.DisplayName)
+	d.Set("display_name_path", rootPath)
+	d.Set("parent_management_group_id", parentId)
+
+	managementGroups := make([]interface{}, 0)
+	subscriptions := make([]interface{}, 0)
+	if err := flattenManagementGroupHierarchy(&managementGroups, &subscriptions, root.Children, id.ID(), rootPath, 1); err != nil {
+		return fmt.Errorf("flattening descendants of %s: %+v", id, err)
+	}
+	if err := d.Set("management_groups", managementGroups); err != nil {
+		return fmt.Errorf("setting `management_groups`: %+v", err)
+	}
+	if err := d.Set("subscriptions", subscriptions); err != nil {
+		return fmt.Errorf("setting `subscriptions`: %+v", err)
+	}
+
+	return nil
+}
+
+func findManagementGroupChildByDisplayName(input *[]managementgroups.ChildInfo, displayName string) (*managementgroups.ChildInfo, error) {
+	results := make([]managementgroups.ChildInfo, 0)
+	if input != nil {
+		for _, child := range *input {
+			if child.Type != managementgroups.Type1MicrosoftManagementmanagementGroups {
+				continue
+			}
+
+			if child.DisplayName != nil && *child.DisplayName == displayName {
+				results = append(results, child)
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("child Management Group (Display Name %q) was not found", displayName)
+	}
+
+	if len(results) > 1 {
+		return nil, fmt.Errorf("expected a single child Management Group with the Display Name %q but got %d", displayName, len(results))
+	}
+
+	return &results[0], nil
+}
+
+func flattenManagementGroupHierarchy(managementGroups, subscriptions *[]interface{}, input *[]managementgroups.ChildInfo, parentId string, parentPath []string, depth int) error {
+	if input == nil {
+		return nil
+	}
+
+	for _, child := range *input {
+		if child.ID == nil {
+			continue
+		}
+
+		displayName := utils.NormalizeNilableString(child.DisplayName)
+
+		switch child.Type {
+		case managementgroups.Type1MicrosoftManagementmanagementGroups:
+			id, err := parse.ManagementGroupID(*child.ID)
+			if err != nil {
+				return fmt.Errorf("parsing child Management Group ID: %+v", err)
+			}
+
+			path := make([]string, 0, len(parentPath)+1)
+			path = append(path, parentPath...)
+			path = append(path, displayName)
+
+			*managementGroups = append(*managementGroups, map[string]interface{}{
+				"id":                         id.ID(),
+				"name":                       id.Name,
+				"display_name":               displayName,
+				"display_name_path":          path,
+				"parent_management_group_id": parentId,
+				"depth":                      depth,
+			})
+
+			if err := flattenManagementGroupHierarchy(managementGroups, subscriptions, child.Children, id.ID(), path, depth+1); err != nil {
+				return err
+			}
+
+		case managementgroups.Type1Subscriptions:
+			id, err := parseManagementGroupSubscriptionID(*child.ID)
+			if err != nil {
+				return fmt.Errorf("parsing child Subscription ID: %+v", err)
+			}
+
+			*subscriptions = append(*subscriptions, map[string]interface{}{
+				"subscription_id":            id.subscriptionId,
+				"display_name":               displayName,
+				"parent_management_group_id": parentId,
+				"depth":                      depth,
+			})
+		}
+	}
+
+	return nil
+}
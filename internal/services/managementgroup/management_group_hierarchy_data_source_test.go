@@ -0,0 +1,94 @@
+package managementgroup_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type ManagementGroupHierarchyDataSource struct{}
+
+func TestAccManagementGroupHierarchyDataSource_byName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_management_group_hierarchy", "test")
+	r := ManagementGroupHierarchyDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.byName(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest Management Group %d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("management_groups.#").HasValue("2"),
+				check.That(data.ResourceName).Key("management_groups.0.depth").HasValue("1"),
+				check.That(data.ResourceName).Key("management_groups.1.depth").HasValue("2"),
+				check.That(data.ResourceName).Key("management_groups.1.display_name_path.#").HasValue("3"),
+				check.That(data.ResourceName).Key("subscriptions.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAccManagementGroupHierarchyDataSource_byDisplayNamePath(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_management_group_hierarchy", "test")
+	r := ManagementGroupHierarchyDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.byDisplayNamePath(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest child Management Group %d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("parent_management_group_id").IsNotEmpty(),
+				check.That(data.ResourceName).Key("management_groups.#").HasValue("1"),
+				check.That(data.ResourceName).Key("management_groups.0.depth").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (ManagementGroupHierarchyDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_management_group" "test" {
+  display_name = "acctest Management Group %[1]d"
+}
+
+resource "azurerm_management_group" "child" {
+  display_name               = "acctest child Management Group %[1]d"
+  parent_management_group_id = azurerm_management_group.test.id
+}
+
+resource "azurerm_management_group" "grand_child" {
+  display_name               = "acctest grand child Management Group %[1]d"
+  parent_management_group_id = azurerm_management_group.child.id
+}
+`, data.RandomInteger)
+}
+
+func (r ManagementGroupHierarchyDataSource) byName(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_management_group_hierarchy" "test" {
+  name       = azurerm_management_group.test.name
+  depends_on = [azurerm_management_group.grand_child]
+}
+`, r.template(data))
+}
+
+func (r ManagementGroupHierarchyDataSource) byDisplayNamePath(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_management_group_hierarchy" "test" {
+  display_name_path = [
+    azurerm_management_group.test.display_name,
+    azurerm_management_group.child.display_name,
+  ]
+  depends_on = [azurerm_management_group.grand_child]
+}
+`, r.template(data))
+}
@@ -28,7 +28,8 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurerm_management_group": dataSourceManagementGroup(),
+		"azurerm_management_group":           dataSourceManagementGroup(),
+		"azurerm_management_group_hierarchy": dataSourceManagementGroupHierarchy(),
 	}
 }
 
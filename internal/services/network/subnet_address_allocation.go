@@ -0,0 +1,228 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+const (
+	subnetAddressAllocationIPv4 = "IPv4"
+	subnetAddressAllocationIPv6 = "IPv6"
+)
+
+type subnetAddressPrefixAllocation struct {
+	PrefixLength int
+	IPVersion    string
+}
+
+// validate returns an error when the prefix length isn't valid for the IP Version, since IPv4 prefixes
+// can be at most 32 bits long whereas IPv6 prefixes can be up to 128 bits long
+func (a subnetAddressPrefixAllocation) validate() error {
+	maxPrefixLength := 128
+	if a.IPVersion == subnetAddressAllocationIPv4 {
+		maxPrefixLength = 32
+	}
+
+	if a.PrefixLength < 1 || a.PrefixLength > maxPrefixLength {
+		return fmt.Errorf("expected the prefix length for an %s address prefix to be between 1 and %d but got %d", a.IPVersion, maxPrefixLength, a.PrefixLength)
+	}
+
+	return nil
+}
+
+// subnetAddressPrefixesSatisfyAllocations returns whether the existing address prefixes are exactly the blocks
+// which were requested - that is, there's one existing address prefix of the requested length and IP Version
+// for each allocation, meaning that the Subnet doesn't need to be recreated to allocate them
+func subnetAddressPrefixesSatisfyAllocations(existing []string, allocations []subnetAddressPrefixAllocation) bool {
+	if len(existing) != len(allocations) {
+		return false
+	}
+
+	remaining := make(map[subnetAddressPrefixAllocation]int)
+	for _, v := range existing {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return false
+		}
+
+		ipVersion := subnetAddressAllocationIPv6
+		if prefix.Addr().Is4() {
+			ipVersion = subnetAddressAllocationIPv4
+		}
+		remaining[subnetAddressPrefixAllocation{PrefixLength: prefix.Bits(), IPVersion: ipVersion}]++
+	}
+
+	for _, allocation := range allocations {
+		if remaining[allocation] == 0 {
+			return false
+		}
+		remaining[allocation]--
+	}
+
+	return true
+}
+
+// allocateSubnetAddressPrefix returns the first block of the specified prefix length within the address
+// space which doesn't overlap any of the prefixes which are already in use. Blocks are allocated in order
+// (lowest address first) so that the same inputs always result in the same allocation.
+func allocateSubnetAddressPrefix(addressSpace []string, usedPrefixes []string, prefixLength int, ipVersion string) (*string, error) {
+	spaces, err := parseSubnetAddressPrefixes(addressSpace, ipVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the address space: %+v", err)
+	}
+	if len(spaces) == 0 {
+		return nil, fmt.Errorf("the address space doesn't contain any %s address ranges", ipVersion)
+	}
+
+	used, err := parseSubnetAddressPrefixes(usedPrefixes, ipVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the address prefixes which are in use: %+v", err)
+	}
+
+	for _, space := range spaces {
+		if prefixLength < space.Bits() || prefixLength > space.Addr().BitLen() {
+			continue
+		}
+
+		blockSize := new(big.Int).Lsh(big.NewInt(1), uint(space.Addr().BitLen()-prefixLength))
+		spaceEnd := lastAddressInPrefix(space)
+
+		start := addressToInt(space.Addr())
+		for start.Cmp(spaceEnd) <= 0 {
+			candidate := netip.PrefixFrom(intToAddress(start, space.Addr().Is4()), prefixLength)
+
+			var overlapping *netip.Prefix
+			for i := range used {
+				if used[i].Overlaps(candidate) {
+					overlapping = &used[i]
+					break
+				}
+			}
+			if overlapping == nil {
+				result := candidate.String()
+				return &result, nil
+			}
+
+			// skip past the prefix which is in use, aligned to the next block of the requested size
+			next := new(big.Int).Add(lastAddressInPrefix(*overlapping), big.NewInt(1))
+			if candidateEnd := new(big.Int).Add(lastAddressInPrefix(candidate), big.NewInt(1)); candidateEnd.Cmp(next) > 0 {
+				next = candidateEnd
+			}
+			offset := new(big.Int).Sub(next, addressToInt(space.Addr()))
+			remainder := new(big.Int).Mod(offset, blockSize)
+			if remainder.Sign() != 0 {
+				next.Add(next, new(big.Int).Sub(blockSize, remainder))
+			}
+			start = next
+		}
+	}
+
+	return nil, fmt.Errorf("no free /%d %s address range is available within the address space %q", prefixLength, ipVersion, strings.Join(addressSpace, ", "))
+}
+
+// freeSubnetAddressRanges returns the smallest set of CIDR blocks covering the addresses within the address
+// space which aren't used by any of the specified prefixes.
+func freeSubnetAddressRanges(addressSpace []string, usedPrefixes []string) ([]string, error) {
+	spaces, err := parseSubnetAddressPrefixes(addressSpace, "")
+	if err != nil {
+		return nil, fmt.Errorf("parsing the address space: %+v", err)
+	}
+
+	used, err := parseSubnetAddressPrefixes(usedPrefixes, "")
+	if err != nil {
+		return nil, fmt.Errorf("parsing the address prefixes which are in use: %+v", err)
+	}
+
+	results := make([]string, 0)
+	for _, space := range spaces {
+		results = append(results, subtractSubnetAddressPrefixes(space, used)...)
+	}
+
+	return results, nil
+}
+
+func subtractSubnetAddressPrefixes(space netip.Prefix, used []netip.Prefix) []string {
+	fullyUsed := false
+	partiallyUsed := false
+	for _, u := range used {
+		if !u.Overlaps(space) {
+			continue
+		}
+		if u.Bits() <= space.Bits() {
+			fullyUsed = true
+			break
+		}
+		partiallyUsed = true
+	}
+
+	if fullyUsed {
+		return []string{}
+	}
+	if !partiallyUsed {
+		return []string{space.String()}
+	}
+
+	// split the range in half and check each half
+	lower := netip.PrefixFrom(space.Addr(), space.Bits()+1)
+	upperStart := new(big.Int).Add(lastAddressInPrefix(lower), big.NewInt(1))
+	upper := netip.PrefixFrom(intToAddress(upperStart, space.Addr().Is4()), space.Bits()+1)
+
+	results := subtractSubnetAddressPrefixes(lower, used)
+	return append(results, subtractSubnetAddressPrefixes(upper, used)...)
+}
+
+// parseSubnetAddressPrefixes parses the CIDR blocks, optionally filtering them to the specified IP Version,
+// returning them sorted by address
+func parseSubnetAddressPrefixes(input []string, ipVersion string) ([]netip.Prefix, error) {
+	results := make([]netip.Prefix, 0)
+	for _, v := range input {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return nil, err
+		}
+		prefix = prefix.Masked()
+
+		if ipVersion == subnetAddressAllocationIPv4 && !prefix.Addr().Is4() {
+			continue
+		}
+		if ipVersion == subnetAddressAllocationIPv6 && prefix.Addr().Is4() {
+			continue
+		}
+
+		results = append(results, prefix)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Addr().BitLen() != results[j].Addr().BitLen() {
+			return results[i].Addr().Is4()
+		}
+		return results[i].Addr().Less(results[j].Addr())
+	})
+
+	return results, nil
+}
+
+func lastAddressInPrefix(prefix netip.Prefix) *big.Int {
+	hostBits := uint(prefix.Addr().BitLen() - prefix.Bits())
+	size := new(big.Int).Lsh(big.NewInt(1), hostBits)
+	return new(big.Int).Add(addressToInt(prefix.Masked().Addr()), new(big.Int).Sub(size, big.NewInt(1)))
+}
+
+func addressToInt(input netip.Addr) *big.Int {
+	return new(big.Int).SetBytes(input.AsSlice())
+}
+
+func intToAddress(input *big.Int, ipv4 bool) netip.Addr {
+	if ipv4 {
+		var b [4]byte
+		input.FillBytes(b[:])
+		return netip.AddrFrom4(b)
+	}
+
+	var b [16]byte
+	input.FillBytes(b[:])
+	return netip.AddrFrom16(b)
+}
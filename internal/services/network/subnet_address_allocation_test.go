@@ -0,0 +1,293 @@
+package network
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllocateSubnetAddressPrefix(t *testing.T) {
+	testData := []struct {
+		name          string
+		addressSpace  []string
+		usedPrefixes  []string
+		prefixLength  int
+		ipVersion     string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:         "empty virtual network",
+			addressSpace: []string{"10.0.0.0/16"},
+			prefixLength: 24,
+			ipVersion:    subnetAddressAllocationIPv4,
+			expected:     "10.0.0.0/24",
+		},
+		{
+			name:         "first block in use",
+			addressSpace: []string{"10.0.0.0/16"},
+			usedPrefixes: []string{"10.0.0.0/24"},
+			prefixLength: 24,
+			ipVersion:    subnetAddressAllocationIPv4,
+			expected:     "10.0.1.0/24",
+		},
+		{
+			name:         "gap between used blocks",
+			addressSpace: []string{"10.0.0.0/16"},
+			usedPrefixes: []string{"10.0.0.0/26", "10.0.0.128/25"},
+			prefixLength: 26,
+			ipVersion:    subnetAddressAllocationIPv4,
+			expected:     "10.0.0.64/26",
+		},
+		{
+			name:         "smaller used block is skipped and the result is aligned",
+			addressSpace: []string{"10.0.0.0/16"},
+			usedPrefixes: []string{"10.0.0.16/28"},
+			prefixLength: 24,
+			ipVersion:    subnetAddressAllocationIPv4,
+			expected:     "10.0.1.0/24",
+		},
+		{
+			name:         "larger used block is skipped",
+			addressSpace: []string{"10.0.0.0/16"},
+			usedPrefixes: []string{"10.0.0.0/20"},
+			prefixLength: 26,
+			ipVersion:    subnetAddressAllocationIPv4,
+			expected:     "10.0.16.0/26",
+		},
+		{
+			name:         "first address space is full",
+			addressSpace: []string{"10.0.0.0/24", "10.1.0.0/24"},
+			usedPrefixes: []string{"10.0.0.0/25", "10.0.0.128/25"},
+			prefixLength: 25,
+			ipVersion:    subnetAddressAllocationIPv4,
+			expected:     "10.1.0.0/25",
+		},
+		{
+			name:         "address space too small",
+			addressSpace: []string{"10.0.0.0/24", "10.1.0.0/16"},
+			prefixLength: 20,
+			ipVersion:    subnetAddressAllocationIPv4,
+			expected:     "10.1.0.0/20",
+		},
+		{
+			name:          "address space full",
+			addressSpace:  []string{"10.0.0.0/24"},
+			usedPrefixes:  []string{"10.0.0.0/24"},
+			prefixLength:  26,
+			ipVersion:     subnetAddressAllocationIPv4,
+			expectedError: true,
+		},
+		{
+			name:         "ipv6",
+			addressSpace: []string{"10.0.0.0/16", "ace:cab:deca::/48"},
+			usedPrefixes: []string{"10.0.0.0/24", "ace:cab:deca::/64"},
+			prefixLength: 64,
+			ipVersion:    subnetAddressAllocationIPv6,
+			expected:     "ace:cab:deca:1::/64",
+		},
+		{
+			name:          "no ipv6 address space",
+			addressSpace:  []string{"10.0.0.0/16"},
+			prefixLength:  64,
+			ipVersion:     subnetAddressAllocationIPv6,
+			expectedError: true,
+		},
+		{
+			name:          "invalid address space",
+			addressSpace:  []string{"10.0.0.0"},
+			prefixLength:  24,
+			ipVersion:     subnetAddressAllocationIPv4,
+			expectedError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := allocateSubnetAddressPrefix(v.addressSpace, v.usedPrefixes, v.prefixLength, v.ipVersion)
+		if v.expectedError {
+			if err == nil {
+				t.Fatalf("expected an error but got %q", *actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		if *actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, *actual)
+		}
+	}
+}
+
+func TestFreeSubnetAddressRanges(t *testing.T) {
+	testData := []struct {
+		name         string
+		addressSpace []string
+		usedPrefixes []string
+		expected     []string
+	}{
+		{
+			name:         "empty virtual network",
+			addressSpace: []string{"10.0.0.0/16"},
+			expected:     []string{"10.0.0.0/16"},
+		},
+		{
+			name:         "full virtual network",
+			addressSpace: []string{"10.0.0.0/24"},
+			usedPrefixes: []string{"10.0.0.0/25", "10.0.0.128/25"},
+			expected:     []string{},
+		},
+		{
+			name:         "partially used",
+			addressSpace: []string{"10.0.0.0/22"},
+			usedPrefixes: []string{"10.0.1.0/24"},
+			expected:     []string{"10.0.0.0/24", "10.0.2.0/23"},
+		},
+		{
+			name:         "multiple address spaces",
+			addressSpace: []string{"10.1.0.0/24", "10.0.0.0/24", "ace:cab:deca::/48"},
+			usedPrefixes: []string{"10.0.0.0/25", "ace:cab:deca::/49"},
+			expected:     []string{"10.0.0.128/25", "10.1.0.0/24", "ace:cab:deca:8000::/49"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := freeSubnetAddressRanges(v.addressSpace, v.usedPrefixes)
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestSubnetAddressPrefixAllocationValidate(t *testing.T) {
+	testData := []struct {
+		allocation subnetAddressPrefixAllocation
+		valid      bool
+	}{
+		{
+			allocation: subnetAddressPrefixAllocation{PrefixLength: 24, IPVersion: subnetAddressAllocationIPv4},
+			valid:      true,
+		},
+		{
+			allocation: subnetAddressPrefixAllocation{PrefixLength: 32, IPVersion: subnetAddressAllocationIPv4},
+			valid:      true,
+		},
+		{
+			allocation: subnetAddressPrefixAllocation{PrefixLength: 33, IPVersion: subnetAddressAllocationIPv4},
+			valid:      false,
+		},
+		{
+			allocation: subnetAddressPrefixAllocation{PrefixLength: 64, IPVersion: subnetAddressAllocationIPv6},
+			valid:      true,
+		},
+		{
+			allocation: subnetAddressPrefixAllocation{PrefixLength: 128, IPVersion: subnetAddressAllocationIPv6},
+			valid:      true,
+		},
+		{
+			allocation: subnetAddressPrefixAllocation{PrefixLength: 129, IPVersion: subnetAddressAllocationIPv6},
+			valid:      false,
+		},
+		{
+			allocation: subnetAddressPrefixAllocation{PrefixLength: 0, IPVersion: subnetAddressAllocationIPv4},
+			valid:      false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing /%d %s..", v.allocation.PrefixLength, v.allocation.IPVersion)
+
+		err := v.allocation.validate()
+		if v.valid && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if !v.valid && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+	}
+}
+
+func TestSubnetAddressPrefixesSatisfyAllocations(t *testing.T) {
+	testData := []struct {
+		name        string
+		existing    []string
+		allocations []subnetAddressPrefixAllocation
+		expected    bool
+	}{
+		{
+			name:     "matching IPv4 prefix",
+			existing: []string{"10.0.2.0/24"},
+			allocations: []subnetAddressPrefixAllocation{
+				{PrefixLength: 24, IPVersion: subnetAddressAllocationIPv4},
+			},
+			expected: true,
+		},
+		{
+			name:     "different prefix length",
+			existing: []string{"10.0.2.0/24"},
+			allocations: []subnetAddressPrefixAllocation{
+				{PrefixLength: 25, IPVersion: subnetAddressAllocationIPv4},
+			},
+			expected: false,
+		},
+		{
+			name:     "different IP version",
+			existing: []string{"ace:cab:deca::/64"},
+			allocations: []subnetAddressPrefixAllocation{
+				{PrefixLength: 64, IPVersion: subnetAddressAllocationIPv4},
+			},
+			expected: false,
+		},
+		{
+			name:     "dual stack in a different order",
+			existing: []string{"ace:cab:deca::/64", "10.0.2.0/24"},
+			allocations: []subnetAddressPrefixAllocation{
+				{PrefixLength: 24, IPVersion: subnetAddressAllocationIPv4},
+				{PrefixLength: 64, IPVersion: subnetAddressAllocationIPv6},
+			},
+			expected: true,
+		},
+		{
+			name:     "additional allocation",
+			existing: []string{"10.0.2.0/24"},
+			allocations: []subnetAddressPrefixAllocation{
+				{PrefixLength: 24, IPVersion: subnetAddressAllocationIPv4},
+				{PrefixLength: 64, IPVersion: subnetAddressAllocationIPv6},
+			},
+			expected: false,
+		},
+		{
+			name:     "duplicate allocations",
+			existing: []string{"10.0.2.0/24", "10.0.3.0/25"},
+			allocations: []subnetAddressPrefixAllocation{
+				{PrefixLength: 24, IPVersion: subnetAddressAllocationIPv4},
+				{PrefixLength: 24, IPVersion: subnetAddressAllocationIPv4},
+			},
+			expected: false,
+		},
+		{
+			name:     "invalid existing prefix",
+			existing: []string{"not-a-prefix"},
+			allocations: []subnetAddressPrefixAllocation{
+				{PrefixLength: 24, IPVersion: subnetAddressAllocationIPv4},
+			},
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := subnetAddressPrefixesSatisfyAllocations(v.existing, v.allocations); actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -72,6 +72,8 @@ func resourceSubnet() *pluginsdk.Resource {
 			return err
 		}),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceSubnetCustomizeDiff),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -96,12 +98,40 @@ func resourceSubnet() *pluginsdk.Resource {
 
 			"address_prefixes": {
 				Type:     pluginsdk.TypeList,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				MinItems: 1,
 				Elem: &pluginsdk.Schema{
 					Type:         pluginsdk.TypeString,
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
+				ExactlyOneOf: []string{"address_prefixes", "address_prefix_allocation"},
+			},
+
+			"address_prefix_allocation": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 2,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"prefix_length": {
+							Type:         pluginsdk.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 128),
+						},
+
+						"ip_version": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+							Default:  subnetAddressAllocationIPv4,
+							ValidateFunc: validation.StringInSlice([]string{
+								subnetAddressAllocationIPv4,
+								subnetAddressAllocationIPv6,
+							}, false),
+						},
+					},
+				},
+				ExactlyOneOf: []string{"address_prefixes", "address_prefix_allocation"},
 			},
 
 			"service_endpoints": {
@@ -263,6 +293,14 @@ func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		}
 		properties.AddressPrefixes = &addressPrefixes
 	}
+	if value, ok := d.GetOk("address_prefix_allocation"); ok {
+		// the Virtual Network is locked above, so no other Subnet can be allocated the same address prefix
+		addressPrefixes, err := allocateSubnetAddressPrefixes(ctx, vnetClient, id, value.([]interface{}))
+		if err != nil {
+			return err
+		}
+		properties.AddressPrefixes = addressPrefixes
+	}
 	if properties.AddressPrefixes != nil && len(*properties.AddressPrefixes) == 1 {
 		properties.AddressPrefix = &(*properties.AddressPrefixes)[0]
 		properties.AddressPrefixes = nil
@@ -631,6 +669,117 @@ func resourceSubnetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func allocateSubnetAddressPrefixes(ctx context.Context, vnetClient *network.VirtualNetworksClient, id parse.SubnetId, input []interface{}) (*[]string, error) {
+	vnetId := parse.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName)
+	vnet, err := vnetClient.Get(ctx, vnetId.ResourceGroup, vnetId.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s to allocate the address prefixes for %s: %+v", vnetId, id, err)
+	}
+
+	addressSpace := make([]string, 0)
+	usedPrefixes := make([]string, 0)
+	if props := vnet.VirtualNetworkPropertiesFormat; props != nil {
+		if props.AddressSpace != nil && props.AddressSpace.AddressPrefixes != nil {
+			addressSpace = *props.AddressSpace.AddressPrefixes
+		}
+		usedPrefixes = subnetAddressPrefixesInUse(props.Subnets)
+	}
+
+	addressPrefixes := make([]string, 0)
+	for _, allocation := range expandSubnetAddressPrefixAllocations(input) {
+		prefix, err := allocateSubnetAddressPrefix(addressSpace, usedPrefixes, allocation.PrefixLength, allocation.IPVersion)
+		if err != nil {
+			return nil, fmt.Errorf("allocating a /%d %s address prefix for %s: %+v", allocation.PrefixLength, allocation.IPVersion, id, err)
+		}
+		log.Printf("[DEBUG] Allocated the address prefix %q for %s", *prefix, id)
+
+		addressPrefixes = append(addressPrefixes, *prefix)
+		usedPrefixes = append(usedPrefixes, *prefix)
+	}
+
+	return &addressPrefixes, nil
+}
+
+// resourceSubnetCustomizeDiff validates the `address_prefix_allocation` blocks and, since the address prefixes are
+// only allocated when the Subnet is created, recreates the Subnet when they're changed to blocks which the existing
+// address prefixes don't satisfy - switching to `address_prefixes` keeps the allocated address prefixes as-is
+func resourceSubnetCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	allocations := expandSubnetAddressPrefixAllocations(d.Get("address_prefix_allocation").([]interface{}))
+	allocationsKnown := d.NewValueKnown("address_prefix_allocation")
+	for i, allocation := range allocations {
+		// the prefix length can only be validated once it's known
+		if !d.NewValueKnown(fmt.Sprintf("address_prefix_allocation.%d.prefix_length", i)) || !d.NewValueKnown(fmt.Sprintf("address_prefix_allocation.%d.ip_version", i)) {
+			allocationsKnown = false
+			continue
+		}
+
+		if err := allocation.validate(); err != nil {
+			return fmt.Errorf("`address_prefix_allocation.%d`: %+v", i, err)
+		}
+	}
+
+	if d.Id() == "" || !d.HasChange("address_prefix_allocation") {
+		return nil
+	}
+	if !allocationsKnown {
+		return d.ForceNew("address_prefix_allocation")
+	}
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	existing := make([]string, 0)
+	oldAddressPrefixes, _ := d.GetChange("address_prefixes")
+	for _, v := range oldAddressPrefixes.([]interface{}) {
+		existing = append(existing, v.(string))
+	}
+	if subnetAddressPrefixesSatisfyAllocations(existing, allocations) {
+		return nil
+	}
+
+	return d.ForceNew("address_prefix_allocation")
+}
+
+func expandSubnetAddressPrefixAllocations(input []interface{}) []subnetAddressPrefixAllocation {
+	output := make([]subnetAddressPrefixAllocation, 0)
+	for _, raw := range input {
+		if raw == nil {
+			continue
+		}
+		v := raw.(map[string]interface{})
+
+		output = append(output, subnetAddressPrefixAllocation{
+			PrefixLength: v["prefix_length"].(int),
+			IPVersion:    v["ip_version"].(string),
+		})
+	}
+
+	return output
+}
+
+func subnetAddressPrefixesInUse(input *[]network.Subnet) []string {
+	results := make([]string, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, subnet := range *input {
+		props := subnet.SubnetPropertiesFormat
+		if props == nil {
+			continue
+		}
+
+		if props.AddressPrefix != nil && *props.AddressPrefix != "" {
+			results = append(results, *props.AddressPrefix)
+		}
+		if props.AddressPrefixes != nil {
+			results = append(results, *props.AddressPrefixes...)
+		}
+	}
+
+	return results
+}
+
 func expandSubnetServiceEndpoints(input []interface{}) *[]network.ServiceEndpointPropertiesFormat {
 	endpoints := make([]network.ServiceEndpointPropertiesFormat, 0)
 
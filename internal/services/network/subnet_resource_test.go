@@ -46,6 +46,41 @@ func TestAccSubnet_basic_addressPrefixes(t *testing.T) {
 	})
 }
 
+func TestAccSubnet_addressPrefixAllocation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
+	r := SubnetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.addressPrefixAllocation(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("address_prefixes.#").HasValue("1"),
+				check.That(data.ResourceName).Key("address_prefixes.0").HasValue("10.0.1.0/26"),
+			),
+		},
+		data.ImportStep("address_prefix_allocation"),
+	})
+}
+
+func TestAccSubnet_addressPrefixAllocationDualStack(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
+	r := SubnetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.addressPrefixAllocationDualStack(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("address_prefixes.#").HasValue("2"),
+				check.That(data.ResourceName).Key("address_prefixes.0").HasValue("10.0.0.0/24"),
+				check.That(data.ResourceName).Key("address_prefixes.1").HasValue("ace:cab:deca::/64"),
+			),
+		},
+		data.ImportStep("address_prefix_allocation"),
+	})
+}
+
 func TestAccSubnet_complete_addressPrefixes(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
 	r := SubnetResource{}
@@ -846,6 +881,66 @@ resource "azurerm_subnet" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
 }
 
+func (r SubnetResource) addressPrefixAllocation(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_subnet" "existing" {
+  name                 = "existing"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.0.0/24"]
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+
+  address_prefix_allocation {
+    prefix_length = 26
+  }
+
+  depends_on = [azurerm_subnet.existing]
+}
+`, r.template(data))
+}
+
+func (SubnetResource) addressPrefixAllocationDualStack(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-n-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvirtnet%d"
+  address_space       = ["10.0.0.0/16", "ace:cab:deca::/48"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctestsubnet%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+
+  address_prefix_allocation {
+    prefix_length = 24
+  }
+
+  address_prefix_allocation {
+    ip_version    = "IPv6"
+    prefix_length = 64
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
 func (r SubnetResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
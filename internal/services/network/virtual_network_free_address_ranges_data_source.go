@@ -0,0 +1,107 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceVirtualNetworkFreeAddressRanges() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceVirtualNetworkFreeAddressRangesRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"virtual_network_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"address_space": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"used_address_prefixes": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"free_address_ranges": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVirtualNetworkFreeAddressRangesRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.VnetClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewVirtualNetworkID(subscriptionId, d.Get("resource_group_name").(string), d.Get("virtual_network_name").(string))
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	// the free address ranges are a view of the Virtual Network, so use a distinct ID
+	d.SetId(fmt.Sprintf("%s/freeAddressRanges", id.ID()))
+
+	addressSpace := make([]string, 0)
+	usedPrefixes := make([]string, 0)
+	if props := resp.VirtualNetworkPropertiesFormat; props != nil {
+		if props.AddressSpace != nil && props.AddressSpace.AddressPrefixes != nil {
+			addressSpace = *props.AddressSpace.AddressPrefixes
+		}
+		usedPrefixes = subnetAddressPrefixesInUse(props.Subnets)
+	}
+
+	freeRanges, err := freeSubnetAddressRanges(addressSpace, usedPrefixes)
+	if err != nil {
+		return fmt.Errorf("determining the free address ranges within %s: %+v", id, err)
+	}
+
+	d.Set("virtual_network_name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+
+	if err := d.Set("address_space", addressSpace); err != nil {
+		return fmt.Errorf("setting `address_space`: %+v", err)
+	}
+	if err := d.Set("used_address_prefixes", usedPrefixes); err != nil {
+		return fmt.Errorf("setting `used_address_prefixes`: %+v", err)
+	}
+	if err := d.Set("free_address_ranges", freeRanges); err != nil {
+		return fmt.Errorf("setting `free_address_ranges`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package network_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type VirtualNetworkFreeAddressRangesDataSource struct{}
+
+func TestAccDataSourceVirtualNetworkFreeAddressRanges_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_virtual_network_free_address_ranges", "test")
+	r := VirtualNetworkFreeAddressRangesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").MatchesRegex(regexp.MustCompile(`/virtualNetworks/[^/]+/freeAddressRanges$`)),
+				check.That(data.ResourceName).Key("address_space.#").HasValue("2"),
+				check.That(data.ResourceName).Key("used_address_prefixes.#").HasValue("3"),
+				check.That(data.ResourceName).Key("free_address_ranges.#").HasValue("18"),
+				check.That(data.ResourceName).Key("free_address_ranges.0").HasValue("10.0.0.0/24"),
+				check.That(data.ResourceName).Key("free_address_ranges.1").HasValue("10.0.2.0/24"),
+				check.That(data.ResourceName).Key("free_address_ranges.2").HasValue("ace:cab:deca:1::/64"),
+			),
+		},
+	})
+}
+
+func (VirtualNetworkFreeAddressRangesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvnet-%d"
+  address_space       = ["10.0.0.0/22", "ace:cab:deca::/48"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "subnet1"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.1.0/24"]
+}
+
+resource "azurerm_subnet" "test2" {
+  name                 = "subnet2"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.3.0/24", "ace:cab:deca::/64"]
+}
+
+data "azurerm_virtual_network_free_address_ranges" "test" {
+  virtual_network_name = azurerm_virtual_network.test.name
+  resource_group_name  = azurerm_resource_group.test.name
+
+  depends_on = [azurerm_subnet.test, azurerm_subnet.test2]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
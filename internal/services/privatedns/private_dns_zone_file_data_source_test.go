@@ -0,0 +1,61 @@
+package privatedns_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type PrivateDnsZoneFileDataSource struct{}
+
+func TestAccPrivateDnsZoneFileDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_private_dns_zone_file", "test")
+	r := PrivateDnsZoneFileDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("zone_file").MatchesRegex(regexp.MustCompile(`(?m)^@\t\d+\tIN\tSOA\t`)),
+				check.That(data.ResourceName).Key("zone_file").MatchesRegex(regexp.MustCompile(`(?m)^www\t300\tIN\tA\t10\.0\.0\.1$`)),
+				check.That(data.ResourceName).Key("zone_file").MatchesRegex(regexp.MustCompile(`(?m)^www\t300\tIN\tA\t10\.0\.0\.2$`)),
+			),
+		},
+	})
+}
+
+func (PrivateDnsZoneFileDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_private_dns_zone" "test" {
+  name                = "testzone%d.com"
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_private_dns_a_record" "test" {
+  name                = "www"
+  zone_name           = azurerm_private_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  ttl                 = 300
+  records             = ["10.0.0.1", "10.0.0.2"]
+}
+
+data "azurerm_private_dns_zone_file" "test" {
+  zone_name           = azurerm_private_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+
+  depends_on = [azurerm_private_dns_a_record.test]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
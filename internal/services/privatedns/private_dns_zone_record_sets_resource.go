@@ -0,0 +1,404 @@
+package privatedns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/privatedns/2018-09-01/privatezones"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/privatedns/2018-09-01/recordsets"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dns/zonefile"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// privateDnsZoneRecordSetsRecordTypes are the record types which can be managed within a Private DNS Zone
+var privateDnsZoneRecordSetsRecordTypes = []string{
+	string(recordsets.RecordTypeA),
+	string(recordsets.RecordTypeAAAA),
+	string(recordsets.RecordTypeCNAME),
+	string(recordsets.RecordTypeMX),
+	string(recordsets.RecordTypePTR),
+	string(recordsets.RecordTypeSRV),
+	string(recordsets.RecordTypeTXT),
+}
+
+func resourcePrivateDnsZoneRecordSets() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourcePrivateDnsZoneRecordSetsCreate,
+		Read:   resourcePrivateDnsZoneRecordSetsRead,
+		Update: resourcePrivateDnsZoneRecordSetsUpdate,
+		Delete: resourcePrivateDnsZoneRecordSetsDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
+			_, err := parsePrivateDnsZoneRecordSetsID(id)
+			return err
+		}, zonefile.ImportRecordSets),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		// TODO: make `resource_group_name` case sensitive once the API's fixed https://github.com/Azure/azure-rest-api-specs/issues/6641
+		Schema: zonefile.RecordSetsSchema(azure.SchemaResourceGroupNameDiffSuppress(), privateDnsZoneRecordSetsRecordTypes),
+	}
+}
+
+func resourcePrivateDnsZoneRecordSetsCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).PrivateDns.RecordSetsClient
+	zonesClient := meta.(*clients.Client).PrivateDns.PrivateZonesClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := recordsets.NewPrivateDnsZoneID(subscriptionId, d.Get("resource_group_name").(string), d.Get("zone_name").(string))
+
+	if err := newPrivateDnsZoneRecordSets(client, zonesClient, id).Create(ctx, d); err != nil {
+		return err
+	}
+
+	return resourcePrivateDnsZoneRecordSetsRead(d, meta)
+}
+
+func resourcePrivateDnsZoneRecordSetsRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).PrivateDns.RecordSetsClient
+	zonesClient := meta.(*clients.Client).PrivateDns.PrivateZonesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parsePrivateDnsZoneRecordSetsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return newPrivateDnsZoneRecordSets(client, zonesClient, *id).Read(ctx, d)
+}
+
+func resourcePrivateDnsZoneRecordSetsUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).PrivateDns.RecordSetsClient
+	zonesClient := meta.(*clients.Client).PrivateDns.PrivateZonesClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parsePrivateDnsZoneRecordSetsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if err := newPrivateDnsZoneRecordSets(client, zonesClient, *id).Update(ctx, d); err != nil {
+		return err
+	}
+
+	return resourcePrivateDnsZoneRecordSetsRead(d, meta)
+}
+
+func resourcePrivateDnsZoneRecordSetsDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).PrivateDns.RecordSetsClient
+	zonesClient := meta.(*clients.Client).PrivateDns.PrivateZonesClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parsePrivateDnsZoneRecordSetsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return newPrivateDnsZoneRecordSets(client, zonesClient, *id).Delete(ctx, d)
+}
+
+// parsePrivateDnsZoneRecordSetsID parses the ID of the Record Sets within a Private DNS Zone, in the format `{privateDnsZoneId}/recordSets`
+func parsePrivateDnsZoneRecordSetsID(input string) (*recordsets.PrivateDnsZoneId, error) {
+	zoneId, err := zonefile.ParseRecordSetsID(input)
+	if err != nil {
+		return nil, err
+	}
+	return recordsets.ParsePrivateDnsZoneID(zoneId)
+}
+
+func newPrivateDnsZoneRecordSets(client *recordsets.RecordSetsClient, zonesClient *privatezones.PrivateZonesClient, id recordsets.PrivateDnsZoneId) zonefile.ZoneRecordSets {
+	return zonefile.ZoneRecordSets{
+		ResourceType:      "azurerm_private_dns_zone_record_sets",
+		ZoneId:            id,
+		ZoneName:          id.PrivateDnsZoneName,
+		ResourceGroupName: id.ResourceGroupName,
+		RecordTypes:       privateDnsZoneRecordSetsRecordTypes,
+		Client: privateDnsZoneRecordSetsClient{
+			client:      client,
+			zonesClient: zonesClient,
+			zoneId:      id,
+		},
+	}
+}
+
+// privateDnsZoneRecordSetsClient performs the operations on the Record Sets within a single Private DNS Zone
+type privateDnsZoneRecordSetsClient struct {
+	client      *recordsets.RecordSetsClient
+	zonesClient *privatezones.PrivateZonesClient
+	zoneId      recordsets.PrivateDnsZoneId
+}
+
+var _ zonefile.Client = privateDnsZoneRecordSetsClient{}
+
+func (c privateDnsZoneRecordSetsClient) List(ctx context.Context) (*[]zonefile.RecordSet, error) {
+	zoneId := privatezones.NewPrivateDnsZoneID(c.zoneId.SubscriptionId, c.zoneId.ResourceGroupName, c.zoneId.PrivateDnsZoneName)
+	zone, err := c.zonesClient.Get(ctx, zoneId)
+	if err != nil {
+		if response.WasNotFound(zone.HttpResponse) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", zoneId, err)
+	}
+
+	resp, err := c.client.ListComplete(ctx, c.zoneId, recordsets.DefaultListOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing Record Sets within %s: %+v", c.zoneId, err)
+	}
+
+	results := make([]zonefile.RecordSet, 0)
+	for _, v := range resp.Items {
+		set, err := flattenPrivateDnsZoneRecordSet(v, c.zoneId.PrivateDnsZoneName)
+		if err != nil {
+			return nil, fmt.Errorf("flattening Record Sets within %s: %+v", c.zoneId, err)
+		}
+		if set != nil {
+			results = append(results, *set)
+		}
+	}
+
+	return &results, nil
+}
+
+func (c privateDnsZoneRecordSetsClient) CreateOrUpdate(ctx context.Context, set zonefile.RecordSet) error {
+	id := recordsets.NewRecordTypeID(c.zoneId.SubscriptionId, c.zoneId.ResourceGroupName, c.zoneId.PrivateDnsZoneName, recordsets.RecordType(set.Type), set.Name)
+	parameters, err := expandPrivateDnsZoneRecordSet(set)
+	if err != nil {
+		return err
+	}
+	options := recordsets.CreateOrUpdateOperationOptions{
+		IfMatch:     utils.String(""),
+		IfNoneMatch: utils.String(""),
+	}
+	if _, err := c.client.CreateOrUpdate(ctx, id, *parameters, options); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+	return nil
+}
+
+func (c privateDnsZoneRecordSetsClient) Delete(ctx context.Context, set zonefile.RecordSet) error {
+	id := recordsets.NewRecordTypeID(c.zoneId.SubscriptionId, c.zoneId.ResourceGroupName, c.zoneId.PrivateDnsZoneName, recordsets.RecordType(set.Type), set.Name)
+	if _, err := c.client.Delete(ctx, id, recordsets.DeleteOperationOptions{IfMatch: utils.String("")}); err != nil {
+		return fmt.Errorf("deleting %s: %+v", id, err)
+	}
+	return nil
+}
+
+func expandPrivateDnsZoneRecordSet(input zonefile.RecordSet) (*recordsets.RecordSet, error) {
+	ttl := input.TTL
+	props := recordsets.RecordSetProperties{
+		Ttl: &ttl,
+	}
+
+	fields := make([][]string, 0)
+	for _, record := range input.Records {
+		v, err := zonefile.Fields(record)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, v)
+	}
+
+	switch input.Type {
+	case zonefile.RecordTypeA:
+		records := make([]recordsets.ARecord, 0)
+		for _, v := range fields {
+			records = append(records, recordsets.ARecord{IPv4Address: &v[0]})
+		}
+		props.ARecords = &records
+
+	case zonefile.RecordTypeAAAA:
+		records := make([]recordsets.AaaaRecord, 0)
+		for _, v := range fields {
+			records = append(records, recordsets.AaaaRecord{IPv6Address: &v[0]})
+		}
+		props.AaaaRecords = &records
+
+	case zonefile.RecordTypeCNAME:
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("a CNAME Record Set must contain exactly one record but got %d", len(fields))
+		}
+		props.CnameRecord = &recordsets.CnameRecord{
+			Cname: privateDnsZoneRecordSetsDomainName(fields[0][0]),
+		}
+
+	case zonefile.RecordTypeMX:
+		records := make([]recordsets.MxRecord, 0)
+		for _, v := range fields {
+			preference, err := strconv.ParseInt(v[0], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, recordsets.MxRecord{
+				Preference: &preference,
+				Exchange:   privateDnsZoneRecordSetsDomainName(v[1]),
+			})
+		}
+		props.MxRecords = &records
+
+	case zonefile.RecordTypePTR:
+		records := make([]recordsets.PtrRecord, 0)
+		for _, v := range fields {
+			records = append(records, recordsets.PtrRecord{Ptrdname: privateDnsZoneRecordSetsDomainName(v[0])})
+		}
+		props.PtrRecords = &records
+
+	case zonefile.RecordTypeSRV:
+		records := make([]recordsets.SrvRecord, 0)
+		for _, v := range fields {
+			values := make([]int64, 0)
+			for _, field := range v[0:3] {
+				value, err := strconv.ParseInt(field, 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+			records = append(records, recordsets.SrvRecord{
+				Priority: &values[0],
+				Weight:   &values[1],
+				Port:     &values[2],
+				Target:   privateDnsZoneRecordSetsDomainName(v[3]),
+			})
+		}
+		props.SrvRecords = &records
+
+	case zonefile.RecordTypeTXT:
+		records := make([]recordsets.TxtRecord, 0)
+		for _, v := range fields {
+			value := strings.Join(v, "")
+
+			segments := make([]string, 0)
+			for len(value) > 254 {
+				segments = append(segments, value[:254])
+				value = value[254:]
+			}
+			segments = append(segments, value)
+
+			records = append(records, recordsets.TxtRecord{Value: &segments})
+		}
+		props.TxtRecords = &records
+
+	default:
+		return nil, fmt.Errorf("the record type %q is not supported by Private DNS Zones", input.Type)
+	}
+
+	return &recordsets.RecordSet{
+		Name:       utils.String(input.Name),
+		Properties: &props,
+	}, nil
+}
+
+// flattenPrivateDnsZoneRecordSet converts the Record Set into its zone file representation
+func flattenPrivateDnsZoneRecordSet(input recordsets.RecordSet, zoneName string) (*zonefile.RecordSet, error) {
+	if input.Name == nil || input.Type == nil || input.Properties == nil {
+		return nil, nil
+	}
+	props := input.Properties
+
+	recordType := *input.Type
+	if i := strings.LastIndex(recordType, "/"); i != -1 {
+		recordType = recordType[i+1:]
+	}
+
+	ttl := int64(0)
+	if props.Ttl != nil {
+		ttl = *props.Ttl
+	}
+
+	records := make([]string, 0)
+	switch recordsets.RecordType(recordType) {
+	case recordsets.RecordTypeA:
+		if props.ARecords != nil {
+			for _, v := range *props.ARecords {
+				records = append(records, pointer.From(v.IPv4Address))
+			}
+		}
+	case recordsets.RecordTypeAAAA:
+		if props.AaaaRecords != nil {
+			for _, v := range *props.AaaaRecords {
+				records = append(records, pointer.From(v.IPv6Address))
+			}
+		}
+	case recordsets.RecordTypeCNAME:
+		if props.CnameRecord != nil && props.CnameRecord.Cname != nil {
+			records = append(records, privateDnsZoneRecordSetsFqdn(*props.CnameRecord.Cname))
+		}
+	case recordsets.RecordTypeMX:
+		if props.MxRecords != nil {
+			for _, v := range *props.MxRecords {
+				records = append(records, fmt.Sprintf("%d %s", pointer.From(v.Preference), privateDnsZoneRecordSetsFqdn(pointer.From(v.Exchange))))
+			}
+		}
+	case recordsets.RecordTypePTR:
+		if props.PtrRecords != nil {
+			for _, v := range *props.PtrRecords {
+				records = append(records, privateDnsZoneRecordSetsFqdn(pointer.From(v.Ptrdname)))
+			}
+		}
+	case recordsets.RecordTypeSOA:
+		if v := props.SoaRecord; v != nil {
+			records = append(records, fmt.Sprintf("%s %s %d %d %d %d %d", privateDnsZoneRecordSetsFqdn(pointer.From(v.Host)), privateDnsZoneRecordSetsFqdn(pointer.From(v.Email)), pointer.From(v.SerialNumber), pointer.From(v.RefreshTime), pointer.From(v.RetryTime), pointer.From(v.ExpireTime), pointer.From(v.MinimumTtl)))
+		}
+	case recordsets.RecordTypeSRV:
+		if props.SrvRecords != nil {
+			for _, v := range *props.SrvRecords {
+				records = append(records, fmt.Sprintf("%d %d %d %s", pointer.From(v.Priority), pointer.From(v.Weight), pointer.From(v.Port), privateDnsZoneRecordSetsFqdn(pointer.From(v.Target))))
+			}
+		}
+	case recordsets.RecordTypeTXT:
+		if props.TxtRecords != nil {
+			for _, v := range *props.TxtRecords {
+				value := ""
+				if v.Value != nil {
+					value = strings.Join(*v.Value, "")
+				}
+				records = append(records, zonefile.Quote(value))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("the record type %q is not supported", recordType)
+	}
+
+	// the records returned from the API are normalised in the same way as those in a zone file, so that the two can be compared
+	set, err := zonefile.Normalise(zonefile.RecordSet{
+		Name:    *input.Name,
+		Type:    recordType,
+		TTL:     ttl,
+		Records: records,
+	}, zoneName)
+	if err != nil {
+		return nil, fmt.Errorf("normalising the %s Record Set %q: %+v", recordType, *input.Name, err)
+	}
+
+	return set, nil
+}
+
+// privateDnsZoneRecordSetsFqdn returns the fully-qualified form (with a trailing dot) of a domain name returned from the API
+func privateDnsZoneRecordSetsFqdn(input string) string {
+	return strings.TrimSuffix(input, ".") + "."
+}
+
+// privateDnsZoneRecordSetsDomainName returns the domain name in the format used by the API (without a trailing dot)
+func privateDnsZoneRecordSetsDomainName(input string) *string {
+	value := strings.TrimSuffix(input, ".")
+	return &value
+}
@@ -0,0 +1,311 @@
+package privatedns_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/privatedns/2018-09-01/recordsets"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dns/zonefile"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type PrivateDnsZoneRecordSetsResource struct{}
+
+func TestAccPrivateDnsZoneRecordSets_zoneFile(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_private_dns_zone_record_sets", "test")
+	r := PrivateDnsZoneRecordSetsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.zoneFile(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("mode").HasValue("Additive"),
+				data.CheckWithClientForResource(r.recordSetExists("www", recordsets.RecordTypeA, true), data.ResourceName),
+				data.CheckWithClientForResource(r.recordSetExists("old", recordsets.RecordTypeCNAME, true), data.ResourceName),
+			),
+		},
+		{
+			Config: r.zoneFileUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.recordSetExists("www", recordsets.RecordTypeA, true), data.ResourceName),
+				data.CheckWithClientForResource(r.recordSetExists("old", recordsets.RecordTypeCNAME, false), data.ResourceName),
+				data.CheckWithClientForResource(r.recordSetExists("old", recordsets.RecordTypeA, true), data.ResourceName),
+			),
+		},
+	})
+}
+
+func TestAccPrivateDnsZoneRecordSets_recordSets(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_private_dns_zone_record_sets", "test")
+	r := PrivateDnsZoneRecordSetsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.recordSets(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("record_set.#").HasValue("2"),
+			),
+		},
+		data.ImportStep("mode", "record_set", "zone_file"),
+	})
+}
+
+func TestAccPrivateDnsZoneRecordSets_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_private_dns_zone_record_sets", "test")
+	r := PrivateDnsZoneRecordSetsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.recordSets(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(data),
+			ExpectError: acceptance.RequiresImportError("azurerm_private_dns_zone_record_sets"),
+		},
+	})
+}
+
+func TestAccPrivateDnsZoneRecordSets_authoritative(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_private_dns_zone_record_sets", "test")
+	r := PrivateDnsZoneRecordSetsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.additive(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.createUnmanagedRecordSet("unmanaged"), data.ResourceName),
+			),
+		},
+		{
+			Config: r.authoritative(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.recordSetExists("www", recordsets.RecordTypeA, true), data.ResourceName),
+				data.CheckWithClientForResource(r.recordSetExists("unmanaged", recordsets.RecordTypeA, false), data.ResourceName),
+			),
+		},
+		data.ImportStep("zone_file"),
+	})
+}
+
+// zoneId returns the ID of the zone from the ID of the Record Sets within it
+func (PrivateDnsZoneRecordSetsResource) zoneId(state *pluginsdk.InstanceState) (*recordsets.PrivateDnsZoneId, error) {
+	zoneId, err := zonefile.ParseRecordSetsID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	return recordsets.ParsePrivateDnsZoneID(zoneId)
+}
+
+func (PrivateDnsZoneRecordSetsResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := PrivateDnsZoneRecordSetsResource{}.zoneId(state)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.PrivateDns.RecordSetsClient.ListComplete(ctx, *id, recordsets.DefaultListOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing Record Sets within %s: %+v", *id, err)
+	}
+
+	return utils.Bool(len(resp.Items) > 0), nil
+}
+
+func (PrivateDnsZoneRecordSetsResource) recordSetExists(name string, recordType recordsets.RecordType, shouldExist bool) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		zoneId, err := PrivateDnsZoneRecordSetsResource{}.zoneId(state)
+		if err != nil {
+			return err
+		}
+
+		id := recordsets.NewRecordTypeID(zoneId.SubscriptionId, zoneId.ResourceGroupName, zoneId.PrivateDnsZoneName, recordType, name)
+		resp, err := clients.PrivateDns.RecordSetsClient.Get(ctx, id)
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				if shouldExist {
+					return fmt.Errorf("%s was not found", id)
+				}
+				return nil
+			}
+			return fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+
+		if !shouldExist {
+			return fmt.Errorf("%s still exists", id)
+		}
+		return nil
+	}
+}
+
+func (PrivateDnsZoneRecordSetsResource) createUnmanagedRecordSet(name string) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		zoneId, err := PrivateDnsZoneRecordSetsResource{}.zoneId(state)
+		if err != nil {
+			return err
+		}
+
+		id := recordsets.NewRecordTypeID(zoneId.SubscriptionId, zoneId.ResourceGroupName, zoneId.PrivateDnsZoneName, recordsets.RecordTypeA, name)
+		parameters := recordsets.RecordSet{
+			Properties: &recordsets.RecordSetProperties{
+				Ttl: utils.Int64(300),
+				ARecords: &[]recordsets.ARecord{
+					{
+						IPv4Address: utils.String("10.0.0.100"),
+					},
+				},
+			},
+		}
+		if _, err := clients.PrivateDns.RecordSetsClient.CreateOrUpdate(ctx, id, parameters, recordsets.DefaultCreateOrUpdateOperationOptions()); err != nil {
+			return fmt.Errorf("creating %s: %+v", id, err)
+		}
+
+		return nil
+	}
+}
+
+func (PrivateDnsZoneRecordSetsResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_private_dns_zone" "test" {
+  name                = "testzone%d.com"
+  resource_group_name = azurerm_resource_group.test.name
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
+func (r PrivateDnsZoneRecordSetsResource) zoneFile(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_private_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_private_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+
+  zone_file = <<ZONE
+$TTL 300
+@       IN MX    10 mail
+@       IN TXT   "v=spf1 mx -all"
+mail    IN A     10.0.0.10
+www     IN A     10.0.0.1
+        IN A     10.0.0.2
+old     IN CNAME www
+_sip._tcp 600 IN SRV 10 60 5060 sip.example.com.
+ZONE
+}
+`, r.template(data))
+}
+
+func (r PrivateDnsZoneRecordSetsResource) zoneFileUpdated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_private_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_private_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+
+  zone_file = <<ZONE
+$TTL 600
+@       IN MX    10 mail
+@       IN TXT   "v=spf1 mx -all"
+mail    IN A     10.0.0.10
+www     IN A     10.0.0.1
+old     IN A     10.0.0.3
+ZONE
+}
+`, r.template(data))
+}
+
+func (r PrivateDnsZoneRecordSetsResource) recordSets(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_private_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_private_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+
+  record_set {
+    name    = "www"
+    type    = "A"
+    ttl     = 300
+    records = ["10.0.0.1", "10.0.0.2"]
+  }
+
+  record_set {
+    name    = "ipv6"
+    type    = "AAAA"
+    ttl     = 300
+    records = ["fd5d:70bc:930e:d008::7ae6"]
+  }
+}
+`, r.template(data))
+}
+
+func (r PrivateDnsZoneRecordSetsResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_private_dns_zone_record_sets" "import" {
+  zone_name           = azurerm_private_dns_zone_record_sets.test.zone_name
+  resource_group_name = azurerm_private_dns_zone_record_sets.test.resource_group_name
+
+  record_set {
+    name    = "www"
+    type    = "A"
+    ttl     = 300
+    records = ["10.0.0.1", "10.0.0.2"]
+  }
+}
+`, r.recordSets(data))
+}
+
+func (r PrivateDnsZoneRecordSetsResource) additive(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_private_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_private_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+
+  zone_file = <<ZONE
+www 300 IN A 10.0.0.1
+ZONE
+}
+`, r.template(data))
+}
+
+func (r PrivateDnsZoneRecordSetsResource) authoritative(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_private_dns_zone_record_sets" "test" {
+  zone_name           = azurerm_private_dns_zone.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  mode                = "Authoritative"
+
+  zone_file = <<ZONE
+www 300 IN A 10.0.0.1
+ZONE
+}
+`, r.template(data))
+}
@@ -35,6 +35,7 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 		"azurerm_private_dns_srv_record":                dataSourcePrivateDnsSrvRecord(),
 		"azurerm_private_dns_txt_record":                dataSourcePrivateDnsTxtRecord(),
 		"azurerm_private_dns_zone_virtual_network_link": dataSourcePrivateDnsZoneVirtualNetworkLink(),
+		"azurerm_private_dns_zone_file":                 dataSourcePrivateDnsZoneFile(),
 	}
 }
 
@@ -50,5 +51,6 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_private_dns_srv_record":                resourcePrivateDnsSrvRecord(),
 		"azurerm_private_dns_txt_record":                resourcePrivateDnsTxtRecord(),
 		"azurerm_private_dns_zone_virtual_network_link": resourcePrivateDnsZoneVirtualNetworkLink(),
+		"azurerm_private_dns_zone_record_sets":          resourcePrivateDnsZoneRecordSets(),
 	}
 }
@@ -127,8 +127,10 @@ func resourceResourceGroupDelete(d *pluginsdk.ResourceData, meta interface{}) er
 		return err
 	}
 
+	resourceGroupFeatures := meta.(*clients.Client).Features.ResourceGroup
+
 	// conditionally check for nested resources and error if they exist
-	if meta.(*clients.Client).Features.ResourceGroup.PreventDeletionIfContainsResources {
+	if resourceGroupFeatures.PreventDeletionIfContainsResources {
 		resourceClient := meta.(*clients.Client).Resource.ResourcesClient
 		// Resource groups sometimes hold on to resource information after the resources have been deleted. We'll retry this check to account for that eventual consistency.
 		err = pluginsdk.Retry(10*time.Minute, func() *pluginsdk.RetryError {
@@ -136,11 +138,22 @@ func resourceResourceGroupDelete(d *pluginsdk.ResourceData, meta interface{}) er
 			if err != nil {
 				return pluginsdk.NonRetryableError(fmt.Errorf("listing resources in %s: %v", *id, err))
 			}
-			nestedResourceIds := make([]string, 0)
+			nestedResources := make([]nestedResource, 0)
 			for results.NotDone() {
 				val := results.Value()
 				if val.ID != nil {
-					nestedResourceIds = append(nestedResourceIds, *val.ID)
+					resourceType := ""
+					if val.Type != nil {
+						resourceType = *val.Type
+					}
+
+					// resources of these types are deleted alongside the Resource Group, so shouldn't block deletion
+					if !resourceTypeIsDeletableWithResourceGroup(resourceType, resourceGroupFeatures.DeletableNestedResourceTypes) {
+						nestedResources = append(nestedResources, nestedResource{
+							ID:   *val.ID,
+							Type: resourceType,
+						})
+					}
 				}
 
 				if err := results.NextWithContext(ctx); err != nil {
@@ -148,9 +161,9 @@ func resourceResourceGroupDelete(d *pluginsdk.ResourceData, meta interface{}) er
 				}
 			}
 
-			if len(nestedResourceIds) > 0 {
+			if len(nestedResources) > 0 {
 				time.Sleep(30 * time.Second)
-				return pluginsdk.RetryableError(resourceGroupContainsItemsError(id.ResourceGroup, nestedResourceIds))
+				return pluginsdk.RetryableError(resourceGroupContainsItemsError(id.ResourceGroup, nestedResources))
 			}
 			return nil
 		})
@@ -159,7 +172,15 @@ func resourceResourceGroupDelete(d *pluginsdk.ResourceData, meta interface{}) er
 		}
 	}
 
-	deleteFuture, err := client.Delete(ctx, id.ResourceGroup, "")
+	forceDeletionTypes := ""
+	if resourceGroupFeatures.ForceDeleteVirtualMachines {
+		forceDeletionTypes = strings.Join([]string{
+			"Microsoft.Compute/virtualMachines",
+			"Microsoft.Compute/virtualMachineScaleSets",
+		}, ",")
+	}
+
+	deleteFuture, err := client.Delete(ctx, id.ResourceGroup, forceDeletionTypes)
 	if err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
@@ -172,10 +193,30 @@ func resourceResourceGroupDelete(d *pluginsdk.ResourceData, meta interface{}) er
 	return nil
 }
 
-func resourceGroupContainsItemsError(name string, nestedResourceIds []string) error {
+type nestedResource struct {
+	ID   string
+	Type string
+}
+
+func resourceTypeIsDeletableWithResourceGroup(resourceType string, deletableResourceTypes []string) bool {
+	for _, v := range deletableResourceTypes {
+		if strings.EqualFold(v, resourceType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func resourceGroupContainsItemsError(name string, nestedResources []nestedResource) error {
 	formattedResourceUris := make([]string, 0)
-	for _, id := range nestedResourceIds {
-		formattedResourceUris = append(formattedResourceUris, fmt.Sprintf("* `%s`", id))
+	for _, item := range nestedResources {
+		if item.Type != "" {
+			formattedResourceUris = append(formattedResourceUris, fmt.Sprintf("* `%s` (%s)", item.ID, item.Type))
+			continue
+		}
+
+		formattedResourceUris = append(formattedResourceUris, fmt.Sprintf("* `%s`", item.ID))
 	}
 	sort.Strings(formattedResourceUris)
 
@@ -204,6 +245,18 @@ provider "azurerm" {
 When that feature flag is set, Terraform will skip checking for any Resources within the Resource Group and
 delete this using the Azure API directly (which will clear up any nested resources).
 
+Alternatively, where these Resources are expected to exist (for example Resources created automatically by Azure)
+the Resource Types which can be deleted alongside the Resource Group can be specified using the
+'deletable_nested_resource_types' field within the 'resource_group' block, for example:
+
+provider "azurerm" {
+  features {
+    resource_group {
+      deletable_nested_resource_types = ["Microsoft.Network/networkWatchers"]
+    }
+  }
+}
+
 More information on the 'features' block can be found in the documentation:
 https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs#features
 `, name, strings.Join(formattedResourceUris, "\n"))
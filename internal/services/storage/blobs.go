@@ -88,6 +88,40 @@ func (sbu BlobUpload) Create(ctx context.Context) error {
 	return fmt.Errorf("Unsupported Blob Type: %q", blobType)
 }
 
+// Update replaces the contents of an existing Block Blob in-place, rather than deleting and recreating it - meaning
+// that any Snapshots are retained and (where versioning is enabled) the previous contents are kept as a Version
+func (sbu BlobUpload) Update(ctx context.Context) error {
+	if !strings.EqualFold(sbu.BlobType, "block") {
+		return fmt.Errorf("the contents of a %s blob cannot be updated in-place", sbu.BlobType)
+	}
+
+	if sbu.SourceUri != "" {
+		return sbu.copy(ctx)
+	}
+
+	if sbu.SourceContent != "" {
+		content := []byte(sbu.SourceContent)
+		return sbu.stageAndCommitBlockBlob(ctx, bytes.NewReader(content), int64(len(content)))
+	}
+
+	if sbu.Source != "" {
+		file, err := os.Open(sbu.Source)
+		if err != nil {
+			return fmt.Errorf("opening: %s", err)
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return fmt.Errorf("could not stat file %q: %s", file.Name(), err)
+		}
+
+		return sbu.stageAndCommitBlockBlob(ctx, file, info.Size())
+	}
+
+	return sbu.stageAndCommitBlockBlob(ctx, bytes.NewReader(nil), 0)
+}
+
 func (sbu BlobUpload) copy(ctx context.Context) error {
 	input := blobs.CopyInput{
 		CopySource: sbu.SourceUri,
@@ -220,6 +254,85 @@ func (sbu BlobUpload) uploadPageBlob(ctx context.Context) error {
 	return nil
 }
 
+// blockSize is the size of each of the blocks staged when updating the contents of a Block Blob
+const blockSize int64 = 4 * 1024 * 1024
+
+// stageAndCommitBlockBlob uploads the content as a series of uncommitted blocks, which only replace the existing
+// contents of the blob once every block has been uploaded and the block list is committed
+func (sbu BlobUpload) stageAndCommitBlockBlob(ctx context.Context, source io.ReaderAt, size int64) error {
+	blockIds := make([]blobs.BlockID, 0)
+	for offset := int64(0); offset < size; offset += blockSize {
+		// block ID's must be base64 encoded and of the same length for all blocks within a blob
+		blockId := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("block-%08d", len(blockIds))))
+		blockIds = append(blockIds, blobs.BlockID{Value: blockId})
+	}
+
+	workerCount := sbu.Parallelism
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	blocks := make(chan int, len(blockIds))
+	for i := range blockIds {
+		blocks <- i
+	}
+	close(blocks)
+
+	errors := make(chan error, len(blockIds))
+	wg := &sync.WaitGroup{}
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range blocks {
+				offset := int64(i) * blockSize
+				length := blockSize
+				if offset+length > size {
+					length = size - offset
+				}
+
+				content := make([]byte, length)
+				if _, err := source.ReadAt(content, offset); err != nil && err != io.EOF {
+					errors <- fmt.Errorf("reading block at offset %d: %s", offset, err)
+					continue
+				}
+
+				input := blobs.PutBlockInput{
+					BlockID: blockIds[i].Value,
+					Content: content,
+				}
+				if _, err := sbu.Client.PutBlock(ctx, sbu.AccountName, sbu.ContainerName, sbu.BlobName, input); err != nil {
+					errors <- fmt.Errorf("staging block at offset %d: %s", offset, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return fmt.Errorf("PutBlock: %s", <-errors)
+	}
+
+	input := blobs.PutBlockListInput{
+		BlockList: blobs.BlockList{
+			LatestBlockIDs: blockIds,
+		},
+		ContentType: utils.String(sbu.ContentType),
+		MetaData:    sbu.MetaData,
+	}
+	if sbu.CacheControl != "" {
+		input.CacheControl = utils.String(sbu.CacheControl)
+	}
+	if sbu.ContentMD5 != "" {
+		input.ContentMD5 = utils.String(sbu.ContentMD5)
+	}
+	if _, err := sbu.Client.PutBlockList(ctx, sbu.AccountName, sbu.ContainerName, sbu.BlobName, input); err != nil {
+		return fmt.Errorf("PutBlockList: %s", err)
+	}
+
+	return nil
+}
+
 // TODO: move below here into Giovanni
 
 type storageBlobPage struct {
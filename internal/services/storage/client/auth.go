@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// DataPlaneAuthMode determines how requests to the Storage Data Plane APIs for a Storage Account are authorized
+type DataPlaneAuthMode string
+
+const (
+	// DataPlaneAuthModeAuto uses Azure AD when `storage_use_azuread` is enabled, when Shared Key access
+	// is disabled on the Storage Account or when the Account Keys can't be listed - otherwise Shared Key
+	DataPlaneAuthModeAuto DataPlaneAuthMode = "Auto"
+
+	// DataPlaneAuthModeAzureAD always uses Azure AD
+	DataPlaneAuthModeAzureAD DataPlaneAuthMode = "AzureAD"
+
+	// DataPlaneAuthModeSharedKey always uses the Account Key
+	DataPlaneAuthModeSharedKey DataPlaneAuthMode = "SharedKey"
+)
+
+func PossibleValuesForDataPlaneAuthMode() []string {
+	return []string{
+		string(DataPlaneAuthModeAuto),
+		string(DataPlaneAuthModeAzureAD),
+		string(DataPlaneAuthModeSharedKey),
+	}
+}
+
+// SetDataPlaneAuthMode overrides how requests to the Data Plane APIs for this Storage Account are authorized.
+// This only applies to this instance of the account details, rather than to the cached Storage Account.
+func (ad *accountDetails) SetDataPlaneAuthMode(mode string) {
+	ad.authMode = DataPlaneAuthMode(mode)
+}
+
+// sharedKeyAccessDisabled returns whether Shared Key authorization has been disabled on this Storage Account
+func (ad accountDetails) sharedKeyAccessDisabled() bool {
+	return ad.Properties != nil && ad.Properties.AllowSharedKeyAccess != nil && !*ad.Properties.AllowSharedKeyAccess
+}
+
+// dataPlaneAuthorizer returns the Authorizer to use for the Data Plane API of the specified service, along
+// with whether this Authorizer uses Azure AD
+func (client Client) dataPlaneAuthorizer(ctx context.Context, account accountDetails, keyType autorest.SharedKeyType) (autorest.Authorizer, bool, error) {
+	mode := account.authMode
+	if mode == "" {
+		mode = DataPlaneAuthModeAuto
+	}
+
+	switch mode {
+	case DataPlaneAuthModeAzureAD:
+		return client.storageAuthorizer, true, nil
+
+	case DataPlaneAuthModeSharedKey:
+		if account.sharedKeyAccessDisabled() {
+			return nil, false, fmt.Errorf("the `SharedKey` authentication mode was specified but Shared Key access is disabled on Storage Account %q - either enable `shared_access_key_enabled` or use Azure AD", account.name)
+		}
+		authorizer, err := client.sharedKeyAuthorizer(ctx, account, keyType)
+		return authorizer, false, err
+	}
+
+	if client.storageUseAzureAD {
+		return client.storageAuthorizer, true, nil
+	}
+
+	if account.sharedKeyAccessDisabled() {
+		log.Printf("[DEBUG] Shared Key access is disabled on Storage Account %q - using Azure AD", account.name)
+		return client.storageAuthorizer, true, nil
+	}
+
+	authorizer, err := client.sharedKeyAuthorizer(ctx, account, keyType)
+	if err != nil {
+		var forbidden listKeysForbiddenError
+		if errors.As(err, &forbidden) {
+			log.Printf("[DEBUG] Unable to list the Account Keys for Storage Account %q - falling back to Azure AD: %+v", account.name, err)
+			return client.storageAuthorizer, true, nil
+		}
+		return nil, false, err
+	}
+
+	return authorizer, false, nil
+}
+
+// sharedKeyOnlyAuthorizer returns an Authorizer for the Data Plane APIs which only support Shared Key authorization
+func (client Client) sharedKeyOnlyAuthorizer(ctx context.Context, account accountDetails, keyType autorest.SharedKeyType, service string) (autorest.Authorizer, error) {
+	if account.sharedKeyAccessDisabled() {
+		return nil, fmt.Errorf("Shared Key access is disabled on Storage Account %q but %s only support Shared Key authorization - `shared_access_key_enabled` must be enabled to manage %s", account.name, service, service)
+	}
+
+	return client.sharedKeyAuthorizer(ctx, account, keyType)
+}
+
+func (client Client) sharedKeyAuthorizer(ctx context.Context, account accountDetails, keyType autorest.SharedKeyType) (autorest.Authorizer, error) {
+	accountKey, err := account.AccountKey(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account Key: %w", err)
+	}
+
+	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, keyType)
+	if err != nil {
+		return nil, fmt.Errorf("building Authorizer: %+v", err)
+	}
+
+	return storageAuth, nil
+}
+
+// listKeysForbiddenError is returned when the caller doesn't have permission to list the Account Keys
+type listKeysForbiddenError struct {
+	accountName   string
+	resourceGroup string
+	err           error
+}
+
+func (e listKeysForbiddenError) Error() string {
+	return fmt.Sprintf("the caller doesn't have permission to list the Keys for Storage Account %q (Resource Group %q) - this requires the `Microsoft.Storage/storageAccounts/listKeys/action` permission, which is granted by roles such as `Storage Account Key Operator Service Role` or `Contributor`: %+v", e.accountName, e.resourceGroup, e.err)
+}
+
+// azureADResponseInspector surfaces which RBAC role is required when a Data Plane request authorized
+// using Azure AD is rejected, since the error returned by the API doesn't include this
+func azureADResponseInspector(accountName, role string) autorest.RespondDecorator {
+	return func(r autorest.Responder) autorest.Responder {
+		return autorest.ResponderFunc(func(resp *http.Response) error {
+			if err := r.Respond(resp); err != nil {
+				return err
+			}
+
+			if resp == nil || resp.StatusCode != http.StatusForbidden {
+				return nil
+			}
+
+			return fmt.Errorf("the request to Storage Account %q was authorized using Azure AD but was forbidden (error code %q) - ensure that the principal running Terraform has been assigned the `%s` role (or a role with equivalent Data Actions) on the Storage Account, or that Shared Key authorization is available", accountName, resp.Header.Get("x-ms-error-code"), role)
+		})
+	}
+}
+
+// configureDataPlaneClient configures the Authorizer for a Data Plane client, and when using Azure AD
+// ensures that authorization failures name the RBAC role which is required
+func configureDataPlaneClient(c *autorest.Client, authorizer autorest.Authorizer, usesAzureAD bool, accountName, role string) {
+	c.Authorizer = authorizer
+	if usesAzureAD {
+		c.ResponseInspector = azureADResponseInspector(accountName, role)
+	}
+}
@@ -2,14 +2,15 @@ package client
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources"     // nolint: staticcheck
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-09-01/storage"         // nolint: staticcheck
 	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2020-03-01/storagesync" // nolint: staticcheck
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	storage_v2022_05_01 "github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-05-01"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-05-01/localusers"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-09-01/storageaccounts"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/shim"
 	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/accounts"
@@ -27,6 +28,7 @@ import (
 
 type Client struct {
 	AccountsClient              *storage.AccountsClient
+	AccountsFailoverClient      *storageaccounts.StorageAccountsClient
 	LocalUsersClient            *localusers.LocalUsersClient
 	FileSystemsClient           *filesystems.Client
 	ADLSGen2PathsClient         *paths.Client
@@ -44,13 +46,19 @@ type Client struct {
 	ResourceManager *storage_v2022_05_01.Client
 
 	resourceManagerAuthorizer autorest.Authorizer
-	storageAdAuth             *autorest.Authorizer
+	resourcesClient           *resources.Client
+	storageAuthorizer         autorest.Authorizer
+	storageUseAzureAD         bool
 }
 
 func NewClient(options *common.ClientOptions) *Client {
 	accountsClient := storage.NewAccountsClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&accountsClient.Client, options.ResourceManagerAuthorizer)
 
+	// NOTE: API Version 2022-09-01 is required to trigger a Planned Failover
+	accountsFailoverClient := storageaccounts.NewStorageAccountsClientWithBaseURI(options.ResourceManagerEndpoint)
+	accountsFailoverClient.Client.Authorizer = options.ResourceManagerAuthorizer
+
 	localUsersClient := localusers.NewLocalUsersClientWithBaseURI(options.ResourceManagerEndpoint)
 	localUsersClient.Client.Authorizer = options.ResourceManagerAuthorizer
 
@@ -83,6 +91,9 @@ func NewClient(options *common.ClientOptions) *Client {
 			c.Authorizer = options.ResourceManagerAuthorizer
 		})
 
+	resourcesClient := resources.NewClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
+	options.ConfigureClient(&resourcesClient.Client, options.ResourceManagerAuthorizer)
+
 	syncServiceClient := storagesync.NewServicesClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&syncServiceClient.Client, options.ResourceManagerAuthorizer)
 
@@ -93,6 +104,7 @@ func NewClient(options *common.ClientOptions) *Client {
 	// (which should fix #2977) when the storage clients have been moved in here
 	client := Client{
 		AccountsClient:              &accountsClient,
+		AccountsFailoverClient:      &accountsFailoverClient,
 		LocalUsersClient:            &localUsersClient,
 		FileSystemsClient:           &fileSystemsClient,
 		ADLSGen2PathsClient:         &adlsGen2PathsClient,
@@ -109,79 +121,44 @@ func NewClient(options *common.ClientOptions) *Client {
 		SyncGroupsClient:            &syncGroupsClient,
 
 		resourceManagerAuthorizer: options.ResourceManagerAuthorizer,
-	}
-
-	if options.StorageUseAzureAD {
-		client.storageAdAuth = &options.StorageAuthorizer
+		resourcesClient:           &resourcesClient,
+		storageAuthorizer:         options.StorageAuthorizer,
+		storageUseAzureAD:         options.StorageUseAzureAD,
 	}
 
 	return &client
 }
 
 func (client Client) AccountsDataPlaneClient(ctx context.Context, account accountDetails) (*accounts.Client, error) {
-	if client.storageAdAuth != nil {
-		accountsClient := accounts.NewWithEnvironment(client.Environment)
-		accountsClient.Client.Authorizer = *client.storageAdAuth
-		return &accountsClient, nil
-	}
-
-	accountKey, err := account.AccountKey(ctx, client)
+	storageAuth, usesAzureAD, err := client.dataPlaneAuthorizer(ctx, account, autorest.SharedKey)
 	if err != nil {
-		return nil, fmt.Errorf("retrieving Account Key: %s", err)
-	}
-
-	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKey)
-	if err != nil {
-		return nil, fmt.Errorf("building Authorizer: %+v", err)
+		return nil, err
 	}
 
 	accountsClient := accounts.NewWithEnvironment(client.Environment)
-	accountsClient.Client.Authorizer = storageAuth
+	configureDataPlaneClient(&accountsClient.Client, storageAuth, usesAzureAD, account.name, "Storage Account Contributor")
 	return &accountsClient, nil
 }
 
 func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*blobs.Client, error) {
-	if client.storageAdAuth != nil {
-		blobsClient := blobs.NewWithEnvironment(client.Environment)
-		blobsClient.Client.Authorizer = *client.storageAdAuth
-		return &blobsClient, nil
-	}
-
-	accountKey, err := account.AccountKey(ctx, client)
+	storageAuth, usesAzureAD, err := client.dataPlaneAuthorizer(ctx, account, autorest.SharedKey)
 	if err != nil {
-		return nil, fmt.Errorf("retrieving Account Key: %s", err)
-	}
-
-	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKey)
-	if err != nil {
-		return nil, fmt.Errorf("building Authorizer: %+v", err)
+		return nil, err
 	}
 
 	blobsClient := blobs.NewWithEnvironment(client.Environment)
-	blobsClient.Client.Authorizer = storageAuth
+	configureDataPlaneClient(&blobsClient.Client, storageAuth, usesAzureAD, account.name, "Storage Blob Data Contributor")
 	return &blobsClient, nil
 }
 
 func (client Client) ContainersClient(ctx context.Context, account accountDetails) (shim.StorageContainerWrapper, error) {
-	if client.storageAdAuth != nil {
-		containersClient := containers.NewWithEnvironment(client.Environment)
-		containersClient.Client.Authorizer = *client.storageAdAuth
-		shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
-		return shim, nil
-	}
-
-	accountKey, err := account.AccountKey(ctx, client)
+	storageAuth, usesAzureAD, err := client.dataPlaneAuthorizer(ctx, account, autorest.SharedKey)
 	if err != nil {
-		return nil, fmt.Errorf("retrieving Account Key: %s", err)
-	}
-
-	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKey)
-	if err != nil {
-		return nil, fmt.Errorf("building Authorizer: %+v", err)
+		return nil, err
 	}
 
 	containersClient := containers.NewWithEnvironment(client.Environment)
-	containersClient.Client.Authorizer = storageAuth
+	configureDataPlaneClient(&containersClient.Client, storageAuth, usesAzureAD, account.name, "Storage Blob Data Contributor")
 
 	shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 	return shim, nil
@@ -190,14 +167,9 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 func (client Client) FileShareDirectoriesClient(ctx context.Context, account accountDetails) (*directories.Client, error) {
 	// NOTE: Files do not support AzureAD Authentication
 
-	accountKey, err := account.AccountKey(ctx, client)
+	storageAuth, err := client.sharedKeyOnlyAuthorizer(ctx, account, autorest.SharedKeyLite, "File Shares")
 	if err != nil {
-		return nil, fmt.Errorf("retrieving Account Key: %s", err)
-	}
-
-	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKeyLite)
-	if err != nil {
-		return nil, fmt.Errorf("building Authorizer: %+v", err)
+		return nil, err
 	}
 
 	directoriesClient := directories.NewWithEnvironment(client.Environment)
@@ -208,14 +180,9 @@ func (client Client) FileShareDirectoriesClient(ctx context.Context, account acc
 func (client Client) FileShareFilesClient(ctx context.Context, account accountDetails) (*files.Client, error) {
 	// NOTE: Files do not support AzureAD Authentication
 
-	accountKey, err := account.AccountKey(ctx, client)
-	if err != nil {
-		return nil, fmt.Errorf("retrieving Account Key: %s", err)
-	}
-
-	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKeyLite)
+	storageAuth, err := client.sharedKeyOnlyAuthorizer(ctx, account, autorest.SharedKeyLite, "File Shares")
 	if err != nil {
-		return nil, fmt.Errorf("building Authorizer: %+v", err)
+		return nil, err
 	}
 
 	filesClient := files.NewWithEnvironment(client.Environment)
@@ -226,14 +193,9 @@ func (client Client) FileShareFilesClient(ctx context.Context, account accountDe
 func (client Client) FileSharesClient(ctx context.Context, account accountDetails) (shim.StorageShareWrapper, error) {
 	// NOTE: Files do not support AzureAD Authentication
 
-	accountKey, err := account.AccountKey(ctx, client)
+	storageAuth, err := client.sharedKeyOnlyAuthorizer(ctx, account, autorest.SharedKeyLite, "File Shares")
 	if err != nil {
-		return nil, fmt.Errorf("retrieving Account Key: %s", err)
-	}
-
-	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKeyLite)
-	if err != nil {
-		return nil, fmt.Errorf("building Authorizer: %+v", err)
+		return nil, err
 	}
 
 	sharesClient := shares.NewWithEnvironment(client.Environment)
@@ -243,38 +205,22 @@ func (client Client) FileSharesClient(ctx context.Context, account accountDetail
 }
 
 func (client Client) QueuesClient(ctx context.Context, account accountDetails) (shim.StorageQueuesWrapper, error) {
-	if client.storageAdAuth != nil {
-		queueClient := queues.NewWithEnvironment(client.Environment)
-		queueClient.Client.Authorizer = *client.storageAdAuth
-		return shim.NewDataPlaneStorageQueueWrapper(&queueClient), nil
-	}
-
-	accountKey, err := account.AccountKey(ctx, client)
+	storageAuth, usesAzureAD, err := client.dataPlaneAuthorizer(ctx, account, autorest.SharedKeyLite)
 	if err != nil {
-		return nil, fmt.Errorf("retrieving Account Key: %s", err)
-	}
-
-	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKeyLite)
-	if err != nil {
-		return nil, fmt.Errorf("building Authorizer: %+v", err)
+		return nil, err
 	}
 
 	queuesClient := queues.NewWithEnvironment(client.Environment)
-	queuesClient.Client.Authorizer = storageAuth
+	configureDataPlaneClient(&queuesClient.Client, storageAuth, usesAzureAD, account.name, "Storage Queue Data Contributor")
 	return shim.NewDataPlaneStorageQueueWrapper(&queuesClient), nil
 }
 
 func (client Client) TableEntityClient(ctx context.Context, account accountDetails) (*entities.Client, error) {
 	// NOTE: Table Entity does not support AzureAD Authentication
 
-	accountKey, err := account.AccountKey(ctx, client)
+	storageAuth, err := client.sharedKeyOnlyAuthorizer(ctx, account, autorest.SharedKeyLiteForTable, "Tables")
 	if err != nil {
-		return nil, fmt.Errorf("retrieving Account Key: %s", err)
-	}
-
-	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKeyLiteForTable)
-	if err != nil {
-		return nil, fmt.Errorf("building Authorizer: %+v", err)
+		return nil, err
 	}
 
 	entitiesClient := entities.NewWithEnvironment(client.Environment)
@@ -285,14 +231,9 @@ func (client Client) TableEntityClient(ctx context.Context, account accountDetai
 func (client Client) TablesClient(ctx context.Context, account accountDetails) (shim.StorageTableWrapper, error) {
 	// NOTE: Tables do not support AzureAD Authentication
 
-	accountKey, err := account.AccountKey(ctx, client)
-	if err != nil {
-		return nil, fmt.Errorf("retrieving Account Key: %s", err)
-	}
-
-	storageAuth, err := autorest.NewSharedKeyAuthorizer(account.name, *accountKey, autorest.SharedKeyLiteForTable)
+	storageAuth, err := client.sharedKeyOnlyAuthorizer(ctx, account, autorest.SharedKeyLiteForTable, "Tables")
 	if err != nil {
-		return nil, fmt.Errorf("building Authorizer: %+v", err)
+		return nil, err
 	}
 
 	tablesClient := tables.NewWithEnvironment(client.Environment)
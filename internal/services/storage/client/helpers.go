@@ -4,39 +4,60 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"sync"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-09-01/storage" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/cache"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
-var (
-	storageAccountsCache = map[string]accountDetails{}
+const (
+	// storageAccountCacheTTL is how long the details of a Storage Account are cached for before they're
+	// looked up again, which ensures that (for example) rotated Account Keys are eventually picked up
+	storageAccountCacheTTL = 10 * time.Minute
 
-	accountsLock    = sync.RWMutex{}
-	credentialsLock = sync.RWMutex{}
+	// storageAccountCacheMaxEntries is the maximum number of Storage Accounts which are cached at any one time
+	storageAccountCacheMaxEntries = 1000
 )
 
+var storageAccountsCache = cache.New[accountDetails](storageAccountCacheTTL, storageAccountCacheMaxEntries)
+
 type accountDetails struct {
 	ID            string
 	ResourceGroup string
 	Properties    *storage.AccountProperties
 
 	accountKey *string
+	authMode   DataPlaneAuthMode
 	name       string
 }
 
 func (ad *accountDetails) AccountKey(ctx context.Context, client Client) (*string, error) {
-	credentialsLock.Lock()
-	defer credentialsLock.Unlock()
+	unlock := storageAccountsCache.Lock(cacheKeyForAccount(ad.name))
+	defer unlock()
 
 	if ad.accountKey != nil {
 		return ad.accountKey, nil
 	}
 
+	// another resource may have retrieved the Account Key for this Storage Account in the meantime
+	if existing, ok := getAccountFromCache(ad.name); ok && existing.accountKey != nil {
+		ad.accountKey = existing.accountKey
+		return ad.accountKey, nil
+	}
+
 	log.Printf("[DEBUG] Cache Miss - looking up the account key for storage account %q..", ad.name)
 	props, err := client.AccountsClient.ListKeys(ctx, ad.ResourceGroup, ad.name, storage.ListKeyExpandKerb)
 	if err != nil {
+		if utils.ResponseWasForbidden(props.Response) {
+			return nil, listKeysForbiddenError{
+				accountName:   ad.name,
+				resourceGroup: ad.ResourceGroup,
+				err:           err,
+			}
+		}
 		return nil, fmt.Errorf("Listing Keys for Storage Account %q (Resource Group %q): %+v", ad.name, ad.ResourceGroup, err)
 	}
 
@@ -47,74 +68,102 @@ func (ad *accountDetails) AccountKey(ctx context.Context, client Client) (*strin
 	keys := *props.Keys
 	ad.accountKey = keys[0].Value
 
-	// force-cache this
-	storageAccountsCache[ad.name] = *ad
+	// force-cache this - the authentication mode is specific to the resource using these details, so isn't cached
+	cached := *ad
+	cached.authMode = ""
+	storageAccountsCache.Set(cacheKeyForAccount(ad.name), cached)
 
 	return ad.accountKey, nil
 }
 
 func (client Client) AddToCache(accountName string, props storage.Account) error {
-	accountsLock.Lock()
-	defer accountsLock.Unlock()
-
 	account, err := populateAccountDetails(accountName, props)
 	if err != nil {
 		return err
 	}
 
-	storageAccountsCache[accountName] = *account
+	storageAccountsCache.Set(cacheKeyForAccount(accountName), *account)
 
 	return nil
 }
 
 func (client Client) RemoveAccountFromCache(accountName string) {
-	accountsLock.Lock()
-	delete(storageAccountsCache, accountName)
-	accountsLock.Unlock()
+	storageAccountsCache.Delete(cacheKeyForAccount(accountName))
 }
 
+// FindAccount returns the details for the Storage Account with the specified name, using the cached
+// details where possible. If the Storage Account doesn't exist `nil` is returned.
 func (client Client) FindAccount(ctx context.Context, accountName string) (*accountDetails, error) {
-	accountsLock.Lock()
-	defer accountsLock.Unlock()
-
-	if existing, ok := storageAccountsCache[accountName]; ok {
+	if existing, ok := getAccountFromCache(accountName); ok {
 		return &existing, nil
 	}
 
-	accountsPage, err := client.AccountsClient.List(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("retrieving storage accounts: %+v", err)
+	unlock := storageAccountsCache.Lock(cacheKeyForAccount(accountName))
+	defer unlock()
+
+	// another resource may have looked up this Storage Account whilst we were waiting on the lock
+	if existing, ok := getAccountFromCache(accountName); ok {
+		return &existing, nil
 	}
 
-	var accounts []storage.Account
-	for accountsPage.NotDone() {
-		accounts = append(accounts, accountsPage.Values()...)
-		err = accountsPage.NextWithContext(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("retrieving next page of storage accounts: %+v", err)
-		}
+	// Storage Account names are globally unique, so rather than listing every Storage Account within the
+	// Subscription (which is slow and prone to throttling in large Subscriptions) we can look up the
+	// Resource ID for this specific Storage Account and then retrieve it from within its Resource Group
+	log.Printf("[DEBUG] Cache Miss - looking up the Resource ID for storage account %q..", accountName)
+	filter := fmt.Sprintf("resourceType eq 'Microsoft.Storage/storageAccounts' and name eq '%s'", accountName)
+	result, err := client.resourcesClient.List(ctx, filter, "", utils.Int32(5))
+	if err != nil {
+		return nil, fmt.Errorf("listing resources matching %q: %+v", filter, err)
 	}
 
-	for _, v := range accounts {
-		if v.Name == nil {
-			continue
+	for result.NotDone() {
+		for _, v := range result.Values() {
+			if v.ID == nil {
+				continue
+			}
+
+			id, err := parse.StorageAccountID(*v.ID)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %q: %+v", *v.ID, err)
+			}
+			if !strings.EqualFold(id.Name, accountName) {
+				continue
+			}
+
+			props, err := client.AccountsClient.GetProperties(ctx, id.ResourceGroup, id.Name, "")
+			if err != nil {
+				if utils.ResponseWasNotFound(props.Response) {
+					return nil, nil
+				}
+				return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			account, err := populateAccountDetails(id.Name, props)
+			if err != nil {
+				return nil, err
+			}
+
+			storageAccountsCache.Set(cacheKeyForAccount(accountName), *account)
+
+			return account, nil
 		}
 
-		account, err := populateAccountDetails(*v.Name, v)
-		if err != nil {
-			return nil, err
+		if err := result.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("iterating over results: %+v", err)
 		}
-
-		storageAccountsCache[*v.Name] = *account
-	}
-
-	if existing, ok := storageAccountsCache[accountName]; ok {
-		return &existing, nil
 	}
 
 	return nil, nil
 }
 
+func getAccountFromCache(accountName string) (accountDetails, bool) {
+	return storageAccountsCache.Get(cacheKeyForAccount(accountName))
+}
+
+func cacheKeyForAccount(accountName string) string {
+	return strings.ToLower(accountName)
+}
+
 func populateAccountDetails(accountName string, props storage.Account) (*accountDetails, error) {
 	if props.ID == nil {
 		return nil, fmt.Errorf("`id` was nil for Account %q", accountName)
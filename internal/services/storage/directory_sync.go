@@ -0,0 +1,383 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/workerpool"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/file/directories"
+	"github.com/tombuildsstuff/giovanni/storage/2020-08-04/file/files"
+)
+
+const directorySyncDefaultContentType = "application/octet-stream"
+
+type directorySyncFile struct {
+	// RelativePath is the slash-separated path of this file relative to the Source Directory
+	RelativePath string
+	LocalPath    string
+	ContentMD5   string
+	ContentType  string
+}
+
+// directorySyncTarget is implemented by each of the destinations a local directory can be synchronised into
+type directorySyncTarget interface {
+	upload(ctx context.Context, file directorySyncFile) error
+
+	// contentMD5 returns the (base64 encoded) Content MD5 of the remote file, or nil if it doesn't exist
+	contentMD5(ctx context.Context, relativePath string) (*string, error)
+
+	delete(ctx context.Context, relativePath string) error
+}
+
+// buildDirectorySyncFiles walks the specified directory and returns the files matching the include and
+// exclude patterns, keyed by their path relative to the source directory
+func buildDirectorySyncFiles(sourceDirectory string, include, exclude []string, contentTypes map[string]string) (map[string]directorySyncFile, error) {
+	info, err := os.Stat(sourceDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving information for Source Directory %q: %+v", sourceDirectory, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("Source Directory %q is not a directory", sourceDirectory)
+	}
+
+	output := make(map[string]directorySyncFile)
+	err = filepath.WalkDir(sourceDirectory, func(localPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(sourceDirectory, localPath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+
+		if !directorySyncPathIsIncluded(relativePath, include, exclude) {
+			return nil
+		}
+
+		contentMD5, err := directorySyncContentMD5(localPath)
+		if err != nil {
+			return fmt.Errorf("calculating the Content MD5 for %q: %+v", localPath, err)
+		}
+
+		output[relativePath] = directorySyncFile{
+			RelativePath: relativePath,
+			LocalPath:    localPath,
+			ContentMD5:   contentMD5,
+			ContentType:  directorySyncContentType(relativePath, contentTypes),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking Source Directory %q: %+v", sourceDirectory, err)
+	}
+
+	return output, nil
+}
+
+func directorySyncPathIsIncluded(relativePath string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if directorySyncPatternMatches(pattern, relativePath) {
+			return false
+		}
+	}
+
+	if len(include) == 0 {
+		return true
+	}
+
+	for _, pattern := range include {
+		if directorySyncPatternMatches(pattern, relativePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// directorySyncPatternMatches matches a slash-separated path against a glob pattern, where each segment is
+// matched using `path.Match` and a `**` segment matches zero or more directories
+func directorySyncPatternMatches(pattern, relativePath string) bool {
+	return directorySyncSegmentsMatch(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(relativePath, "/"))
+}
+
+func directorySyncSegmentsMatch(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if directorySyncSegmentsMatch(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, err := path.Match(pattern[0], segments[0]); err != nil || !matched {
+			return false
+		}
+
+		pattern = pattern[1:]
+		segments = segments[1:]
+	}
+
+	return len(segments) == 0
+}
+
+func directorySyncContentType(relativePath string, overrides map[string]string) string {
+	extension := strings.ToLower(path.Ext(relativePath))
+	if extension == "" {
+		return directorySyncDefaultContentType
+	}
+
+	if v, ok := overrides[extension]; ok {
+		return v
+	}
+
+	if v := mime.TypeByExtension(extension); v != "" {
+		return v
+	}
+
+	return directorySyncDefaultContentType
+}
+
+func directorySyncContentMD5(localPath string) (string, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+// runDirectorySyncOperations calls the specified function for each of the paths, using up to `parallelism`
+// workers at once, and returns all of the errors which occurred
+func runDirectorySyncOperations(paths []string, parallelism int, operation func(relativePath string) error) error {
+	sort.Strings(paths)
+	return workerpool.Run(paths, parallelism, func(relativePath string) string {
+		return fmt.Sprintf("%q", relativePath)
+	}, operation)
+}
+
+type blobDirectorySyncTarget struct {
+	client *blobs.Client
+
+	accountName   string
+	containerName string
+	prefix        string
+}
+
+func (t blobDirectorySyncTarget) blobName(relativePath string) string {
+	return path.Join(t.prefix, relativePath)
+}
+
+func (t blobDirectorySyncTarget) upload(ctx context.Context, file directorySyncFile) error {
+	upload := BlobUpload{
+		Client:        t.client,
+		AccountName:   t.accountName,
+		ContainerName: t.containerName,
+		BlobName:      t.blobName(file.RelativePath),
+		ContentType:   file.ContentType,
+		ContentMD5:    file.ContentMD5,
+		Source:        file.LocalPath,
+	}
+	return upload.uploadBlockBlob(ctx)
+}
+
+func (t blobDirectorySyncTarget) contentMD5(ctx context.Context, relativePath string) (*string, error) {
+	props, err := t.client.GetProperties(ctx, t.accountName, t.containerName, t.blobName(relativePath), blobs.GetPropertiesInput{})
+	if err != nil {
+		if utils.ResponseWasNotFound(props.Response) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return utils.String(props.ContentMD5), nil
+}
+
+func (t blobDirectorySyncTarget) delete(ctx context.Context, relativePath string) error {
+	resp, err := t.client.Delete(ctx, t.accountName, t.containerName, t.blobName(relativePath), blobs.DeleteInput{
+		DeleteSnapshots: true,
+	})
+	if err != nil && !utils.ResponseWasNotFound(resp) {
+		return err
+	}
+
+	return nil
+}
+
+type fileShareDirectorySyncTarget struct {
+	filesClient       *files.Client
+	directoriesClient *directories.Client
+
+	accountName string
+	shareName   string
+	path        string
+
+	// directoriesLock guards directories, which tracks each of the directories within the File Share - the lock is
+	// only held whilst looking up the entry, the network calls are made whilst holding the lock for that directory
+	directoriesLock *sync.Mutex
+	directories     map[string]*fileShareDirectorySyncDirectory
+}
+
+type fileShareDirectorySyncDirectory struct {
+	lock   sync.Mutex
+	exists bool
+}
+
+func newFileShareDirectorySyncTarget(filesClient *files.Client, directoriesClient *directories.Client, accountName, shareName, sharePath string) fileShareDirectorySyncTarget {
+	return fileShareDirectorySyncTarget{
+		filesClient:       filesClient,
+		directoriesClient: directoriesClient,
+		accountName:       accountName,
+		shareName:         shareName,
+		path:              strings.Trim(sharePath, "/"),
+		directoriesLock:   &sync.Mutex{},
+		directories:       map[string]*fileShareDirectorySyncDirectory{},
+	}
+}
+
+// split returns the directory and file name within the File Share for the specified relative path
+func (t fileShareDirectorySyncTarget) split(relativePath string) (string, string) {
+	directory := path.Join(t.path, path.Dir(relativePath))
+	if directory == "." {
+		directory = ""
+	}
+	return directory, path.Base(relativePath)
+}
+
+func (t fileShareDirectorySyncTarget) directory(name string) *fileShareDirectorySyncDirectory {
+	t.directoriesLock.Lock()
+	defer t.directoriesLock.Unlock()
+
+	directory, ok := t.directories[name]
+	if !ok {
+		directory = &fileShareDirectorySyncDirectory{}
+		t.directories[name] = directory
+	}
+	return directory
+}
+
+func (t fileShareDirectorySyncTarget) ensureDirectory(ctx context.Context, directory string) error {
+	current := ""
+	for _, segment := range strings.Split(directory, "/") {
+		if segment == "" {
+			continue
+		}
+		current = path.Join(current, segment)
+		if err := t.ensureDirectorySegment(ctx, current); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t fileShareDirectorySyncTarget) ensureDirectorySegment(ctx context.Context, name string) error {
+	directory := t.directory(name)
+	directory.lock.Lock()
+	defer directory.lock.Unlock()
+
+	if directory.exists {
+		return nil
+	}
+
+	existing, err := t.directoriesClient.Get(ctx, t.accountName, t.shareName, name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for the presence of Directory %q: %+v", name, err)
+		}
+
+		if _, err := t.directoriesClient.Create(ctx, t.accountName, t.shareName, name, directories.CreateDirectoryInput{}); err != nil {
+			return fmt.Errorf("creating Directory %q: %+v", name, err)
+		}
+	}
+
+	directory.exists = true
+	return nil
+}
+
+func (t fileShareDirectorySyncTarget) upload(ctx context.Context, file directorySyncFile) error {
+	directory, fileName := t.split(file.RelativePath)
+	if err := t.ensureDirectory(ctx, directory); err != nil {
+		return err
+	}
+
+	source, err := os.Open(file.LocalPath)
+	if err != nil {
+		return fmt.Errorf("opening: %+v", err)
+	}
+	defer source.Close()
+
+	info, err := source.Stat()
+	if err != nil {
+		return fmt.Errorf("'stat'-ing: %+v", err)
+	}
+
+	input := files.CreateInput{
+		ContentLength: info.Size(),
+		ContentType:   utils.String(file.ContentType),
+		ContentMD5:    utils.String(file.ContentMD5),
+		MetaData:      map[string]string{},
+	}
+	if _, err := t.filesClient.Create(ctx, t.accountName, t.shareName, directory, fileName, input); err != nil {
+		return fmt.Errorf("creating: %+v", err)
+	}
+
+	if info.Size() > 0 {
+		if err := t.filesClient.PutFile(ctx, t.accountName, t.shareName, directory, fileName, source, 4); err != nil {
+			return fmt.Errorf("uploading: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func (t fileShareDirectorySyncTarget) contentMD5(ctx context.Context, relativePath string) (*string, error) {
+	directory, fileName := t.split(relativePath)
+	props, err := t.filesClient.GetProperties(ctx, t.accountName, t.shareName, directory, fileName)
+	if err != nil {
+		if utils.ResponseWasNotFound(props.Response) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return utils.String(props.ContentMD5), nil
+}
+
+func (t fileShareDirectorySyncTarget) delete(ctx context.Context, relativePath string) error {
+	directory, fileName := t.split(relativePath)
+	resp, err := t.filesClient.Delete(ctx, t.accountName, t.shareName, directory, fileName)
+	if err != nil && !utils.ResponseWasNotFound(resp) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDirectorySyncPatternMatches(t *testing.T) {
+	testCases := []struct {
+		Pattern      string
+		RelativePath string
+		Expected     bool
+	}{
+		{
+			Pattern:      "*.txt",
+			RelativePath: "hello.txt",
+			Expected:     true,
+		},
+		{
+			// a single `*` doesn't match across directories
+			Pattern:      "*.txt",
+			RelativePath: "docs/hello.txt",
+			Expected:     false,
+		},
+		{
+			Pattern:      "docs/*.txt",
+			RelativePath: "docs/hello.txt",
+			Expected:     true,
+		},
+		{
+			Pattern:      "/docs/*.txt/",
+			RelativePath: "docs/hello.txt",
+			Expected:     true,
+		},
+		{
+			Pattern:      "**/*.txt",
+			RelativePath: "hello.txt",
+			Expected:     true,
+		},
+		{
+			Pattern:      "**/*.txt",
+			RelativePath: "docs/nested/hello.txt",
+			Expected:     true,
+		},
+		{
+			Pattern:      "docs/**",
+			RelativePath: "docs/nested/hello.txt",
+			Expected:     true,
+		},
+		{
+			Pattern:      "docs/**",
+			RelativePath: "images/logo.png",
+			Expected:     false,
+		},
+		{
+			Pattern:      "docs/**/hello.txt",
+			RelativePath: "docs/hello.txt",
+			Expected:     true,
+		},
+		{
+			Pattern:      "docs/**/hello.txt",
+			RelativePath: "docs/a/b/goodbye.txt",
+			Expected:     false,
+		},
+		{
+			// invalid patterns never match
+			Pattern:      "[",
+			RelativePath: "[",
+			Expected:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := directorySyncPatternMatches(tc.Pattern, tc.RelativePath); actual != tc.Expected {
+			t.Fatalf("expected %t for pattern %q and path %q but got %t", tc.Expected, tc.Pattern, tc.RelativePath, actual)
+		}
+	}
+}
+
+func TestDirectorySyncSegmentsMatch(t *testing.T) {
+	testCases := []struct {
+		Pattern  []string
+		Segments []string
+		Expected bool
+	}{
+		{
+			Pattern:  []string{},
+			Segments: []string{},
+			Expected: true,
+		},
+		{
+			Pattern:  []string{},
+			Segments: []string{"a"},
+			Expected: false,
+		},
+		{
+			Pattern:  []string{"a"},
+			Segments: []string{},
+			Expected: false,
+		},
+		{
+			Pattern:  []string{"**"},
+			Segments: []string{},
+			Expected: true,
+		},
+		{
+			Pattern:  []string{"**"},
+			Segments: []string{"a", "b", "c"},
+			Expected: true,
+		},
+		{
+			Pattern:  []string{"a", "**", "**", "d"},
+			Segments: []string{"a", "b", "c", "d"},
+			Expected: true,
+		},
+		{
+			Pattern:  []string{"a", "?", "c"},
+			Segments: []string{"a", "b", "c"},
+			Expected: true,
+		},
+		{
+			Pattern:  []string{"a", "?", "c"},
+			Segments: []string{"a", "bb", "c"},
+			Expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := directorySyncSegmentsMatch(tc.Pattern, tc.Segments); actual != tc.Expected {
+			t.Fatalf("expected %t for pattern %q and segments %q but got %t", tc.Expected, strings.Join(tc.Pattern, "/"), strings.Join(tc.Segments, "/"), actual)
+		}
+	}
+}
+
+func TestDirectorySyncContentType(t *testing.T) {
+	testCases := []struct {
+		RelativePath string
+		Overrides    map[string]string
+		Expected     string
+	}{
+		{
+			RelativePath: "README",
+			Expected:     directorySyncDefaultContentType,
+		},
+		{
+			RelativePath: "README",
+			Overrides: map[string]string{
+				"": "text/plain",
+			},
+			Expected: directorySyncDefaultContentType,
+		},
+		{
+			RelativePath: "docs/index.html",
+			Expected:     "text/html; charset=utf-8",
+		},
+		{
+			RelativePath: "docs/INDEX.HTML",
+			Expected:     "text/html; charset=utf-8",
+		},
+		{
+			RelativePath: "docs/index.html",
+			Overrides: map[string]string{
+				".html": "text/plain",
+			},
+			Expected: "text/plain",
+		},
+		{
+			RelativePath: "data/file.unknownextension",
+			Expected:     directorySyncDefaultContentType,
+		},
+		{
+			RelativePath: "data/file.unknownextension",
+			Overrides: map[string]string{
+				".unknownextension": "application/x-custom",
+			},
+			Expected: "application/x-custom",
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := directorySyncContentType(tc.RelativePath, tc.Overrides); actual != tc.Expected {
+			t.Fatalf("expected the Content Type for %q to be %q but got %q", tc.RelativePath, tc.Expected, actual)
+		}
+	}
+}
+
+func TestParseStorageDirectorySyncID(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected *storageDirectorySyncId
+	}{
+		{
+			// not a url
+			Input: "://",
+		},
+		{
+			// missing the endpoint type
+			Input: "https://account1/container1",
+		},
+		{
+			// missing the container/share name
+			Input: "https://account1.blob.core.windows.net/",
+		},
+		{
+			// unsupported endpoint type
+			Input: "https://account1.queue.core.windows.net/queue1",
+		},
+		{
+			Input: "https://account1.blob.core.windows.net/container1",
+			Expected: &storageDirectorySyncId{
+				AccountName:   "account1",
+				DomainSuffix:  "core.windows.net",
+				ContainerName: "container1",
+			},
+		},
+		{
+			Input: "https://account1.blob.core.windows.net/container1/some/path/",
+			Expected: &storageDirectorySyncId{
+				AccountName:     "account1",
+				DomainSuffix:    "core.windows.net",
+				ContainerName:   "container1",
+				DestinationPath: "some/path",
+			},
+		},
+		{
+			Input: "https://account1.file.core.chinacloudapi.cn/share1",
+			Expected: &storageDirectorySyncId{
+				AccountName:  "account1",
+				DomainSuffix: "core.chinacloudapi.cn",
+				ShareName:    "share1",
+			},
+		},
+		{
+			Input: "https://account1.file.core.windows.net/share1/some/path",
+			Expected: &storageDirectorySyncId{
+				AccountName:     "account1",
+				DomainSuffix:    "core.windows.net",
+				ShareName:       "share1",
+				DestinationPath: "some/path",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("[DEBUG] Testing %q..", tc.Input)
+
+		actual, err := parseStorageDirectorySyncID(tc.Input)
+		if err != nil {
+			if tc.Expected == nil {
+				continue
+			}
+			t.Fatalf("parsing %q: %+v", tc.Input, err)
+		}
+
+		if tc.Expected == nil {
+			t.Fatalf("expected an error parsing %q but got %+v", tc.Input, *actual)
+		}
+
+		if !reflect.DeepEqual(*actual, *tc.Expected) {
+			t.Fatalf("expected %+v but got %+v", *tc.Expected, *actual)
+		}
+	}
+}
@@ -32,10 +32,14 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 		"azurerm_storage_account_sas":                dataSourceStorageAccountSharedAccessSignature(),
 		"azurerm_storage_account":                    dataSourceStorageAccount(),
 		"azurerm_storage_blob":                       dataSourceStorageBlob(),
+		"azurerm_storage_blobs":                      dataSourceStorageBlobs(),
 		"azurerm_storage_container":                  dataSourceStorageContainer(),
+		"azurerm_storage_containers":                 dataSourceStorageContainers(),
 		"azurerm_storage_encryption_scope":           dataSourceStorageEncryptionScope(),
 		"azurerm_storage_management_policy":          dataSourceStorageManagementPolicy(),
+		"azurerm_storage_queues":                     dataSourceStorageQueues(),
 		"azurerm_storage_share":                      dataSourceStorageShare(),
+		"azurerm_storage_shares":                     dataSourceStorageShares(),
 		"azurerm_storage_sync":                       dataSourceStorageSync(),
 		"azurerm_storage_sync_group":                 dataSourceStorageSyncGroup(),
 		"azurerm_storage_table_entity":               dataSourceStorageTableEntity(),
@@ -54,6 +58,7 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_storage_encryption_scope":             resourceStorageEncryptionScope(),
 		"azurerm_storage_data_lake_gen2_filesystem":    resourceStorageDataLakeGen2FileSystem(),
 		"azurerm_storage_data_lake_gen2_path":          resourceStorageDataLakeGen2Path(),
+		"azurerm_storage_directory_sync":               resourceStorageDirectorySync(),
 		"azurerm_storage_management_policy":            resourceStorageManagementPolicy(),
 		"azurerm_storage_object_replication":           resourceStorageObjectReplication(),
 		"azurerm_storage_queue":                        resourceStorageQueue(),
@@ -75,5 +80,6 @@ func (r Registration) DataSources() []sdk.DataSource {
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		LocalUserResource{},
+		StorageAccountFailoverResource{},
 	}
 }
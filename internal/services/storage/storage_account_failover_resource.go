@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-09-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+const (
+	storageAccountFailoverTypeUnplanned = "Unplanned"
+
+	// storageAccountFailoverNameFormat is the format of the time at which the failover was performed, which is used
+	// as the name of the failover within the ID
+	storageAccountFailoverNameFormat = "20060102150405"
+)
+
+type StorageAccountFailoverModel struct {
+	StorageAccountId  string                 `tfschema:"storage_account_id"`
+	FailoverType      string                 `tfschema:"failover_type"`
+	Triggers          map[string]interface{} `tfschema:"triggers"`
+	LastSyncTime      string                 `tfschema:"last_sync_time"`
+	PrimaryLocation   string                 `tfschema:"primary_location"`
+	SecondaryLocation string                 `tfschema:"secondary_location"`
+}
+
+// StorageAccountFailoverResource fails a geo-redundant Storage Account over to its secondary location. Since
+// the failover is only performed once, every argument forces a new resource - `triggers` can be used to
+// perform another failover (e.g. to fail back) when other values change. Each failover has its own ID, made
+// up of the ID of the Storage Account and the time at which the failover was performed.
+type StorageAccountFailoverResource struct{}
+
+var _ sdk.Resource = StorageAccountFailoverResource{}
+
+func (r StorageAccountFailoverResource) ResourceType() string {
+	return "azurerm_storage_account_failover"
+}
+
+func (r StorageAccountFailoverResource) ModelObject() interface{} {
+	return &StorageAccountFailoverModel{}
+}
+
+func (r StorageAccountFailoverResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.StorageAccountFailoverID
+}
+
+func (r StorageAccountFailoverResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_account_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: storageaccounts.ValidateStorageAccountID,
+		},
+
+		"failover_type": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  storageAccountFailoverTypeUnplanned,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(storageaccounts.FailoverTypePlanned),
+				storageAccountFailoverTypeUnplanned,
+			}, false),
+		},
+
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r StorageAccountFailoverResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"last_sync_time": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"primary_location": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"secondary_location": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r StorageAccountFailoverResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		// a failover can take over an hour to complete
+		Timeout: 3 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.AccountsFailoverClient
+
+			var model StorageAccountFailoverModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id, err := storageaccounts.ParseStorageAccountID(model.StorageAccountId)
+			if err != nil {
+				return err
+			}
+
+			existing, err := client.GetProperties(ctx, *id, storageaccounts.GetPropertiesOperationOptions{
+				Expand: pointer.To(storageaccounts.StorageAccountExpandGeoReplicationStats),
+			})
+			if err != nil {
+				return fmt.Errorf("retrieving the geo-replication stats for %s: %+v", *id, err)
+			}
+			if existing.Model == nil || existing.Model.Properties == nil || existing.Model.Properties.GeoReplicationStats == nil {
+				return fmt.Errorf("retrieving the geo-replication stats for %s: `geoReplicationStats` was nil - failover is only supported for geo-redundant Storage Accounts", *id)
+			}
+			stats := existing.Model.Properties.GeoReplicationStats
+			if !pointer.From(stats.CanFailover) {
+				status := ""
+				if stats.Status != nil {
+					status = string(*stats.Status)
+				}
+				return fmt.Errorf("%s can't currently be failed over (the geo-replication status is %q)", *id, status)
+			}
+			// writes after this point in time weren't replicated to the secondary location and are lost in an unplanned failover
+			model.LastSyncTime = pointer.From(stats.LastSyncTime)
+
+			options := storageaccounts.DefaultFailoverOperationOptions()
+			if model.FailoverType == string(storageaccounts.FailoverTypePlanned) {
+				options.FailoverType = pointer.To(storageaccounts.FailoverTypePlanned)
+			}
+
+			log.Printf("[DEBUG] Performing a %s Failover of %s (last sync time %q)..", model.FailoverType, *id, model.LastSyncTime)
+			if err := client.FailoverThenPoll(ctx, *id, options); err != nil {
+				return fmt.Errorf("failing over %s: %+v", *id, err)
+			}
+
+			resp, err := client.GetProperties(ctx, *id, storageaccounts.DefaultGetPropertiesOperationOptions())
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if resp.Model != nil && resp.Model.Properties != nil {
+				model.PrimaryLocation = location.NormalizeNilable(resp.Model.Properties.PrimaryLocation)
+				model.SecondaryLocation = location.NormalizeNilable(resp.Model.Properties.SecondaryLocation)
+			}
+
+			failoverId := parse.NewStorageAccountFailoverID(id.SubscriptionId, id.ResourceGroupName, id.StorageAccountName, time.Now().UTC().Format(storageAccountFailoverNameFormat))
+			metadata.SetID(failoverId)
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r StorageAccountFailoverResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.AccountsFailoverClient
+
+			id, err := parse.StorageAccountFailoverID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			accountId := storageaccounts.NewStorageAccountID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName)
+			resp, err := client.GetProperties(ctx, accountId, storageaccounts.DefaultGetPropertiesOperationOptions())
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", accountId, err)
+			}
+
+			var state StorageAccountFailoverModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+			state.StorageAccountId = accountId.ID()
+			if state.FailoverType == "" {
+				state.FailoverType = storageAccountFailoverTypeUnplanned
+			}
+
+			// the locations are intentionally left as they were at the time of the failover
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageAccountFailoverResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// a failover can't be undone - failing back requires another failover
+			return nil
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-09-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type StorageAccountFailoverResource struct{}
+
+func TestAccStorageAccountFailover_unplanned(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account_failover", "test")
+	r := StorageAccountFailoverResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "Unplanned"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("last_sync_time").IsSet(),
+				check.That(data.ResourceName).Key("primary_location").IsSet(),
+			),
+		},
+	})
+}
+
+func TestAccStorageAccountFailover_planned(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account_failover", "test")
+	r := StorageAccountFailoverResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "Planned"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("primary_location").IsSet(),
+				check.That(data.ResourceName).Key("secondary_location").IsSet(),
+			),
+		},
+	})
+}
+
+func (r StorageAccountFailoverResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.StorageAccountFailoverID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountId := storageaccounts.NewStorageAccountID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName)
+	resp, err := client.Storage.AccountsFailoverClient.GetProperties(ctx, accountId, storageaccounts.DefaultGetPropertiesOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", accountId, err)
+	}
+
+	return utils.Bool(resp.Model != nil), nil
+}
+
+func (r StorageAccountFailoverResource) basic(data acceptance.TestData, failoverType string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "unlikely23exst2acct%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "GRS"
+
+  # the location and replication type of the Storage Account change as a result of the failover
+  lifecycle {
+    ignore_changes = [location, account_replication_type]
+  }
+}
+
+resource "azurerm_storage_account_failover" "test" {
+  storage_account_id = azurerm_storage_account.test.id
+  failover_type      = "%[4]s"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, failoverType)
+}
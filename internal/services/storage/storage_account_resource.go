@@ -861,6 +861,29 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"geo_replication_stats": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"status": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"last_sync_time": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"can_failover": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"primary_blob_endpoint": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -1933,7 +1956,21 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	resp, err := client.GetProperties(ctx, id.ResourceGroup, id.Name, "")
+	// the geo-replication stats are only available (and can only be requested) for geo-redundant Storage Accounts, so
+	// they're requested alongside the Storage Account when the state shows that it's geo-redundant
+	expand := storage.AccountExpand("")
+	if storageAccountIsGeoRedundant(storage.SkuName(fmt.Sprintf("%s_%s", d.Get("account_tier").(string), d.Get("account_replication_type").(string)))) {
+		expand = storage.AccountExpandGeoReplicationStats
+	}
+
+	resp, err := client.GetProperties(ctx, id.ResourceGroup, id.Name, expand)
+	if err != nil && expand != "" && !utils.ResponseWasNotFound(resp.Response) {
+		// the stats are informational and aren't always available (e.g. whilst a failover is in progress or when the
+		// secondary location is unavailable), so an error retrieving them shouldn't prevent the Storage Account being read
+		log.Printf("[DEBUG] unable to retrieve the geo-replication stats for %s - treating them as empty: %+v", *id, err)
+		expand = ""
+		resp, err = client.GetProperties(ctx, id.ResourceGroup, id.Name, expand)
+	}
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
 			d.SetId("")
@@ -1978,6 +2015,14 @@ func resourceStorageAccountRead(d *pluginsdk.ResourceData, meta interface{}) err
 		d.Set("account_replication_type", strings.Split(fmt.Sprintf("%v", sku.Name), "_")[1])
 	}
 
+	geoReplicationStats := make([]interface{}, 0)
+	if expand == storage.AccountExpandGeoReplicationStats && resp.AccountProperties != nil {
+		geoReplicationStats = flattenStorageAccountGeoReplicationStats(resp.AccountProperties.GeoReplicationStats)
+	}
+	if err := d.Set("geo_replication_stats", geoReplicationStats); err != nil {
+		return fmt.Errorf("setting `geo_replication_stats`: %+v", err)
+	}
+
 	if props := resp.AccountProperties; props != nil {
 		d.Set("access_tier", props.AccessTier)
 		if err := d.Set("azure_files_authentication", flattenArmStorageAccountAzureFilesAuthentication(props.AzureFilesIdentityBasedAuthentication)); err != nil {
@@ -3655,6 +3700,33 @@ func flattenEdgeZone(input *storage.ExtendedLocation) string {
 	return edgezones.NormalizeNilable(input.Name)
 }
 
+func storageAccountIsGeoRedundant(input storage.SkuName) bool {
+	switch input {
+	case storage.SkuNameStandardGRS, storage.SkuNameStandardRAGRS, storage.SkuNameStandardGZRS, storage.SkuNameStandardRAGZRS:
+		return true
+	}
+	return false
+}
+
+func flattenStorageAccountGeoReplicationStats(input *storage.GeoReplicationStats) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	lastSyncTime := ""
+	if input.LastSyncTime != nil {
+		lastSyncTime = input.LastSyncTime.Format(time.RFC3339)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"status":         string(input.Status),
+			"last_sync_time": lastSyncTime,
+			"can_failover":   input.CanFailover != nil && *input.CanFailover,
+		},
+	}
+}
+
 func expandStorageAccountSASPolicy(input []interface{}) *storage.SasPolicy {
 	if len(input) == 0 {
 		return nil
@@ -29,6 +29,7 @@ func TestAccStorageAccount_basic(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("account_tier").HasValue("Standard"),
 				check.That(data.ResourceName).Key("account_replication_type").HasValue("LRS"),
+				check.That(data.ResourceName).Key("geo_replication_stats.#").HasValue("0"),
 				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
 				check.That(data.ResourceName).Key("tags.environment").HasValue("production"),
 			),
@@ -40,6 +41,7 @@ func TestAccStorageAccount_basic(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("account_tier").HasValue("Standard"),
 				check.That(data.ResourceName).Key("account_replication_type").HasValue("GRS"),
+				check.That(data.ResourceName).Key("geo_replication_stats.#").HasValue("1"),
 				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
 				check.That(data.ResourceName).Key("tags.environment").HasValue("staging"),
 				check.That(data.ResourceName).Key("cross_tenant_replication_enabled").HasValue("true"),
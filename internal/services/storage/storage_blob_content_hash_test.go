@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestStorageBlobPlannedContentHash(t *testing.T) {
+	directory := t.TempDir()
+	existing := filepath.Join(directory, "existing.txt")
+	if err := os.WriteFile(existing, []byte("hello world"), 0o600); err != nil {
+		t.Fatalf("writing %q: %+v", existing, err)
+	}
+
+	testData := []struct {
+		Name          string
+		Source        string
+		SourceContent string
+		Expected      *string
+	}{
+		{
+			Name:     "No Contents",
+			Expected: utils.String(""),
+		},
+		{
+			Name:          "Source Content",
+			SourceContent: "hello world",
+			Expected:      utils.String("5eb63bbbe01eeed093cb22bb8f5acdc3"),
+		},
+		{
+			Name:     "Existing Source",
+			Source:   existing,
+			Expected: utils.String("5eb63bbbe01eeed093cb22bb8f5acdc3"),
+		},
+		{
+			Name:     "Missing Source",
+			Source:   filepath.Join(directory, "missing.txt"),
+			Expected: nil,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := storageBlobPlannedContentHash(v.Source, v.SourceContent)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+
+		if v.Expected == nil {
+			if actual != nil {
+				t.Fatalf("expected the hash to be unknown but got %q", *actual)
+			}
+			continue
+		}
+		if actual == nil {
+			t.Fatalf("expected the hash to be %q but it was unknown", *v.Expected)
+		}
+		if *actual != *v.Expected {
+			t.Fatalf("expected the hash to be %q but got %q", *v.Expected, *actual)
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package storage
 
 import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
+	"os"
 	"strings"
 	"time"
 
@@ -107,48 +112,85 @@ func resourceStorageBlob() *pluginsdk.Resource {
 			"source": {
 				Type:          pluginsdk.TypeString,
 				Optional:      true,
-				ForceNew:      true,
 				ConflictsWith: []string{"source_uri", "source_content"},
 			},
 
 			"source_content": {
 				Type:          pluginsdk.TypeString,
 				Optional:      true,
-				ForceNew:      true,
 				ConflictsWith: []string{"source", "source_uri"},
 			},
 
 			"source_uri": {
 				Type:          pluginsdk.TypeString,
 				Optional:      true,
-				ForceNew:      true,
 				ConflictsWith: []string{"source", "source_content"},
 			},
 
 			"content_md5": {
 				Type:          pluginsdk.TypeString,
 				Optional:      true,
-				ForceNew:      true,
+				Computed:      true,
 				ConflictsWith: []string{"source_uri"},
 			},
 
+			"content_hash": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"url": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
 			},
 
 			"parallelism": {
-				// TODO: @tombuildsstuff - a note this only works for Page blobs
+				// NOTE: this is used when uploading Page blobs and when updating the contents of Block blobs
 				Type:         pluginsdk.TypeInt,
 				Optional:     true,
 				Default:      8,
-				ForceNew:     true,
 				ValidateFunc: validation.IntAtLeast(1),
 			},
 
 			"metadata": MetaDataComputedSchema(),
+
+			"data_plane_auth_mode": DataPlaneAuthModeSchema(),
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceStorageBlobCustomizeDiff),
+	}
+}
+
+func resourceStorageBlobCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	// only the contents of Block blobs can be updated in-place, Append and Page blobs must be recreated
+	if !strings.EqualFold(d.Get("type").(string), "Block") {
+		for _, key := range []string{"source", "source_content", "source_uri", "content_md5"} {
+			if d.HasChange(key) {
+				if err := d.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if !d.NewValueKnown("source") || !d.NewValueKnown("source_content") {
+		return d.SetNewComputed("content_hash")
+	}
+
+	// hashing the contents at plan time means that changes to the local file are detected without `content_md5`
+	contentHash, err := storageBlobPlannedContentHash(d.Get("source").(string), d.Get("source_content").(string))
+	if err != nil {
+		return err
+	}
+	if contentHash == nil {
+		return d.SetNewComputed("content_hash")
+	}
+	if *contentHash != d.Get("content_hash").(string) {
+		return d.SetNew("content_hash", *contentHash)
 	}
+
+	return nil
 }
 
 func resourceStorageBlobCreate(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -167,6 +209,7 @@ func resourceStorageBlobCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	if account == nil {
 		return fmt.Errorf("Unable to locate Storage Account %q!", accountName)
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 
 	blobsClient, err := storageClient.BlobsClient(ctx, *account)
 	if err != nil {
@@ -187,16 +230,19 @@ func resourceStorageBlobCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 	}
 
-	contentMD5Raw := d.Get("content_md5").(string)
-	contentMD5 := ""
-	if contentMD5Raw != "" {
-		// Azure uses a Base64 encoded representation of the standard MD5 sum of the file
-		contentMD5, err = convertHexToBase64Encoding(d.Get("content_md5").(string))
+	contentHash := ""
+	if strings.EqualFold(d.Get("type").(string), "Block") {
+		contentHash, err = storageBlobContentHash(d.Get("source").(string), d.Get("source_content").(string))
 		if err != nil {
-			return fmt.Errorf("failed to base64 encode `content_md5` value: %s", err)
+			return err
 		}
 	}
 
+	contentMD5, err := expandStorageBlobContentMD5(d.Get("content_md5").(string), contentHash)
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[DEBUG] Creating Blob %q in Container %q within Storage Account %q..", name, containerName, accountName)
 	metaDataRaw := d.Get("metadata").(map[string]interface{})
 	blobInput := BlobUpload{
@@ -222,6 +268,7 @@ func resourceStorageBlobCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	log.Printf("[DEBUG] Created Blob %q in Container %q within Storage Account %q.", name, containerName, accountName)
 
 	d.SetId(id)
+	d.Set("content_hash", contentHash)
 
 	return resourceStorageBlobUpdate(d, meta)
 }
@@ -243,13 +290,69 @@ func resourceStorageBlobUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 	if account == nil {
 		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 
 	blobsClient, err := storageClient.BlobsClient(ctx, *account)
 	if err != nil {
 		return fmt.Errorf("building Blobs Client: %s", err)
 	}
 
-	if d.HasChange("access_tier") {
+	// when the contents of the blob are replaced the properties & metadata are reset, so need to be re-applied
+	contentUpdated := false
+	updatedContentMD5 := ""
+	oldContentHash, newContentHash := d.GetChange("content_hash")
+	contentHashChanged := oldContentHash.(string) != newContentHash.(string)
+	if contentHashChanged && oldContentHash.(string) == "" {
+		// the `content_hash` isn't known for Blobs which were imported or created by an older version of the Provider,
+		// so compare against the MD5 of the existing contents instead
+		contentHashChanged = !strings.EqualFold(d.Get("content_md5").(string), newContentHash.(string))
+	}
+
+	if !d.IsNewResource() && (d.HasChanges("source", "source_content", "source_uri", "content_md5") || contentHashChanged) {
+		log.Printf("[DEBUG] Updating Contents for Blob %q (Container %q / Account %q)...", id.BlobName, id.ContainerName, id.AccountName)
+		contentHash, err := storageBlobContentHash(d.Get("source").(string), d.Get("source_content").(string))
+		if err != nil {
+			return err
+		}
+
+		if d.Get("source_uri").(string) == "" {
+			// a `content_md5` which is unchanged was read from the previous contents, so shouldn't be used for the new contents
+			contentMD5Raw := ""
+			if d.HasChange("content_md5") {
+				contentMD5Raw = d.Get("content_md5").(string)
+			}
+			updatedContentMD5, err = expandStorageBlobContentMD5(contentMD5Raw, contentHash)
+			if err != nil {
+				return err
+			}
+		}
+
+		blobInput := BlobUpload{
+			AccountName:   id.AccountName,
+			ContainerName: id.ContainerName,
+			BlobName:      id.BlobName,
+			Client:        blobsClient,
+
+			BlobType:      d.Get("type").(string),
+			CacheControl:  d.Get("cache_control").(string),
+			ContentType:   d.Get("content_type").(string),
+			ContentMD5:    updatedContentMD5,
+			MetaData:      ExpandMetaData(d.Get("metadata").(map[string]interface{})),
+			Parallelism:   d.Get("parallelism").(int),
+			Source:        d.Get("source").(string),
+			SourceContent: d.Get("source_content").(string),
+			SourceUri:     d.Get("source_uri").(string),
+		}
+		if err := blobInput.Update(ctx); err != nil {
+			return fmt.Errorf("updating Contents for Blob %q (Container %q / Account %q): %s", id.BlobName, id.ContainerName, id.AccountName, err)
+		}
+
+		d.Set("content_hash", contentHash)
+		contentUpdated = true
+		log.Printf("[DEBUG] Updated Contents for Blob %q (Container %q / Account %q).", id.BlobName, id.ContainerName, id.AccountName)
+	}
+
+	if d.HasChange("access_tier") || (contentUpdated && d.Get("access_tier").(string) != "") {
 		// this is only applicable for Gen2/BlobStorage accounts
 		log.Printf("[DEBUG] Updating Access Tier for Blob %q (Container %q / Account %q)...", id.BlobName, id.ContainerName, id.AccountName)
 		accessTier := blobs.AccessTier(d.Get("access_tier").(string))
@@ -261,15 +364,19 @@ func resourceStorageBlobUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		log.Printf("[DEBUG] Updated Access Tier for Blob %q (Container %q / Account %q).", id.BlobName, id.ContainerName, id.AccountName)
 	}
 
-	if d.HasChange("content_type") || d.HasChange("cache_control") {
+	if d.HasChange("content_type") || d.HasChange("cache_control") || contentUpdated {
 		log.Printf("[DEBUG] Updating Properties for Blob %q (Container %q / Account %q)...", id.BlobName, id.ContainerName, id.AccountName)
 		input := blobs.SetPropertiesInput{
 			ContentType:  utils.String(d.Get("content_type").(string)),
 			CacheControl: utils.String(d.Get("cache_control").(string)),
 		}
 
-		// `content_md5` is `ForceNew` but must be included in the `SetPropertiesInput` update payload or it will be zeroed on the blob.
-		if contentMD5 := d.Get("content_md5").(string); contentMD5 != "" {
+		// `content_md5` must be included in the `SetPropertiesInput` update payload or it will be zeroed on the blob.
+		if contentUpdated {
+			if updatedContentMD5 != "" {
+				input.ContentMD5 = utils.String(updatedContentMD5)
+			}
+		} else if contentMD5 := d.Get("content_md5").(string); contentMD5 != "" {
 			data, err := convertHexToBase64Encoding(contentMD5)
 			if err != nil {
 				return fmt.Errorf("in converting hex to base64 encoding for content_md5: %s", err)
@@ -284,7 +391,7 @@ func resourceStorageBlobUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		log.Printf("[DEBUG] Updated Properties for Blob %q (Container %q / Account %q).", id.BlobName, id.ContainerName, id.AccountName)
 	}
 
-	if d.HasChange("metadata") {
+	if d.HasChange("metadata") || contentUpdated {
 		log.Printf("[DEBUG] Updating MetaData for Blob %q (Container %q / Account %q)...", id.BlobName, id.ContainerName, id.AccountName)
 		metaDataRaw := d.Get("metadata").(map[string]interface{})
 		input := blobs.SetMetaDataInput{
@@ -318,6 +425,7 @@ func resourceStorageBlobRead(d *pluginsdk.ResourceData, meta interface{}) error
 		d.SetId("")
 		return nil
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 
 	blobsClient, err := storageClient.BlobsClient(ctx, *account)
 	if err != nil {
@@ -387,6 +495,7 @@ func resourceStorageBlobDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 	if account == nil {
 		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 
 	blobsClient, err := storageClient.BlobsClient(ctx, *account)
 	if err != nil {
@@ -403,3 +512,65 @@ func resourceStorageBlobDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 
 	return nil
 }
+
+// storageBlobContentHash returns the hex encoded MD5 hash of the local file or inline content for this blob, or an empty
+// string when the contents are neither
+// storageBlobPlannedContentHash returns the hash of the contents of the blob at plan time, or nil when the `source`
+// file doesn't exist yet - for example when it's written by another resource during the apply
+func storageBlobPlannedContentHash(source, sourceContent string) (*string, error) {
+	if sourceContent == "" && source != "" {
+		if _, err := os.Stat(source); err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("retrieving information about %q: %+v", source, err)
+		}
+	}
+
+	contentHash, err := storageBlobContentHash(source, sourceContent)
+	if err != nil {
+		return nil, err
+	}
+	return &contentHash, nil
+}
+
+func storageBlobContentHash(source, sourceContent string) (string, error) {
+	hash := md5.New()
+	switch {
+	case sourceContent != "":
+		hash.Write([]byte(sourceContent))
+	case source != "":
+		file, err := os.Open(source)
+		if err != nil {
+			return "", fmt.Errorf("opening %q: %+v", source, err)
+		}
+		defer file.Close()
+
+		if _, err := io.Copy(hash, file); err != nil {
+			return "", fmt.Errorf("hashing %q: %+v", source, err)
+		}
+	default:
+		return "", nil
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// expandStorageBlobContentMD5 returns the Base64 encoded MD5 which should be sent for the blob, falling back to the
+// hash of the content when `content_md5` isn't specified
+func expandStorageBlobContentMD5(contentMD5, contentHash string) (string, error) {
+	if contentMD5 == "" {
+		contentMD5 = contentHash
+	}
+	if contentMD5 == "" {
+		return "", nil
+	}
+
+	// Azure uses a Base64 encoded representation of the standard MD5 sum of the file
+	output, err := convertHexToBase64Encoding(contentMD5)
+	if err != nil {
+		return "", fmt.Errorf("failed to base64 encode `content_md5` value: %s", err)
+	}
+
+	return output, nil
+}
@@ -145,7 +145,30 @@ func TestAccStorageBlob_blockFromInlineContent(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "source_content", "type"),
+		data.ImportStep("content_hash", "parallelism", "size", "source_content", "type"),
+	})
+}
+
+func TestAccStorageBlob_blockFromInlineContentUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.blockFromInlineContent(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("content_hash", "parallelism", "size", "source_content", "type"),
+		{
+			Config: r.blockFromInlineContentUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("content_md5").HasValue("96a700ef7501dbfa1dfbd2df1e9d2955"),
+			),
+		},
+		data.ImportStep("content_hash", "parallelism", "size", "source_content", "type"),
 	})
 }
 
@@ -214,7 +237,49 @@ func TestAccStorageBlob_blockFromLocalFile(t *testing.T) {
 				data.CheckWithClient(r.blobMatchesFile(blobs.BlockBlob, sourceBlob.Name())),
 			),
 		},
-		data.ImportStep("parallelism", "size", "source", "type"),
+		data.ImportStep("content_hash", "parallelism", "size", "source", "type"),
+	})
+}
+
+func TestAccStorageBlob_blockFromLocalFileUpdated(t *testing.T) {
+	sourceBlob, err := os.CreateTemp("", "")
+	if err != nil {
+		t.Fatalf("Failed to create local source blob file")
+	}
+
+	if err := populateTempFile(sourceBlob); err != nil {
+		t.Fatalf("Error populating temp file: %s", err)
+	}
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.blockFromLocalBlob(data, sourceBlob.Name()),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.blobMatchesFile(blobs.BlockBlob, sourceBlob.Name())),
+			),
+		},
+		data.ImportStep("content_hash", "parallelism", "size", "source", "type"),
+		{
+			PreConfig: func() {
+				// the contents of the file change, but its path doesn't - which should update the Blob in-place
+				file, err := os.OpenFile(sourceBlob.Name(), os.O_RDWR, 0o600)
+				if err != nil {
+					t.Fatalf("Failed to open local source blob file")
+				}
+				if err := populateTempFile(file); err != nil {
+					t.Fatalf("Error populating temp file: %s", err)
+				}
+			},
+			Config: r.blockFromLocalBlob(data, sourceBlob.Name()),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.blobMatchesFile(blobs.BlockBlob, sourceBlob.Name())),
+			),
+		},
+		data.ImportStep("content_hash", "parallelism", "size", "source", "type"),
 	})
 }
 
@@ -239,7 +304,7 @@ func TestAccStorageBlob_blockFromLocalFileWithContentMd5(t *testing.T) {
 				acceptance.TestCheckResourceAttr(data.ResourceName, "source", sourceBlob.Name()),
 			),
 		},
-		data.ImportStep("parallelism", "size", "source", "type"),
+		data.ImportStep("content_hash", "parallelism", "size", "source", "type"),
 	})
 }
 
@@ -655,6 +720,25 @@ resource "azurerm_storage_blob" "test" {
 `, template)
 }
 
+func (r StorageBlobResource) blockFromInlineContentUpdated(data acceptance.TestData) string {
+	template := r.template(data, "blob")
+	return fmt.Sprintf(`
+%s
+
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "rick.morty"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "Get Schwifty"
+}
+`, template)
+}
+
 func (r StorageBlobResource) blockFromPublicBlob(data acceptance.TestData) string {
 	template := r.template(data, "blob")
 	return fmt.Sprintf(`
@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/containers"
+)
+
+// storageBlobsPageSize is the maximum number of blobs the API returns within a single page
+const storageBlobsPageSize = 5000
+
+func dataSourceStorageBlobs() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageBlobsRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"storage_container_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageContainerName,
+			},
+
+			"prefix": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"include_metadata": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"max_results": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      5000,
+				ValidateFunc: validation.IntBetween(1, 50000),
+			},
+
+			"blobs": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"type": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"access_tier": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"content_md5": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"content_type": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"last_modified": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"size": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"url": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"metadata": MetaDataComputedSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStorageBlobsRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+	prefix := d.Get("prefix").(string)
+	maxResults := d.Get("max_results").(int)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Container %q: %s", accountName, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Account %q for Storage Container %q", accountName, containerName)
+	}
+
+	containersClient, err := storageClient.ContainersClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Containers Client for Storage Account %q (Resource Group %q): %s", accountName, account.ResourceGroup, err)
+	}
+
+	blobsClient, err := storageClient.BlobsClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Blobs Client for Storage Account %q (Resource Group %q): %s", accountName, account.ResourceGroup, err)
+	}
+
+	id := parse.NewStorageContainerDataPlaneId(accountName, storageClient.Environment.StorageEndpointSuffix, containerName)
+
+	items := make([]containers.BlobDetails, 0)
+	input := containers.ListBlobsInput{
+		MaxResults: utils.Int(storageBlobsPageSize),
+	}
+	if prefix != "" {
+		input.Prefix = utils.String(prefix)
+	}
+	for {
+		result, err := containersClient.ListBlobs(ctx, account.ResourceGroup, accountName, containerName, input)
+		if err != nil {
+			return fmt.Errorf("listing Blobs within %s: %+v", id, err)
+		}
+
+		items = append(items, result.Blobs.Blobs...)
+		if len(items) > maxResults {
+			return fmt.Errorf("more than %d Blobs were found within %s - either increase `max_results` or specify a more specific `prefix`", maxResults, id)
+		}
+
+		if result.NextMarker == nil || *result.NextMarker == "" {
+			break
+		}
+		input.Marker = result.NextMarker
+	}
+
+	output := make([]interface{}, 0)
+	for _, item := range items {
+		blob := map[string]interface{}{
+			"name":     item.Name,
+			"url":      blobsClient.GetResourceID(accountName, containerName, item.Name),
+			"metadata": map[string]interface{}{},
+		}
+
+		if props := item.Properties; props != nil {
+			blob["type"] = strings.TrimSuffix(utils.NormalizeNilableString(props.BlobType), "Blob")
+			blob["access_tier"] = utils.NormalizeNilableString(props.AccessTier)
+			blob["content_type"] = utils.NormalizeNilableString(props.ContentType)
+			blob["last_modified"] = utils.NormalizeNilableString(props.LastModified)
+
+			contentMD5 := ""
+			if v := utils.NormalizeNilableString(props.ContentMD5); v != "" {
+				if contentMD5, err = convertBase64ToHexEncoding(v); err != nil {
+					return fmt.Errorf("converting the Content MD5 for Blob %q: %+v", item.Name, err)
+				}
+			}
+			blob["content_md5"] = contentMD5
+
+			size := 0
+			if props.ContentLength != nil {
+				size = int(*props.ContentLength)
+			}
+			blob["size"] = size
+		}
+
+		// the MetaData isn't returned in a parseable format when listing Blobs, so needs to be retrieved for each Blob
+		if d.Get("include_metadata").(bool) {
+			props, err := blobsClient.GetProperties(ctx, accountName, containerName, item.Name, blobs.GetPropertiesInput{})
+			if err != nil {
+				return fmt.Errorf("retrieving Properties for Blob %q within %s: %+v", item.Name, id, err)
+			}
+			blob["metadata"] = FlattenMetaData(props.MetaData)
+		}
+
+		output = append(output, blob)
+	}
+
+	d.SetId(storageBlobsDataSourceID(id, prefix))
+	d.Set("storage_account_name", accountName)
+	d.Set("storage_container_name", containerName)
+	d.Set("prefix", prefix)
+
+	if err := d.Set("blobs", output); err != nil {
+		return fmt.Errorf("setting `blobs`: %+v", err)
+	}
+
+	return nil
+}
+
+// storageBlobsDataSourceID returns the ID of the Storage Container, including the prefix (when specified) so that
+// listing different prefixes within the same Container results in different IDs
+func storageBlobsDataSourceID(id parse.StorageContainerDataPlaneId, prefix string) string {
+	if prefix == "" {
+		return id.ID()
+	}
+
+	return fmt.Sprintf("%s?prefix=%s", id.ID(), url.QueryEscape(prefix))
+}
@@ -0,0 +1,136 @@
+package storage_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type StorageBlobsDataSource struct{}
+
+func TestAccDataSourceStorageBlobs_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_blobs", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageBlobsDataSource{}.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").MatchesRegex(regexp.MustCompile(`/[a-z0-9-]+\?prefix=logs%2F$`)),
+				check.That(data.ResourceName).Key("blobs.#").HasValue("2"),
+				check.That(data.ResourceName).Key("blobs.0.name").HasValue("logs/one.txt"),
+				check.That(data.ResourceName).Key("blobs.0.type").HasValue("Block"),
+				check.That(data.ResourceName).Key("blobs.0.size").HasValue("5"),
+				check.That(data.ResourceName).Key("blobs.0.metadata.%").HasValue("1"),
+				check.That(data.ResourceName).Key("blobs.0.metadata.k1").HasValue("v1"),
+				check.That(data.ResourceName).Key("blobs.1.name").HasValue("logs/two.txt"),
+				check.That(data.ResourceName).Key("blobs.1.last_modified").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceStorageBlobs_maxResultsExceeded(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_blobs", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config:      StorageBlobsDataSource{}.maxResultsExceeded(data),
+			ExpectError: regexp.MustCompile("more than 1 Blobs were found"),
+		},
+	})
+}
+
+func (d StorageBlobsDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "test"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "one" {
+  name                   = "logs/one.txt"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "hello"
+
+  metadata = {
+    k1 = "v1"
+  }
+}
+
+resource "azurerm_storage_blob" "two" {
+  name                   = "logs/two.txt"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "world"
+}
+
+resource "azurerm_storage_blob" "other" {
+  name                   = "other.txt"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "other"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (d StorageBlobsDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_blobs" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  prefix                 = "logs/"
+  include_metadata       = true
+
+  depends_on = [
+    azurerm_storage_blob.one,
+    azurerm_storage_blob.two,
+    azurerm_storage_blob.other,
+  ]
+}
+`, d.template(data))
+}
+
+func (d StorageBlobsDataSource) maxResultsExceeded(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_blobs" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  max_results            = 1
+
+  depends_on = [
+    azurerm_storage_blob.one,
+    azurerm_storage_blob.two,
+    azurerm_storage_blob.other,
+  ]
+}
+`, d.template(data))
+}
@@ -68,6 +68,8 @@ func resourceStorageContainer() *pluginsdk.Resource {
 
 			"metadata": MetaDataComputedSchema(),
 
+			"data_plane_auth_mode": DataPlaneAuthModeSchema(),
+
 			// TODO: support for ACL's, Legal Holds and Immutability Policies
 			"has_immutability_policy": {
 				Type:     pluginsdk.TypeBool,
@@ -107,6 +109,7 @@ func resourceStorageContainerCreate(d *pluginsdk.ResourceData, meta interface{})
 	if account == nil {
 		return fmt.Errorf("Unable to locate Storage Account %q!", accountName)
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 
 	client, err := storageClient.ContainersClient(ctx, *account)
 	if err != nil {
@@ -153,6 +156,7 @@ func resourceStorageContainerUpdate(d *pluginsdk.ResourceData, meta interface{})
 	if account == nil {
 		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 	client, err := storageClient.ContainersClient(ctx, *account)
 	if err != nil {
 		return fmt.Errorf("building Containers Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
@@ -205,6 +209,7 @@ func resourceStorageContainerRead(d *pluginsdk.ResourceData, meta interface{}) e
 		d.SetId("")
 		return nil
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 	client, err := storageClient.ContainersClient(ctx, *account)
 	if err != nil {
 		return fmt.Errorf("building Containers Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
@@ -255,6 +260,7 @@ func resourceStorageContainerDelete(d *pluginsdk.ResourceData, meta interface{})
 	if account == nil {
 		return fmt.Errorf("Unable to locate Storage Account %q!", id.AccountName)
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 	client, err := storageClient.ContainersClient(ctx, *account)
 	if err != nil {
 		return fmt.Errorf("building Containers Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-05-01/blobcontainers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceStorageContainers() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageContainersRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"name_prefix": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"max_results": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      5000,
+				ValidateFunc: validation.IntBetween(1, 50000),
+			},
+
+			"containers": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"container_access_type": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"has_immutability_policy": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"has_legal_hold": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"last_modified": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"metadata": MetaDataComputedSchema(),
+
+						"data_plane_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"resource_manager_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStorageContainersRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	client := storageClient.ResourceManager.BlobContainers
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	maxResults := d.Get("max_results").(int)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q: %s", accountName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q", accountName)
+	}
+
+	id := blobcontainers.NewStorageAccountID(storageClient.SubscriptionId, account.ResourceGroup, accountName)
+	options := blobcontainers.DefaultListOperationOptions()
+	if v := d.Get("name_prefix").(string); v != "" {
+		// the API treats the filter as a prefix which the names of the containers must start with
+		options.Filter = utils.String(v)
+	}
+
+	items := make([]blobcontainers.ListContainerItem, 0)
+	resp, err := client.List(ctx, id, options)
+	for {
+		if err != nil {
+			return fmt.Errorf("listing Containers within %s: %+v", id, err)
+		}
+
+		if resp.Model != nil {
+			items = append(items, *resp.Model...)
+		}
+		if len(items) > maxResults {
+			return fmt.Errorf("more than %d Containers were found within %s - either increase `max_results` or specify a more specific `name_prefix`", maxResults, id)
+		}
+
+		if !resp.HasMore() {
+			break
+		}
+		resp, err = resp.LoadMore(ctx)
+	}
+
+	d.SetId(id.ID())
+	d.Set("storage_account_name", accountName)
+
+	if err := d.Set("containers", flattenStorageContainersList(items, storageClient.Environment.StorageEndpointSuffix, id)); err != nil {
+		return fmt.Errorf("setting `containers`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenStorageContainersList(input []blobcontainers.ListContainerItem, domainSuffix string, accountId blobcontainers.StorageAccountId) []interface{} {
+	output := make([]interface{}, 0)
+	for _, item := range input {
+		if item.Name == nil {
+			continue
+		}
+		name := *item.Name
+
+		accessType := "private"
+		hasImmutabilityPolicy := false
+		hasLegalHold := false
+		lastModified := ""
+		metadata := make(map[string]interface{})
+		if props := item.Properties; props != nil {
+			if props.PublicAccess != nil {
+				switch *props.PublicAccess {
+				case blobcontainers.PublicAccessBlob:
+					accessType = "blob"
+				case blobcontainers.PublicAccessContainer:
+					accessType = "container"
+				}
+			}
+			if props.HasImmutabilityPolicy != nil {
+				hasImmutabilityPolicy = *props.HasImmutabilityPolicy
+			}
+			if props.HasLegalHold != nil {
+				hasLegalHold = *props.HasLegalHold
+			}
+			if props.LastModifiedTime != nil {
+				lastModified = *props.LastModifiedTime
+			}
+			if props.Metadata != nil {
+				for k, v := range *props.Metadata {
+					metadata[k] = v
+				}
+			}
+		}
+
+		output = append(output, map[string]interface{}{
+			"name":                    name,
+			"container_access_type":   accessType,
+			"has_immutability_policy": hasImmutabilityPolicy,
+			"has_legal_hold":          hasLegalHold,
+			"last_modified":           lastModified,
+			"metadata":                metadata,
+			"data_plane_id":           parse.NewStorageContainerDataPlaneId(accountId.StorageAccountName, domainSuffix, name).ID(),
+			"resource_manager_id":     parse.NewStorageContainerResourceManagerID(accountId.SubscriptionId, accountId.ResourceGroupName, accountId.StorageAccountName, "default", name).ID(),
+		})
+	}
+
+	return output
+}
@@ -0,0 +1,83 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type StorageContainersDataSource struct{}
+
+func TestAccDataSourceStorageContainers_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_containers", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageContainersDataSource{}.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("containers.#").HasValue("2"),
+				check.That(data.ResourceName).Key("containers.0.name").HasValue("app-one"),
+				check.That(data.ResourceName).Key("containers.0.container_access_type").HasValue("private"),
+				check.That(data.ResourceName).Key("containers.0.metadata.%").HasValue("1"),
+				check.That(data.ResourceName).Key("containers.1.name").HasValue("app-two"),
+				check.That(data.ResourceName).Key("containers.1.container_access_type").HasValue("blob"),
+			),
+		},
+	})
+}
+
+func (d StorageContainersDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "one" {
+  name                  = "app-one"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+
+  metadata = {
+    k1 = "v1"
+  }
+}
+
+resource "azurerm_storage_container" "two" {
+  name                  = "app-two"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "blob"
+}
+
+resource "azurerm_storage_container" "other" {
+  name                  = "other"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+
+data "azurerm_storage_containers" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  name_prefix          = "app-"
+
+  depends_on = [
+    azurerm_storage_container.one,
+    azurerm_storage_container.two,
+    azurerm_storage_container.other,
+  ]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
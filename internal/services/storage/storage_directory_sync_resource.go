@@ -0,0 +1,447 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func resourceStorageDirectorySync() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceStorageDirectorySyncCreate,
+		Read:   resourceStorageDirectorySyncRead,
+		Update: resourceStorageDirectorySyncUpdate,
+		Delete: resourceStorageDirectorySyncDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parseStorageDirectorySyncID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"storage_container_name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageContainerName,
+				ExactlyOneOf: []string{"storage_container_name", "storage_share_name"},
+			},
+
+			"storage_share_name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageShareName,
+				ExactlyOneOf: []string{"storage_container_name", "storage_share_name"},
+			},
+
+			"destination_path": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"source_directory": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"include": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"exclude": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"content_types": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"parallelism": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntBetween(1, 64),
+			},
+
+			"delete_removed_files": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"files": {
+				Type:     pluginsdk.TypeMap,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceStorageDirectorySyncCustomizeDiff),
+	}
+}
+
+// resourceStorageDirectorySyncCustomizeDiff hashes the files within the Source Directory at plan time, so that
+// any changes to the local files surface as a diff on the `files` attribute
+func resourceStorageDirectorySyncCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"source_directory", "include", "exclude"} {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("files")
+		}
+	}
+
+	localFiles, err := buildDirectorySyncFiles(d.Get("source_directory").(string), expandStorageDirectorySyncPatterns(d.Get("include").([]interface{})), expandStorageDirectorySyncPatterns(d.Get("exclude").([]interface{})), nil)
+	if err != nil {
+		return err
+	}
+
+	hashes := flattenStorageDirectorySyncFiles(localFiles)
+	existing := d.Get("files").(map[string]interface{})
+	if d.Id() == "" || !reflect.DeepEqual(existing, hashes) {
+		return d.SetNew("files", hashes)
+	}
+
+	return nil
+}
+
+func resourceStorageDirectorySyncCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	storageClient := meta.(*clients.Client).Storage
+
+	id := storageDirectorySyncId{
+		AccountName:     d.Get("storage_account_name").(string),
+		DomainSuffix:    storageClient.Environment.StorageEndpointSuffix,
+		ContainerName:   d.Get("storage_container_name").(string),
+		ShareName:       d.Get("storage_share_name").(string),
+		DestinationPath: strings.Trim(d.Get("destination_path").(string), "/"),
+	}.ID()
+
+	if err := syncStorageDirectory(ctx, d, meta, map[string]interface{}{}, true); err != nil {
+		return fmt.Errorf("synchronising %q to %q: %+v", d.Get("source_directory").(string), id, err)
+	}
+
+	d.SetId(id)
+
+	return resourceStorageDirectorySyncRead(d, meta)
+}
+
+func resourceStorageDirectorySyncRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parseStorageDirectorySyncID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.Set("storage_account_name", id.AccountName)
+	d.Set("storage_container_name", id.ContainerName)
+	d.Set("storage_share_name", id.ShareName)
+	d.Set("destination_path", id.DestinationPath)
+
+	target, err := buildStorageDirectorySyncTarget(ctx, d, meta)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		log.Printf("[DEBUG] Unable to locate Storage Account %q for %q - assuming removed & removing from state", d.Get("storage_account_name").(string), d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// check each of the files we're tracking still exists with the same contents - any which have been
+	// removed or modified outside of Terraform are removed from/updated in the state so they're re-uploaded
+	tracked := d.Get("files").(map[string]interface{})
+	paths := make([]string, 0, len(tracked))
+	for k := range tracked {
+		paths = append(paths, k)
+	}
+
+	remote := make(map[string]string)
+	results := make(chan [2]string, len(paths))
+	err = runDirectorySyncOperations(paths, d.Get("parallelism").(int), func(relativePath string) error {
+		contentMD5, err := target.contentMD5(ctx, relativePath)
+		if err != nil {
+			return fmt.Errorf("retrieving properties: %+v", err)
+		}
+		if contentMD5 != nil {
+			results <- [2]string{relativePath, *contentMD5}
+		}
+		return nil
+	})
+	close(results)
+	if err != nil {
+		return fmt.Errorf("retrieving the files synchronised to %q: %+v", d.Id(), err)
+	}
+	for v := range results {
+		remote[v[0]] = v[1]
+	}
+
+	if err := d.Set("files", remote); err != nil {
+		return fmt.Errorf("setting `files`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceStorageDirectorySyncUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	existing, _ := d.GetChange("files")
+	if err := syncStorageDirectory(ctx, d, meta, existing.(map[string]interface{}), d.HasChange("content_types")); err != nil {
+		return fmt.Errorf("synchronising %q to %q: %+v", d.Get("source_directory").(string), d.Id(), err)
+	}
+
+	return resourceStorageDirectorySyncRead(d, meta)
+}
+
+func resourceStorageDirectorySyncDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	target, err := buildStorageDirectorySyncTarget(ctx, d, meta)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return nil
+	}
+
+	paths := make([]string, 0)
+	for k := range d.Get("files").(map[string]interface{}) {
+		paths = append(paths, k)
+	}
+
+	if err := runDirectorySyncOperations(paths, d.Get("parallelism").(int), func(relativePath string) error {
+		return target.delete(ctx, relativePath)
+	}); err != nil {
+		return fmt.Errorf("deleting the files synchronised to %q: %+v", d.Id(), err)
+	}
+
+	return nil
+}
+
+// syncStorageDirectory uploads the local files which differ from those previously synchronised (or all files
+// when `uploadAll` is set) and, when enabled, deletes the remote files which no longer exist locally
+func syncStorageDirectory(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, existing map[string]interface{}, uploadAll bool) error {
+	target, err := buildStorageDirectorySyncTarget(ctx, d, meta)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return fmt.Errorf("unable to locate Storage Account %q", d.Get("storage_account_name").(string))
+	}
+
+	contentTypes := make(map[string]string)
+	for k, v := range d.Get("content_types").(map[string]interface{}) {
+		contentTypes[strings.ToLower(k)] = v.(string)
+	}
+
+	localFiles, err := buildDirectorySyncFiles(d.Get("source_directory").(string), expandStorageDirectorySyncPatterns(d.Get("include").([]interface{})), expandStorageDirectorySyncPatterns(d.Get("exclude").([]interface{})), contentTypes)
+	if err != nil {
+		return err
+	}
+
+	toUpload := make([]string, 0)
+	for relativePath, file := range localFiles {
+		if v, ok := existing[relativePath]; uploadAll || !ok || v.(string) != file.ContentMD5 {
+			toUpload = append(toUpload, relativePath)
+		}
+	}
+
+	parallelism := d.Get("parallelism").(int)
+	log.Printf("[DEBUG] Uploading %d of %d files to %q..", len(toUpload), len(localFiles), d.Id())
+	if err := runDirectorySyncOperations(toUpload, parallelism, func(relativePath string) error {
+		return target.upload(ctx, localFiles[relativePath])
+	}); err != nil {
+		return fmt.Errorf("uploading files: %+v", err)
+	}
+
+	if d.Get("delete_removed_files").(bool) {
+		toDelete := make([]string, 0)
+		for relativePath := range existing {
+			if _, ok := localFiles[relativePath]; !ok {
+				toDelete = append(toDelete, relativePath)
+			}
+		}
+
+		log.Printf("[DEBUG] Deleting %d files which no longer exist locally from %q..", len(toDelete), d.Id())
+		if err := runDirectorySyncOperations(toDelete, parallelism, func(relativePath string) error {
+			return target.delete(ctx, relativePath)
+		}); err != nil {
+			return fmt.Errorf("deleting files: %+v", err)
+		}
+	}
+
+	if err := d.Set("files", flattenStorageDirectorySyncFiles(localFiles)); err != nil {
+		return fmt.Errorf("setting `files`: %+v", err)
+	}
+
+	return nil
+}
+
+// buildStorageDirectorySyncTarget returns the target for this resource, or nil if the Storage Account doesn't exist
+func buildStorageDirectorySyncTarget(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) (directorySyncTarget, error) {
+	storageClient := meta.(*clients.Client).Storage
+
+	accountName := d.Get("storage_account_name").(string)
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Storage Account %q: %+v", accountName, err)
+	}
+	if account == nil {
+		return nil, nil
+	}
+
+	destinationPath := strings.Trim(d.Get("destination_path").(string), "/")
+
+	if containerName := d.Get("storage_container_name").(string); containerName != "" {
+		blobsClient, err := storageClient.BlobsClient(ctx, *account)
+		if err != nil {
+			return nil, fmt.Errorf("building Blobs Client: %+v", err)
+		}
+
+		return blobDirectorySyncTarget{
+			client:        blobsClient,
+			accountName:   accountName,
+			containerName: containerName,
+			prefix:        destinationPath,
+		}, nil
+	}
+
+	filesClient, err := storageClient.FileShareFilesClient(ctx, *account)
+	if err != nil {
+		return nil, fmt.Errorf("building File Share Files Client: %+v", err)
+	}
+	directoriesClient, err := storageClient.FileShareDirectoriesClient(ctx, *account)
+	if err != nil {
+		return nil, fmt.Errorf("building File Share Directories Client: %+v", err)
+	}
+
+	return newFileShareDirectorySyncTarget(filesClient, directoriesClient, accountName, d.Get("storage_share_name").(string), destinationPath), nil
+}
+
+func expandStorageDirectorySyncPatterns(input []interface{}) []string {
+	output := make([]string, 0)
+	for _, v := range input {
+		if v == nil {
+			continue
+		}
+		output = append(output, v.(string))
+	}
+	return output
+}
+
+func flattenStorageDirectorySyncFiles(input map[string]directorySyncFile) map[string]interface{} {
+	output := make(map[string]interface{})
+	for k, v := range input {
+		output[k] = v.ContentMD5
+	}
+	return output
+}
+
+type storageDirectorySyncId struct {
+	AccountName     string
+	DomainSuffix    string
+	ContainerName   string
+	ShareName       string
+	DestinationPath string
+}
+
+func (id storageDirectorySyncId) ID() string {
+	var output string
+	if id.ContainerName != "" {
+		output = parse.NewStorageContainerDataPlaneId(id.AccountName, id.DomainSuffix, id.ContainerName).ID()
+	} else {
+		output = parse.NewStorageShareDataPlaneId(id.AccountName, id.DomainSuffix, id.ShareName).ID()
+	}
+
+	if id.DestinationPath != "" {
+		output = fmt.Sprintf("%s/%s", output, id.DestinationPath)
+	}
+	return output
+}
+
+// parseStorageDirectorySyncID parses the URL of the Storage Container or File Share (and optional destination path),
+// e.g. `https://account1.blob.core.windows.net/container1/some/path`
+func parseStorageDirectorySyncID(input string) (*storageDirectorySyncId, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a URL: %+v", input, err)
+	}
+
+	hostSegments := strings.SplitN(uri.Host, ".", 3)
+	if len(hostSegments) != 3 {
+		return nil, fmt.Errorf("expected the host %q to be in the format `{account}.{blob|file}.{domainSuffix}`", uri.Host)
+	}
+
+	pathSegments := strings.SplitN(strings.TrimPrefix(uri.Path, "/"), "/", 2)
+	if pathSegments[0] == "" {
+		return nil, fmt.Errorf("expected the path %q to contain the name of a Storage Container or File Share", uri.Path)
+	}
+
+	id := storageDirectorySyncId{
+		AccountName:  hostSegments[0],
+		DomainSuffix: hostSegments[2],
+	}
+	switch hostSegments[1] {
+	case "blob":
+		id.ContainerName = pathSegments[0]
+	case "file":
+		id.ShareName = pathSegments[0]
+	default:
+		return nil, fmt.Errorf("expected %q to be the URL of a Blob or File endpoint", input)
+	}
+
+	if len(pathSegments) == 2 {
+		id.DestinationPath = strings.Trim(pathSegments[1], "/")
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,220 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/blobs"
+)
+
+type StorageDirectorySyncResource struct{}
+
+func TestAccStorageDirectorySync_blob(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_directory_sync", "test")
+	r := StorageDirectorySyncResource{}
+	sourceDirectory := r.populateSourceDirectory(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.blob(data, sourceDirectory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("2"),
+			),
+		},
+		data.ImportStep("content_types", "delete_removed_files", "exclude", "files", "include", "parallelism", "source_directory"),
+		{
+			PreConfig: func() {
+				if err := os.WriteFile(filepath.Join(sourceDirectory, "index.html"), []byte("<html>updated</html>"), 0o600); err != nil {
+					t.Fatalf("updating index.html: %+v", err)
+				}
+				if err := os.WriteFile(filepath.Join(sourceDirectory, "js", "app.js"), []byte("console.log('hello');"), 0o600); err != nil {
+					t.Fatalf("writing app.js: %+v", err)
+				}
+				if err := os.Remove(filepath.Join(sourceDirectory, "css", "site.css")); err != nil {
+					t.Fatalf("removing site.css: %+v", err)
+				}
+			},
+			Config: r.blob(data, sourceDirectory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("2"),
+			),
+		},
+		data.ImportStep("content_types", "delete_removed_files", "exclude", "files", "include", "parallelism", "source_directory"),
+	})
+}
+
+func TestAccStorageDirectorySync_fileShare(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_directory_sync", "test")
+	r := StorageDirectorySyncResource{}
+	sourceDirectory := r.populateSourceDirectory(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fileShare(data, sourceDirectory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("2"),
+			),
+		},
+		data.ImportStep("content_types", "delete_removed_files", "exclude", "files", "include", "parallelism", "source_directory"),
+	})
+}
+
+func (StorageDirectorySyncResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	accountName := state.Attributes["storage_account_name"]
+	account, err := clients.Storage.FindAccount(ctx, accountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q: %+v", accountName, err)
+	}
+	if account == nil {
+		return utils.Bool(false), nil
+	}
+
+	destinationPath := state.Attributes["destination_path"]
+	for k := range state.Attributes {
+		if !strings.HasPrefix(k, "files.") || k == "files.%" {
+			continue
+		}
+		relativePath := strings.TrimPrefix(k, "files.")
+
+		if containerName := state.Attributes["storage_container_name"]; containerName != "" {
+			client, err := clients.Storage.BlobsClient(ctx, *account)
+			if err != nil {
+				return nil, fmt.Errorf("building Blobs Client: %+v", err)
+			}
+
+			blobName := strings.TrimPrefix(fmt.Sprintf("%s/%s", destinationPath, relativePath), "/")
+			resp, err := client.GetProperties(ctx, accountName, containerName, blobName, blobs.GetPropertiesInput{})
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return utils.Bool(false), nil
+				}
+				return nil, fmt.Errorf("retrieving Blob %q (Container %q / Account %q): %+v", blobName, containerName, accountName, err)
+			}
+			continue
+		}
+
+		client, err := clients.Storage.FileShareFilesClient(ctx, *account)
+		if err != nil {
+			return nil, fmt.Errorf("building File Share Files Client: %+v", err)
+		}
+
+		shareName := state.Attributes["storage_share_name"]
+		directory := path.Join(destinationPath, path.Dir(relativePath))
+		if directory == "." {
+			directory = ""
+		}
+		fileName := path.Base(relativePath)
+		resp, err := client.GetProperties(ctx, accountName, shareName, directory, fileName)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return utils.Bool(false), nil
+			}
+			return nil, fmt.Errorf("retrieving File %q (Directory %q / Share %q / Account %q): %+v", fileName, directory, shareName, accountName, err)
+		}
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (StorageDirectorySyncResource) populateSourceDirectory(t *testing.T) string {
+	sourceDirectory := t.TempDir()
+
+	files := map[string]string{
+		"index.html":        "<html>hello world</html>",
+		"css/site.css":      "body { margin: 0; }",
+		"tmp/ignored.tmp":   "this file is excluded",
+		"js/placeholder.md": "this file is not included",
+	}
+	for name, content := range files {
+		localPath := filepath.Join(sourceDirectory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(localPath), 0o700); err != nil {
+			t.Fatalf("creating directory for %q: %+v", name, err)
+		}
+		if err := os.WriteFile(localPath, []byte(content), 0o600); err != nil {
+			t.Fatalf("writing %q: %+v", name, err)
+		}
+	}
+
+	return sourceDirectory
+}
+
+func (StorageDirectorySyncResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (r StorageDirectorySyncResource) blob(data acceptance.TestData, sourceDirectory string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_container" "test" {
+  name                  = "site"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_directory_sync" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  destination_path       = "static"
+  source_directory       = %q
+  include                = ["**/*.html", "**/*.css", "**/*.js"]
+  exclude                = ["tmp/**"]
+  parallelism            = 4
+  delete_removed_files   = true
+
+  content_types = {
+    ".js" = "text/javascript"
+  }
+}
+`, r.template(data), sourceDirectory)
+}
+
+func (r StorageDirectorySyncResource) fileShare(data acceptance.TestData, sourceDirectory string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share" "test" {
+  name                 = "site"
+  storage_account_name = azurerm_storage_account.test.name
+  quota                = 50
+}
+
+resource "azurerm_storage_directory_sync" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  storage_share_name   = azurerm_storage_share.test.name
+  destination_path     = "static/site"
+  source_directory     = %q
+  include              = ["**/*.html", "**/*.css"]
+}
+`, r.template(data), sourceDirectory)
+}
@@ -55,6 +55,8 @@ func resourceStorageQueue() *pluginsdk.Resource {
 
 			"metadata": MetaDataSchema(),
 
+			"data_plane_auth_mode": DataPlaneAuthModeSchema(),
+
 			"resource_manager_id": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -81,6 +83,7 @@ func resourceStorageQueueCreate(d *pluginsdk.ResourceData, meta interface{}) err
 	if account == nil {
 		return fmt.Errorf("unable to locate Storage Account %q", accountName)
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 
 	client, err := storageClient.QueuesClient(ctx, *account)
 	if err != nil {
@@ -125,6 +128,7 @@ func resourceStorageQueueUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 	if account == nil {
 		return fmt.Errorf("unable to locate Storage Account %q!", id.AccountName)
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 
 	client, err := storageClient.QueuesClient(ctx, *account)
 	if err != nil {
@@ -158,6 +162,7 @@ func resourceStorageQueueRead(d *pluginsdk.ResourceData, meta interface{}) error
 		d.SetId("")
 		return nil
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 
 	client, err := storageClient.QueuesClient(ctx, *account)
 	if err != nil {
@@ -206,6 +211,7 @@ func resourceStorageQueueDelete(d *pluginsdk.ResourceData, meta interface{}) err
 		d.SetId("")
 		return nil
 	}
+	account.SetDataPlaneAuthMode(d.Get("data_plane_auth_mode").(string))
 
 	client, err := storageClient.QueuesClient(ctx, *account)
 	if err != nil {
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-05-01/queueservice"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceStorageQueues() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageQueuesRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"name_prefix": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"max_results": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      5000,
+				ValidateFunc: validation.IntBetween(1, 50000),
+			},
+
+			"queues": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"metadata": MetaDataComputedSchema(),
+
+						"data_plane_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"resource_manager_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStorageQueuesRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	client := storageClient.ResourceManager.QueueService
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	maxResults := d.Get("max_results").(int)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q: %s", accountName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q", accountName)
+	}
+
+	id := queueservice.NewStorageAccountID(storageClient.SubscriptionId, account.ResourceGroup, accountName)
+	options := queueservice.DefaultQueueListOperationOptions()
+	if v := d.Get("name_prefix").(string); v != "" {
+		// the API treats the filter as a prefix which the names of the queues must start with
+		options.Filter = utils.String(v)
+	}
+
+	items := make([]queueservice.ListQueue, 0)
+	resp, err := client.QueueList(ctx, id, options)
+	for {
+		if err != nil {
+			return fmt.Errorf("listing Queues within %s: %+v", id, err)
+		}
+
+		if resp.Model != nil {
+			items = append(items, *resp.Model...)
+		}
+		if len(items) > maxResults {
+			return fmt.Errorf("more than %d Queues were found within %s - either increase `max_results` or specify a more specific `name_prefix`", maxResults, id)
+		}
+
+		if !resp.HasMore() {
+			break
+		}
+		resp, err = resp.LoadMore(ctx)
+	}
+
+	d.SetId(id.ID())
+	d.Set("storage_account_name", accountName)
+
+	if err := d.Set("queues", flattenStorageQueuesList(items, storageClient.Environment.StorageEndpointSuffix, id)); err != nil {
+		return fmt.Errorf("setting `queues`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenStorageQueuesList(input []queueservice.ListQueue, domainSuffix string, accountId queueservice.StorageAccountId) []interface{} {
+	output := make([]interface{}, 0)
+	for _, item := range input {
+		if item.Name == nil {
+			continue
+		}
+		name := *item.Name
+
+		metadata := make(map[string]interface{})
+		if props := item.Properties; props != nil && props.Metadata != nil {
+			for k, v := range *props.Metadata {
+				metadata[k] = v
+			}
+		}
+
+		output = append(output, map[string]interface{}{
+			"name":                name,
+			"metadata":            metadata,
+			"data_plane_id":       parse.NewStorageQueueDataPlaneId(accountId.StorageAccountName, domainSuffix, name).ID(),
+			"resource_manager_id": parse.NewStorageQueueResourceManagerID(accountId.SubscriptionId, accountId.ResourceGroupName, accountId.StorageAccountName, "default", name).ID(),
+		})
+	}
+
+	return output
+}
@@ -0,0 +1,79 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type StorageQueuesDataSource struct{}
+
+func TestAccDataSourceStorageQueues_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_queues", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageQueuesDataSource{}.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("queues.#").HasValue("2"),
+				check.That(data.ResourceName).Key("queues.0.name").HasValue("app-one"),
+				check.That(data.ResourceName).Key("queues.0.metadata.%").HasValue("1"),
+				check.That(data.ResourceName).Key("queues.1.name").HasValue("app-two"),
+				check.That(data.ResourceName).Key("queues.1.metadata.%").HasValue("0"),
+			),
+		},
+	})
+}
+
+func (d StorageQueuesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "one" {
+  name                 = "app-one"
+  storage_account_name = azurerm_storage_account.test.name
+
+  metadata = {
+    k1 = "v1"
+  }
+}
+
+resource "azurerm_storage_queue" "two" {
+  name                 = "app-two"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_storage_queue" "other" {
+  name                 = "other"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+data "azurerm_storage_queues" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  name_prefix          = "app-"
+
+  depends_on = [
+    azurerm_storage_queue.one,
+    azurerm_storage_queue.two,
+    azurerm_storage_queue.other,
+  ]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-05-01/fileshares"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func dataSourceStorageShares() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageSharesRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"name_prefix": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"max_results": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      5000,
+				ValidateFunc: validation.IntBetween(1, 50000),
+			},
+
+			"shares": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"access_tier": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"enabled_protocol": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"last_modified": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"quota": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"metadata": MetaDataComputedSchema(),
+
+						"data_plane_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"resource_manager_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStorageSharesRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	client := storageClient.ResourceManager.FileShares
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	maxResults := d.Get("max_results").(int)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q: %s", accountName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Storage Account %q", accountName)
+	}
+
+	id := fileshares.NewStorageAccountID(storageClient.SubscriptionId, account.ResourceGroup, accountName)
+	options := fileshares.DefaultListOperationOptions()
+	if v := d.Get("name_prefix").(string); v != "" {
+		// the API treats the filter as a prefix which the names of the shares must start with
+		options.Filter = utils.String(v)
+	}
+
+	items := make([]fileshares.FileShareItem, 0)
+	resp, err := client.List(ctx, id, options)
+	for {
+		if err != nil {
+			return fmt.Errorf("listing Shares within %s: %+v", id, err)
+		}
+
+		if resp.Model != nil {
+			items = append(items, *resp.Model...)
+		}
+		if len(items) > maxResults {
+			return fmt.Errorf("more than %d Shares were found within %s - either increase `max_results` or specify a more specific `name_prefix`", maxResults, id)
+		}
+
+		if !resp.HasMore() {
+			break
+		}
+		resp, err = resp.LoadMore(ctx)
+	}
+
+	d.SetId(id.ID())
+	d.Set("storage_account_name", accountName)
+
+	if err := d.Set("shares", flattenStorageSharesList(items, storageClient.Environment.StorageEndpointSuffix, id)); err != nil {
+		return fmt.Errorf("setting `shares`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenStorageSharesList(input []fileshares.FileShareItem, domainSuffix string, accountId fileshares.StorageAccountId) []interface{} {
+	output := make([]interface{}, 0)
+	for _, item := range input {
+		if item.Name == nil {
+			continue
+		}
+		name := *item.Name
+
+		accessTier := ""
+		enabledProtocol := ""
+		lastModified := ""
+		quota := 0
+		metadata := make(map[string]interface{})
+		if props := item.Properties; props != nil {
+			if props.AccessTier != nil {
+				accessTier = string(*props.AccessTier)
+			}
+			if props.EnabledProtocols != nil {
+				enabledProtocol = string(*props.EnabledProtocols)
+			}
+			if props.LastModifiedTime != nil {
+				lastModified = *props.LastModifiedTime
+			}
+			if props.ShareQuota != nil {
+				quota = int(*props.ShareQuota)
+			}
+			if props.Metadata != nil {
+				for k, v := range *props.Metadata {
+					metadata[k] = v
+				}
+			}
+		}
+
+		output = append(output, map[string]interface{}{
+			"name":                name,
+			"access_tier":         accessTier,
+			"enabled_protocol":    enabledProtocol,
+			"last_modified":       lastModified,
+			"quota":               quota,
+			"metadata":            metadata,
+			"data_plane_id":       parse.NewStorageShareDataPlaneId(accountId.StorageAccountName, domainSuffix, name).ID(),
+			"resource_manager_id": parse.NewStorageShareResourceManagerID(accountId.SubscriptionId, accountId.ResourceGroupName, accountId.StorageAccountName, "default", name).ID(),
+		})
+	}
+
+	return output
+}
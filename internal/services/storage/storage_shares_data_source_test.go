@@ -0,0 +1,83 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type StorageSharesDataSource struct{}
+
+func TestAccDataSourceStorageShares_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_shares", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageSharesDataSource{}.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("shares.#").HasValue("2"),
+				check.That(data.ResourceName).Key("shares.0.name").HasValue("app-one"),
+				check.That(data.ResourceName).Key("shares.0.quota").HasValue("5"),
+				check.That(data.ResourceName).Key("shares.0.metadata.%").HasValue("1"),
+				check.That(data.ResourceName).Key("shares.1.name").HasValue("app-two"),
+				check.That(data.ResourceName).Key("shares.1.quota").HasValue("10"),
+			),
+		},
+	})
+}
+
+func (d StorageSharesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_share" "one" {
+  name                 = "app-one"
+  storage_account_name = azurerm_storage_account.test.name
+  quota                = 5
+
+  metadata = {
+    k1 = "v1"
+  }
+}
+
+resource "azurerm_storage_share" "two" {
+  name                 = "app-two"
+  storage_account_name = azurerm_storage_account.test.name
+  quota                = 10
+}
+
+resource "azurerm_storage_share" "other" {
+  name                 = "other"
+  storage_account_name = azurerm_storage_account.test.name
+  quota                = 5
+}
+
+data "azurerm_storage_shares" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  name_prefix          = "app-"
+
+  depends_on = [
+    azurerm_storage_share.one,
+    azurerm_storage_share.two,
+    azurerm_storage_share.other,
+  ]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -13,9 +13,12 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	subscriptionAlias "github.com/hashicorp/go-azure-sdk/resource-manager/subscription/2021-10-01/subscriptions"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	azValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	billingValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/billing/validate"
+	managementGroupParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/managementgroup/parse"
+	managementGroupValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/managementgroup/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/subscription/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/subscription/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
@@ -23,10 +26,13 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/rickb777/date/period"
 )
 
 var SubscriptionResourceName = "azurerm_subscription"
 
+var subscriptionManagementGroupCacheControl = "no-cache"
+
 func resourceSubscription() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourceSubscriptionCreate,
@@ -106,6 +112,20 @@ func resourceSubscription() *pluginsdk.Resource {
 				ValidateFunc: validation.IsUUID,
 			},
 
+			"management_group_id": {
+				Type:         pluginsdk.TypeString,
+				Description:  "The ID of the Management Group which the Subscription should be placed in.",
+				Optional:     true,
+				ValidateFunc: managementGroupValidate.ManagementGroupID,
+			},
+
+			"cancellation_hold_period": {
+				Type:         pluginsdk.TypeString,
+				Description:  "The duration (in ISO8601 format) which the Subscription should be held for, once the Alias has been removed, before it is cancelled.",
+				Optional:     true,
+				ValidateFunc: azValidate.ISO8601Duration,
+			},
+
 			"tenant_id": {
 				Type:        pluginsdk.TypeString,
 				Description: "The Tenant ID to which the subscription belongs",
@@ -119,8 +139,6 @@ func resourceSubscription() *pluginsdk.Resource {
 
 func resourceSubscriptionCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	aliasClient := meta.(*clients.Client).Subscription.AliasClient
-	subscriptionClient := meta.(*clients.Client).Subscription.SubscriptionClient
-	client := meta.(*clients.Client).Subscription.Client
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -182,26 +200,20 @@ func resourceSubscriptionCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		}
 
 		req.Properties.SubscriptionId = utils.String(subscriptionId)
-		existingSub, err := client.Get(ctx, subscriptionId)
-		if err != nil {
-			return fmt.Errorf("could not read existing Subscription %q", subscriptionId)
-		}
-		// Disabled and Warned are both "effectively" cancelled states,
-		if existingSub.State == subscriptions.StateDisabled || existingSub.State == subscriptions.StateWarned {
-			log.Printf("[DEBUG] Existing subscription in Disabled/Cancelled state Terraform will attempt to re-activate it")
-			if _, err := subscriptionClient.Enable(ctx, subscriptionId); err != nil {
-				return fmt.Errorf("enabling Subscription %q: %+v", subscriptionId, err)
-			}
-			deadline, _ := ctx.Deadline()
-			createDeadline := time.Until(deadline)
-			if err := waitForSubscriptionStateToSettle(ctx, meta.(*clients.Client), subscriptionId, "Active", createDeadline); err != nil {
-				return fmt.Errorf("failed waiting for Subscription %q (Alias %q) to enter %q state: %+v", subscriptionId, id.AliasName, "Active", err)
-			}
+		if err := enableSubscriptionIfCancelled(ctx, meta.(*clients.Client), subscriptionId); err != nil {
+			return fmt.Errorf("re-activating Subscription %q (Alias %q): %+v", subscriptionId, id.AliasName, err)
 		}
 	} else {
 		// If we're not assuming control of an existing Subscription, we need to know where to create it.
 		req.Properties.DisplayName = utils.String(d.Get("subscription_name").(string))
 		req.Properties.BillingScope = utils.String(d.Get("billing_scope_id").(string))
+
+		// new Subscriptions can be placed directly into the Management Group, rather than being moved after creation
+		if v := d.Get("management_group_id").(string); v != "" {
+			req.Properties.AdditionalProperties = &subscriptionAlias.PutAliasRequestAdditionalProperties{
+				ManagementGroupId: utils.String(v),
+			}
+		}
 	}
 
 	future, err := aliasClient.AliasCreate(ctx, id, req)
@@ -225,6 +237,12 @@ func resourceSubscriptionCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		return fmt.Errorf("failed waiting for Subscription %q (Alias %q) to enter %q state: %+v", *alias.Model.Properties.SubscriptionId, id.AliasName, "Active", err)
 	}
 
+	if v := d.Get("management_group_id").(string); v != "" {
+		if err := moveSubscriptionToManagementGroup(ctx, meta.(*clients.Client), *alias.Model.Properties.SubscriptionId, v); err != nil {
+			return fmt.Errorf("moving Subscription %q (Alias %q): %+v", *alias.Model.Properties.SubscriptionId, id.AliasName, err)
+		}
+	}
+
 	if d.HasChange("tags") {
 		tagsClient := meta.(*clients.Client).Resource.TagsClientForSubscription(*alias.Model.Properties.SubscriptionId)
 		t := tags.Expand(d.Get("tags").(map[string]interface{}))
@@ -275,6 +293,16 @@ func resourceSubscriptionUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 		}
 	}
 
+	if d.HasChange("management_group_id") {
+		// removing the Management Group from the configuration leaves the Subscription where it is, since
+		// Subscriptions always belong to a Management Group (by default the Tenant Root Group)
+		if v := d.Get("management_group_id").(string); v != "" {
+			if err := moveSubscriptionToManagementGroup(ctx, meta.(*clients.Client), subscriptionId.SubscriptionId, v); err != nil {
+				return fmt.Errorf("moving Subscription %q (Alias %q): %+v", subscriptionId.SubscriptionId, id.AliasName, err)
+			}
+		}
+	}
+
 	if d.HasChange("tags") {
 		tagsClient := meta.(*clients.Client).Resource.TagsClientForSubscription(subscriptionId.ID())
 		t := tags.Expand(d.Get("tags").(map[string]interface{}))
@@ -337,6 +365,26 @@ func resourceSubscriptionRead(d *pluginsdk.ResourceData, meta interface{}) error
 
 	// (@jackofallops) A subscription's billing scope is not exposed in any way in the API/SDK so we cannot read it back here
 
+	// the Management Group is only checked when it's specified, since retrieving the parent of a Subscription
+	// otherwise requires searching the entire Management Group hierarchy
+	if v := d.Get("management_group_id").(string); v != "" && subscriptionId != "" {
+		managementGroupId, err := managementGroupParse.ManagementGroupID(v)
+		if err != nil {
+			return err
+		}
+
+		managementGroupClient := meta.(*clients.Client).ManagementGroups.SubscriptionClient
+		resp, err := managementGroupClient.GetSubscription(ctx, managementGroupId.Name, subscriptionId, subscriptionManagementGroupCacheControl)
+		if err != nil {
+			if !utils.ResponseWasNotFound(resp.Response) && !utils.ResponseWasForbidden(resp.Response) {
+				return fmt.Errorf("retrieving Subscription %q within %s: %+v", subscriptionId, *managementGroupId, err)
+			}
+
+			log.Printf("[DEBUG] Subscription %q was not found within %s - the Subscription has been moved", subscriptionId, *managementGroupId)
+			d.Set("management_group_id", "")
+		}
+	}
+
 	d.Set("subscription_id", subscriptionId)
 	d.Set("subscription_name", subscriptionName)
 	d.Set("tenant_id", tenantId)
@@ -368,14 +416,37 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 	locks.ByName(id.AliasName, SubscriptionResourceName)
 	defer locks.UnlockByName(id.AliasName, SubscriptionResourceName)
 
+	preventCancellation := meta.(*clients.Client).Features.Subscription.PreventCancellationOnDestroy
+
+	// the hold period is validated before the Alias is removed, since the Subscription can't be cancelled otherwise
+	holdPeriod := time.Duration(0)
+	if v := d.Get("cancellation_hold_period").(string); v != "" && !preventCancellation {
+		holdPeriod, err = parseSubscriptionCancellationHoldPeriod(ctx, v)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Get subscription details for later
+	aliasExists := true
+	subscriptionId := d.Get("subscription_id").(string)
 	alias, err := aliasClient.AliasGet(ctx, *id)
-	if err != nil || alias.Model == nil || alias.Model.Properties == nil {
-		return fmt.Errorf("could not read Alias %q for Subscription: %+v", id.AliasName, err)
-	}
-	subscriptionId := ""
-	if subscriptionIdRaw := alias.Model.Properties.SubscriptionId; subscriptionIdRaw != nil {
-		subscriptionId = *subscriptionIdRaw
+	if err != nil {
+		if !response.WasNotFound(alias.HttpResponse) {
+			return fmt.Errorf("could not read Alias %q for Subscription: %+v", id.AliasName, err)
+		}
+
+		// the Alias is removed before the Subscription is cancelled, so a previous attempt to destroy this resource may
+		// have removed the Alias without cancelling the Subscription
+		log.Printf("[DEBUG] Alias %q was not found - continuing with the cancellation of Subscription %q", id.AliasName, subscriptionId)
+		aliasExists = false
+	} else {
+		if alias.Model == nil || alias.Model.Properties == nil {
+			return fmt.Errorf("could not read Alias %q for Subscription: `properties` was nil", id.AliasName)
+		}
+		if subscriptionIdRaw := alias.Model.Properties.SubscriptionId; subscriptionIdRaw != nil {
+			subscriptionId = *subscriptionIdRaw
+		}
 	}
 	locks.ByID(subscriptionId)
 	defer locks.UnlockByID(subscriptionId)
@@ -393,17 +464,35 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 	if subscriptionName == "" || subscriptionId == "" {
 		return fmt.Errorf("one or both of Subscription Name (%q) and Subscription ID (%q) could not be determined", subscriptionName, subscriptionId)
 	}
-	// remove the alias
-	if _, count, err := checkExistingAliases(ctx, *aliasClient, subscriptionId); err != nil {
-		if count > 1 {
-			return fmt.Errorf("multiple Aliases found for Subscription %q, cannot remove", subscriptionId)
+	if aliasExists {
+		// remove the alias
+		if _, count, err := checkExistingAliases(ctx, *aliasClient, subscriptionId); err != nil {
+			if count > 1 {
+				return fmt.Errorf("multiple Aliases found for Subscription %q, cannot remove", subscriptionId)
+			}
+		}
+
+		resp, err := aliasClient.AliasDelete(ctx, *id)
+		if err != nil {
+			if !response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("could not delete Alias %q for Subscription %q (ID: %q): %+v", id.AliasName, subscriptionName, subscriptionId, err)
+			}
 		}
 	}
 
-	resp, err := aliasClient.AliasDelete(ctx, *id)
-	if err != nil {
-		if !response.WasNotFound(resp.HttpResponse) {
-			return fmt.Errorf("could not delete Alias %q for Subscription %q (ID: %q): %+v", id.AliasName, subscriptionName, subscriptionId, err)
+	if preventCancellation {
+		log.Printf("[DEBUG] Skipping cancellation of Subscription %q (Alias %q) since `prevent_cancellation_on_destroy` is enabled", subscriptionId, id.AliasName)
+		return nil
+	}
+
+	if holdPeriod > 0 {
+		adopted, err := holdSubscriptionBeforeCancellation(ctx, *aliasClient, subscriptionId, holdPeriod)
+		if err != nil {
+			return fmt.Errorf("holding Subscription %q (Alias %q) before cancellation: %+v", subscriptionId, id.AliasName, err)
+		}
+		if adopted {
+			log.Printf("[DEBUG] Skipping cancellation of Subscription %q since it has been assigned a new Alias during the `cancellation_hold_period`", subscriptionId)
+			return nil
 		}
 	}
 
@@ -422,6 +511,90 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 	return nil
 }
 
+// parseSubscriptionCancellationHoldPeriod parses the `cancellation_hold_period`, which must be shorter than the remaining
+// `delete` timeout so that the Subscription can be cancelled once the hold period has elapsed.
+func parseSubscriptionCancellationHoldPeriod(ctx context.Context, holdPeriod string) (time.Duration, error) {
+	p, err := period.Parse(holdPeriod)
+	if err != nil {
+		return 0, fmt.Errorf("parsing `cancellation_hold_period` %q: %+v", holdPeriod, err)
+	}
+	duration := p.DurationApprox()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, fmt.Errorf("internal-error: context had no deadline")
+	}
+	if remaining := time.Until(deadline); duration >= remaining {
+		return 0, fmt.Errorf("the `cancellation_hold_period` (%s) must be shorter than the remaining `delete` timeout (%s)", duration, remaining.Round(time.Second))
+	}
+
+	return duration, nil
+}
+
+// holdSubscriptionBeforeCancellation waits for the specified hold period before a Subscription is cancelled, which gives
+// another configuration the opportunity to adopt the Subscription (by creating a new Alias for it) - in which case the
+// Subscription shouldn't be cancelled and `true` is returned.
+func holdSubscriptionBeforeCancellation(ctx context.Context, client subscriptionAlias.SubscriptionsClient, subscriptionId string, duration time.Duration) (bool, error) {
+	log.Printf("[DEBUG] Holding Subscription %q for %s before cancelling it", subscriptionId, duration)
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-time.After(duration):
+	}
+
+	aliasName, _, err := checkExistingAliases(ctx, client, subscriptionId)
+	if err != nil {
+		return false, err
+	}
+
+	return aliasName != nil, nil
+}
+
+// enableSubscriptionIfCancelled re-activates a Subscription which has previously been cancelled (e.g. by destroying this
+// resource) - Cancelled Subscriptions are retained by Azure for 90 days, during which time they can be re-enabled.
+func enableSubscriptionIfCancelled(ctx context.Context, clients *clients.Client, subscriptionId string) error {
+	existing, err := clients.Subscription.Client.Get(ctx, subscriptionId)
+	if err != nil {
+		return fmt.Errorf("retrieving Subscription %q: %+v", subscriptionId, err)
+	}
+
+	// Disabled and Warned are both "effectively" cancelled states,
+	if existing.State != subscriptions.StateDisabled && existing.State != subscriptions.StateWarned {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Existing subscription in Disabled/Cancelled state Terraform will attempt to re-activate it")
+	if _, err := clients.Subscription.SubscriptionClient.Enable(ctx, subscriptionId); err != nil {
+		return fmt.Errorf("enabling Subscription %q: %+v", subscriptionId, err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+	if err := waitForSubscriptionStateToSettle(ctx, clients, subscriptionId, "Active", time.Until(deadline)); err != nil {
+		return fmt.Errorf("waiting for Subscription %q to enter %q state: %+v", subscriptionId, "Active", err)
+	}
+
+	return nil
+}
+
+func moveSubscriptionToManagementGroup(ctx context.Context, clients *clients.Client, subscriptionId string, managementGroupId string) error {
+	id, err := managementGroupParse.ManagementGroupID(managementGroupId)
+	if err != nil {
+		return err
+	}
+
+	locks.ByID(id.ID())
+	defer locks.UnlockByID(id.ID())
+
+	if _, err := clients.ManagementGroups.SubscriptionClient.Create(ctx, id.Name, subscriptionId, subscriptionManagementGroupCacheControl); err != nil {
+		return fmt.Errorf("assigning Subscription %q to %s: %+v", subscriptionId, *id, err)
+	}
+
+	return nil
+}
+
 func waitForSubscriptionStateToSettle(ctx context.Context, clients *clients.Client, subscriptionId string, targetState string, timeout time.Duration) error {
 	stateConf := &pluginsdk.StateChangeConf{
 		Refresh: func() (result interface{}, state string, err error) {
@@ -94,6 +94,48 @@ func TestAccSubscriptionResource_devTest(t *testing.T) {
 	})
 }
 
+func TestAccSubscriptionResource_managementGroup(t *testing.T) {
+	if os.Getenv("ARM_BILLING_ACCOUNT") == "" {
+		t.Skip("skipping tests - no billing account data provided")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_subscription", "test")
+	r := SubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.managementGroup(data, "test"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r)),
+		},
+		data.ImportStep("billing_scope_id", "management_group_id"),
+		{
+			Config: r.managementGroup(data, "other"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r)),
+		},
+		data.ImportStep("billing_scope_id", "management_group_id"),
+	})
+}
+
+func TestAccSubscriptionResource_cancellationHoldPeriod(t *testing.T) {
+	if os.Getenv("ARM_BILLING_ACCOUNT") == "" {
+		t.Skip("skipping tests - no billing account data provided")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_subscription", "test")
+	r := SubscriptionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.cancellationHoldPeriod(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r)),
+		},
+		data.ImportStep("billing_scope_id", "cancellation_hold_period"),
+	})
+}
+
 func (SubscriptionResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := subscriptions.ParseAliasID(state.ID)
 	if err != nil {
@@ -192,3 +234,57 @@ resource "azurerm_subscription" "import" {
 }
 `, r.basicEnrollmentAccount(data))
 }
+
+func (SubscriptionResource) managementGroup(data acceptance.TestData, managementGroup string) string {
+	billingAccount := os.Getenv("ARM_BILLING_ACCOUNT")
+	billingProfile := os.Getenv("ARM_BILLING_PROFILE")
+	invoiceSection := os.Getenv("ARM_INVOICE_SECTION")
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_billing_mca_account_scope" "test" {
+  billing_account_name = "%[1]s"
+  billing_profile_name = "%[2]s"
+  invoice_section_name = "%[3]s"
+}
+
+resource "azurerm_management_group" "test" {
+  display_name = "acctestmg-%[4]d"
+}
+
+resource "azurerm_management_group" "other" {
+  display_name = "acctestmg-other-%[4]d"
+}
+
+resource "azurerm_subscription" "test" {
+  alias               = "testAcc-%[4]d"
+  subscription_name   = "testAccSubscription %[4]d"
+  billing_scope_id    = data.azurerm_billing_mca_account_scope.test.id
+  management_group_id = azurerm_management_group.%[5]s.id
+}
+`, billingAccount, billingProfile, invoiceSection, data.RandomInteger, managementGroup)
+}
+
+func (SubscriptionResource) cancellationHoldPeriod(data acceptance.TestData) string {
+	billingAccount := os.Getenv("ARM_BILLING_ACCOUNT")
+	enrollmentAccount := os.Getenv("ARM_BILLING_ENROLLMENT_ACCOUNT")
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_billing_enrollment_account_scope" "test" {
+  billing_account_name    = "%s"
+  enrollment_account_name = "%s"
+}
+
+resource "azurerm_subscription" "test" {
+  alias                    = "testAcc-%[3]d"
+  subscription_name        = "testAccSubscription %[3]d"
+  billing_scope_id         = data.azurerm_billing_enrollment_account_scope.test.id
+  cancellation_hold_period = "PT2M"
+}
+`, billingAccount, enrollmentAccount, data.RandomInteger)
+}
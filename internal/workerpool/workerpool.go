@@ -0,0 +1,44 @@
+package workerpool
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Run calls the operation for each of the items, with at most `parallelism` operations running at once.
+//
+// Every item is processed regardless of whether earlier operations failed, and the errors from all of the
+// failed operations are returned - each prefixed with the description of the item returned from `describe`.
+func Run[T any](items []T, parallelism int, describe func(item T) string, operation func(item T) error) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	work := make(chan T, len(items))
+	for _, item := range items {
+		work <- item
+	}
+	close(work)
+
+	var errs *multierror.Error
+	var errsLock sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				if err := operation(item); err != nil {
+					errsLock.Lock()
+					errs = multierror.Append(errs, fmt.Errorf("%s: %+v", describe(item), err))
+					errsLock.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errs.ErrorOrNil()
+}
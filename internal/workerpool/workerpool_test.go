@@ -0,0 +1,92 @@
+package workerpool
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunProcessesEveryItem(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	var lock sync.Mutex
+	processed := make([]int, 0)
+	err := Run(items, 3, describeInt, func(item int) error {
+		lock.Lock()
+		processed = append(processed, item)
+		lock.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+
+	sort.Ints(processed)
+	if fmt.Sprint(processed) != fmt.Sprint(items) {
+		t.Fatalf("expected %v to be processed but got %v", items, processed)
+	}
+}
+
+func TestRunLimitsParallelism(t *testing.T) {
+	for _, parallelism := range []int{-1, 0, 1, 4} {
+		expected := parallelism
+		if expected < 1 {
+			expected = 1
+		}
+
+		var running, maxRunning int32
+		err := Run(make([]int, 20), parallelism, describeInt, func(int) error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				previous := atomic.LoadInt32(&maxRunning)
+				if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+
+		if actual := int(atomic.LoadInt32(&maxRunning)); actual > expected {
+			t.Fatalf("expected at most %d operations to run at once with a parallelism of %d but got %d", expected, parallelism, actual)
+		}
+	}
+}
+
+func TestRunReturnsAllErrors(t *testing.T) {
+	var count int32
+	err := Run([]int{1, 2, 3, 4}, 2, describeInt, func(item int) error {
+		atomic.AddInt32(&count, 1)
+		if item%2 == 0 {
+			return fmt.Errorf("even")
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	if n := atomic.LoadInt32(&count); n != 4 {
+		t.Fatalf("expected every item to be processed but %d were", n)
+	}
+	for _, expected := range []string{"item 2: even", "item 4: even"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected the error to contain %q but got %q", expected, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "item 1") || strings.Contains(err.Error(), "item 3") {
+		t.Fatalf("expected the error to only contain the failed items but got %q", err.Error())
+	}
+}
+
+func describeInt(item int) string {
+	return fmt.Sprintf("item %d", item)
+}